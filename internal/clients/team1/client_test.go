@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -22,6 +23,10 @@ func (h testServerHandle) GetGameConfig() config.ClientConfig {
 	return h.clientGameConfig
 }
 
+func (h testServerHandle) GetForageReturnEstimate(forageType shared.ForageType, contribution shared.Resources, otherContributions map[shared.ClientID]shared.Resources) (foraging.ReturnDistribution, error) {
+	return foraging.ReturnDistribution{}, nil
+}
+
 func MakeTestClient(gamestate gamestate.ClientGameState) client {
 	c := DefaultClient(shared.Team1)
 	c.Initialise(testServerHandle{
@@ -37,14 +37,16 @@ func copySingleRuleMatrix(inp rules.RuleMatrix) rules.RuleMatrix {
 		AuxiliaryVector:   *mat.VecDenseCopyOf(&inp.AuxiliaryVector),
 		Mutable:           inp.Mutable,
 		Link:              copyLink(inp.Link),
+		ExpiryTurn:        inp.ExpiryTurn,
 	}
 }
 
 func copyLink(inp rules.RuleLink) rules.RuleLink {
 	return rules.RuleLink{
-		Linked:     inp.Linked,
-		LinkType:   inp.LinkType,
-		LinkedRule: inp.LinkedRule,
+		Linked:                inp.Linked,
+		LinkType:              inp.LinkType,
+		LinkedRule:            inp.LinkedRule,
+		AdditionalLinkedRules: append([]string(nil), inp.AdditionalLinkedRules...),
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -24,6 +25,10 @@ func (m mockServerReadHandle) GetGameConfig() config.ClientConfig {
 	return m.gameConfig
 }
 
+func (m mockServerReadHandle) GetForageReturnEstimate(forageType shared.ForageType, contribution shared.Resources, otherContributions map[shared.ClientID]shared.Resources) (foraging.ReturnDistribution, error) {
+	return foraging.ReturnDistribution{}, nil
+}
+
 // -----------------------------------------------------------------------------
 
 // clientPrint is a wrapper for team3 Logf function, that only prints when
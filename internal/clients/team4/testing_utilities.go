@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
@@ -47,6 +48,10 @@ func (s fakeServerHandle) GetGameConfig() config.ClientConfig {
 	}
 }
 
+func (s fakeServerHandle) GetForageReturnEstimate(forageType shared.ForageType, contribution shared.Resources, otherContributions map[shared.ClientID]shared.Resources) (foraging.ReturnDistribution, error) {
+	return foraging.ReturnDistribution{}, nil
+}
+
 func registerTestElectionRule() map[string]rules.RuleMatrix {
 	rulesStore := map[string]rules.RuleMatrix{}
 
@@ -5,6 +5,7 @@ package team6
 import (
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -53,3 +54,7 @@ func (s stubServerReadHandle) GetGameState() gamestate.ClientGameState {
 func (s stubServerReadHandle) GetGameConfig() config.ClientConfig {
 	return s.gameConfig
 }
+
+func (s stubServerReadHandle) GetForageReturnEstimate(forageType shared.ForageType, contribution shared.Resources, otherContributions map[shared.ClientID]shared.Resources) (foraging.ReturnDistribution, error) {
+	return foraging.ReturnDistribution{}, nil
+}
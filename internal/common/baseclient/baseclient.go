@@ -6,6 +6,7 @@ import (
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/roles"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
@@ -39,6 +40,9 @@ type Client interface {
 	ReceiveIntendedContribution(receivedIntendedContributions shared.ReceivedIntendedContributionDict)
 
 	//Foraging
+	ProposeHuntingParty() shared.HuntingPartyProposal
+	JoinHuntingParty(invitations shared.HuntingPartyProposalDict) (shared.ClientID, bool)
+	HuntingPartyUpdate(parties []shared.HuntingParty)
 	DecideForage() (shared.ForageDecision, error)
 	ForageUpdate(shared.ForageDecision, shared.Resources, uint)
 
@@ -71,6 +75,14 @@ type Client interface {
 type ServerReadHandle interface {
 	GetGameState() gamestate.ClientGameState
 	GetGameConfig() config.ClientConfig
+	// GetForageReturnEstimate estimates the distribution of the resources returned for contributing to a foraging
+	// session of the given type, alongside the known contributions of other islands.
+	// Returns an error if the oracle is not visible to clients.
+	GetForageReturnEstimate(
+		forageType shared.ForageType,
+		contribution shared.Resources,
+		otherContributions map[shared.ClientID]shared.Resources,
+	) (foraging.ReturnDistribution, error)
 }
 
 // NewClient produces a new client with the BaseClient already implemented.
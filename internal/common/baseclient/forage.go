@@ -21,3 +21,24 @@ func (c *BaseClient) DecideForage() (shared.ForageDecision, error) {
 // analyse their returns - resources returned to them, as well as number of fish/deer caught.
 func (c *BaseClient) ForageUpdate(initialDecision shared.ForageDecision, resourceReturn shared.Resources, numberCaught uint) {
 }
+
+// ProposeHuntingParty allows clients to propose a binding hunting party to other islands before foraging.
+// Return a proposal with no invitees to not propose a party. Proposals for farming are ignored.
+// OPTIONAL. This placeholder implementation does not propose a party.
+func (c *BaseClient) ProposeHuntingParty() shared.HuntingPartyProposal {
+	return shared.HuntingPartyProposal{}
+}
+
+// JoinHuntingParty is called with the hunting party proposals that invite this client, keyed by proposer.
+// Return the proposer of the party to join and true to accept it, or false to decline all invitations.
+// Islands that proposed a party themselves are not invited to other parties.
+// OPTIONAL. This placeholder implementation declines all invitations.
+func (c *BaseClient) JoinHuntingParty(invitations shared.HuntingPartyProposalDict) (shared.ClientID, bool) {
+	return 0, false
+}
+
+// HuntingPartyUpdate is called by the server with the hunting parties formed in this turn, before foraging decisions
+// are made. Members of a party will forage in the party's forage type with at least its minimum commitment,
+// regardless of their foraging decision.
+func (c *BaseClient) HuntingPartyUpdate(parties []shared.HuntingParty) {
+}
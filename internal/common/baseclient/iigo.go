@@ -1,6 +1,8 @@
 package baseclient
 
 import (
+	"sort"
+
 	"github.com/SOMAS2020/SOMAS2020/internal/common/roles"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
@@ -160,22 +162,36 @@ func (c *BaseClient) CheckCompliance(variable rules.VariableFieldName) bool {
 	return complianceCheck
 }
 
-// GetRecommendation provides clients with a way of working out (in reasonably simple cases) what value
-// a given variable must be to ensure compliance. Recomendations are only made for unlinked rules!
+// GetRecommendation provides clients with a way of working out what value a given variable must be to ensure
+// compliance with all rules in play that depend on it, changing it as little as possible. Rules whose variables are
+// not all in the local variable cache are disregarded.
 // OPTIONAL
 func (c *BaseClient) GetRecommendation(variable rules.VariableFieldName) (compliantValue rules.VariableValuePair, success bool) {
-	rulesAffected, found := rules.PickUpRulesByVariable(variable, c.ServerReadHandle.GetGameState().RulesInfo.CurrentRulesInPlay, c.LocalVariableCache)
+	rulesInPlay := c.ServerReadHandle.GetGameState().RulesInfo.CurrentRulesInPlay
+	rulesAffected, found := rules.PickUpRulesByVariable(variable, rulesInPlay, c.LocalVariableCache)
 	if !found {
 		return c.LocalVariableCache[variable], false
 	}
+	rulesToComply := []string{}
 	for _, ruleName := range rulesAffected {
-		ruleToConsider := c.ServerReadHandle.GetGameState().RulesInfo.CurrentRulesInPlay[ruleName]
-		if !ruleToConsider.Link.Linked {
-			newMap, ok := rules.ComplianceRecommendation(ruleToConsider, c.LocalVariableCache)
-			if ok {
-				return newMap[variable], ok
-			}
+		if c.hasAllVariables(rulesInPlay[ruleName]) {
+			rulesToComply = append(rulesToComply, ruleName)
+		}
+	}
+	sort.Strings(rulesToComply)
+	recommendation, err := rules.MinimalComplianceRecommendation(rulesToComply, rulesInPlay, c.LocalVariableCache, map[rules.VariableFieldName]bool{variable: true})
+	if err != nil {
+		c.Logf("Could not recommend a value of %v: %v", variable, err)
+		return c.LocalVariableCache[variable], false
+	}
+	return recommendation[variable], true
+}
+
+func (c *BaseClient) hasAllVariables(rule rules.RuleMatrix) bool {
+	for _, variable := range rule.RequiredVariables {
+		if _, ok := c.LocalVariableCache[variable]; !ok {
+			return false
 		}
 	}
-	return c.LocalVariableCache[variable], false
+	return true
 }
@@ -42,7 +42,8 @@ type ClientIIGOConfig struct {
 type ClientDisasterConfig struct {
 	CommonpoolThreshold SelectivelyVisibleResources
 	DisasterPeriod      SelectivelyVisibleUint
-	StochasticDisasters SelectivelyVisibleBool // if true, period between disasters becomes random. If false, it will be consistent (deterministic)
+	StochasticDisasters SelectivelyVisibleBool            // if true, period between disasters becomes random. If false, it will be consistent (deterministic)
+	MitigationCurve     SelectivelyVisibleMitigationCurve // maps CP level to the multiplier applied to disaster damage
 }
 
 // GetClientConfig gets ClientConfig.
@@ -71,6 +72,10 @@ func (c DisasterConfig) GetClientDisasterConfig() ClientDisasterConfig {
 			c.StochasticPeriod,
 			c.StochasticPeriodVisible,
 		),
+		MitigationCurve: getSelectivelyVisibleMitigationCurve(
+			c.MitigationCurve,
+			c.MitigationCurveVisible,
+		),
 	}
 }
 
@@ -3,6 +3,8 @@ package config
 import (
 	"reflect"
 	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
 
 // Tests GetClientDisasterConfig too
@@ -26,6 +28,11 @@ func TestGetClientConfig(t *testing.T) {
 					PeriodVisible:              true,
 					StochasticPeriod:           true,
 					StochasticPeriodVisible:    true,
+					MitigationCurve: MitigationCurve{
+						Type:          shared.LogisticMitigation,
+						MinMultiplier: 0.3,
+					},
+					MitigationCurveVisible: true,
 				},
 				IIGOConfig: IIGOConfig{
 					GetRuleForSpeakerActionCost:        50,
@@ -66,6 +73,14 @@ func TestGetClientConfig(t *testing.T) {
 						Value: true,
 						Valid: true,
 					},
+					MitigationCurve: SelectivelyVisibleMitigationCurve{
+						Value: MitigationCurve{
+							Type:          shared.LogisticMitigation,
+							Points:        []shared.MitigationPoint{},
+							MinMultiplier: 0.3,
+						},
+						Valid: true,
+					},
 				},
 				IIGOClientConfig: IIGOConfig{
 					GetRuleForSpeakerActionCost:        50,
@@ -40,6 +40,9 @@ type Config struct {
 
 	// Wrapped IIGO config
 	IIGOConfig IIGOConfig
+
+	// Wrapped IIFO config
+	IIFOConfig IIFOConfig
 }
 
 // DeerHuntConfig is a subset of foraging config
@@ -56,8 +59,14 @@ type DeerHuntConfig struct {
 	ThetaMax              float64                             // Bernoulli prob of catching deer when running population = carrying capacity (max population)
 
 	// Deer Population
-	MaxDeerPopulation     uint    // Max possible deer population.
-	DeerGrowthCoefficient float64 // Scaling parameter used in the population model. Larger coeff => deer pop. regenerates faster
+	MaxDeerPopulation     uint                       // Max possible deer population.
+	DeerGrowthCoefficient float64                    // Scaling parameter used in the population model. Larger coeff => deer pop. regenerates faster
+	DeerPopulationModel   shared.PopulationModelType // growth model governing the deer population over time
+	DeerAlleeThreshold    float64                    // critical population below which deer collapse in AlleePopulationModel
+
+	// Cooperative hunting (stag hunt)
+	StagHuntRule        shared.StagHuntRule // how the number of participants affects the chance of catching deer
+	MinHuntParticipants uint                // number of participants K needed for a full chance of catching deer
 }
 
 // FishingConfig is a subset of foraging config
@@ -65,30 +74,97 @@ type FishingConfig struct {
 	// Fishing
 	MaxFishPerHunt        uint                                // Max possible number of fish on a single fishing expedition
 	IncrementalInputDecay float64                             // Determines decay of incremental input cost of catching additional fish
-	Mean                  float64                             // mean of normally distributed fish size
-	Variance              float64                             // variance of normally distributed fish size
+	Mean                  float64                             // mean of fish size
+	Variance              float64                             // variance of fish size
 	InputScaler           float64                             // scalar value that adjusts input resources to be in a range that is commensurate with cost of living, salaries etc.
 	OutputScaler          float64                             // scalar value that adjusts returns to be in a range that is commensurate with cost of living, salaries etc.
 	DistributionStrategy  shared.ResourceDistributionStrategy // basis on which returns are split amongst fishermen
+	FishSizeDistribution  shared.FishSizeDistribution         // distribution of the size of each fish caught
+
+	// Fish Population
+	MaxFishPopulation uint    // Max possible fish population (carrying capacity). 0 => fish stock is unlimited and never depletes
+	FishGrowthRate    float64 // Intrinsic growth rate `r` of the logistic population model. Larger rate => fish pop. regenerates faster
+	ThetaCritical     float64 // Bernoulli prob of catching a fish when running population = max fish per hunt
+	ThetaMax          float64 // Bernoulli prob of catching a fish when running population = carrying capacity (max population)
+}
+
+// FarmingConfig is a subset of foraging config
+type FarmingConfig struct {
+	MaturityTurns       uint                   // Number of turns crops grow for before they are harvested
+	GrowthCurve         shared.FarmGrowthCurve // How crops grow over the turns until they mature
+	YieldMultiplier     float64                // Return per resource invested if crops grow undamaged until maturity
+	DisasterSensitivity float64                // Fraction of standing crops destroyed per unit of disaster magnitude felt by the owner island
 }
 
 // DisasterConfig captures disaster-specific config
 type DisasterConfig struct {
-	XMin, XMax, YMin, YMax      shared.Coordinate     // [min, max] x,y bounds of archipelago (bounds for possible disaster)
-	Period                      uint                  // Period T between disasters in deterministic case and E[T] in stochastic case.
-	SpatialPDFType              shared.SpatialPDFType // Set x,y prob. distribution of the disaster's epicentre (more post MVP)
-	MagnitudeLambda             float64               // Exponential rate param for disaster magnitude
-	MagnitudeResourceMultiplier float64               // multiplier to map disaster magnitude to CP resource deductions
-	CommonpoolThreshold         shared.Resources      // threshold for min CP resources for disaster mitigation
-	StochasticPeriod            bool                  // if true, period between disasters becomes random. If false, it will be consistent (deterministic)
-	CommonpoolThresholdVisible  bool                  // whether CommonpoolThreshold is visible to clients
-	PeriodVisible               bool                  // whether DisasterPeriod should be visible to clients
-	StochasticPeriodVisible     bool                  // whether StochasticPeriod should be visible to clients
+	XMin, XMax, YMin, YMax      shared.Coordinate      // [min, max] x,y bounds of archipelago (bounds for possible disaster)
+	Period                      uint                   // Period T between disasters in deterministic case and E[T] in stochastic case.
+	SpatialPDFType              shared.SpatialPDFType  // Set x,y prob. distribution of the disaster's epicentre (more post MVP)
+	MagnitudeLambda             float64                // Exponential rate param for disaster magnitude
+	AttenuationType             shared.AttenuationType // function mapping distance from epicentre to magnitude felt by an island
+	AttenuationScale            float64                // length scale for ExponentialDecay and Gaussian attenuation, radius for HardCutoff
+	MagnitudeResourceMultiplier float64                // multiplier to map disaster magnitude to CP resource deductions
+	CommonpoolThreshold         shared.Resources       // threshold for min CP resources for disaster mitigation
+	StochasticPeriod            bool                   // if true, period between disasters becomes random. If false, it will be consistent (deterministic)
+	CommonpoolThresholdVisible  bool                   // whether CommonpoolThreshold is visible to clients
+	PeriodVisible               bool                   // whether DisasterPeriod should be visible to clients
+	StochasticPeriodVisible     bool                   // whether StochasticPeriod should be visible to clients
+
+	// Common pool mitigation
+	MitigationCurve        MitigationCurve // maps CP level to the multiplier applied to disaster damage
+	MitigationCurveVisible bool            // whether MitigationCurve is visible to clients
+
+	// Archipelago geography
+	IslandLayout        shared.IslandLayout                 // arrangement of islands within the archipelago bounds
+	IslandMinSeparation float64                             // min distance between any two islands in ScatterLayout
+	IslandPositions     map[shared.ClientID]shared.Position // island co-ordinates in ExplicitLayout
+
+	// Temporal disaster process
+	DisasterProcess shared.DisasterProcess     // process deciding in which turns disasters occur
+	MarkovProcess   MarkovProcessConfig        // regime parameters for MarkovModulatedProcess
+	HawkesProcess   HawkesProcessConfig        // intensity parameters for HawkesProcess
+	ReplaySchedule  []shared.ScheduledDisaster // disasters replayed in ReplayProcess, sorted by turn
+
+	// Ecological effects on foraging stocks
+	DeerStockSensitivity  float64 // fraction of the deer population killed per unit of magnitude felt by the islands (on average)
+	FishStockSensitivity  float64 // fraction of the fish population killed per unit of magnitude felt by the islands (on average)
+	CatchProbabilityShock float64 // reduction in the multiplier on catch probabilities per unit of magnitude felt by the islands
+	CatchShockDuration    uint    // number of turns for which catch probabilities are reduced after a disaster
+}
+
+// MarkovProcessConfig parametrises a disaster process that switches between a calm and a stormy regime.
+// Within a regime, disasters are a Bernoulli process with E[T] equal to the regime's period.
+type MarkovProcessConfig struct {
+	CalmPeriod       uint    // E[T] between disasters in the calm regime
+	StormyPeriod     uint    // E[T] between disasters in the stormy regime
+	CalmToStormyProb float64 // prob. of switching from the calm to the stormy regime at the end of a turn
+	StormyToCalmProb float64 // prob. of switching from the stormy to the calm regime at the end of a turn
+}
+
+// HawkesProcessConfig parametrises a self-exciting disaster process. The intensity in a turn is
+// BaseRate + sum over past disasters of Excitation * magnitude * exp(-Decay * turns since disaster),
+// and a disaster occurs with prob. 1 - exp(-intensity).
+type HawkesProcessConfig struct {
+	BaseRate   float64 // background intensity
+	Excitation float64 // intensity added per unit magnitude of a disaster
+	Decay      float64 // per-turn exponential decay rate of the added intensity
+}
+
+// MitigationCurve maps the common pool level to the multiplier applied to disaster damage
+type MitigationCurve struct {
+	Type              shared.MitigationCurveType
+	Points            []shared.MitigationPoint // (CP level, multiplier) points for PiecewiseLinear and StepTable curves
+	LogisticMidpoint  shared.Resources         // CP level at which the logistic multiplier is halfway between 1 and MinMultiplier
+	LogisticSteepness float64                  // steepness of the logistic curve around its midpoint
+	MinMultiplier     float64                  // lower asymptote of the logistic curve (max. mitigation)
 }
 
 type IIGOConfig struct {
 	// IIGO term lengths (set by config)
 	IIGOTermLengths map[shared.Role]uint
+	// Seed of the tie-breaking ranking of candidates in IIGO elections
+	ElectionTieBreakSeed int64
 	// Executive branch
 	GetRuleForSpeakerActionCost        shared.Resources
 	BroadcastTaxationActionCost        shared.Resources
@@ -113,12 +189,36 @@ type IIGOConfig struct {
 	AnnounceVotingResultActionCost shared.Resources
 	UpdateRulesActionCost          shared.Resources
 	AppointNextJudgeActionCost     shared.Resources
+	// Rule vote decision procedure
+	RuleVoteQuorum             float64                             // fraction of alive islands that must cast a non-abstaining ballot for a rule vote to be valid. Initial value of rule "rule_vote_quorum"
+	RuleVoteSupermajority      float64                             // fraction of the cast ballot weight needed in favour by a SupermajorityRuleVote. Initial value of rule "rule_vote_supermajority"
+	RuleVoteProcedure          shared.RuleVoteProcedure            // procedure of votes on mutable rules
+	ImmutableRuleVoteProcedure shared.RuleVoteProcedure            // procedure of votes on immutable rules
+	RuleVoteProcedureOverrides map[string]shared.RuleVoteProcedure // procedure of votes on specific rules, taking precedence over the above
+	RejectConflictingRules     bool                                // if true, a rule voted in that cannot be satisfied together with the rules in play is not pulled into play
 
 	StartWithRulesInPlay bool
 }
 
+// IIFOConfig captures IIFO-specific config
+type IIFOConfig struct {
+	// Disaster prediction scoring. A prediction is deemed accurate if all its errors are within these tolerances
+	PredictionSpatialTolerance   float64 // max distance between predicted and actual epicentre
+	PredictionMagnitudeTolerance float64 // max abs. difference between predicted and actual magnitude
+	PredictionTimeTolerance      uint    // max number of turns between predicted and actual disaster turn
+}
+
 // ForagingConfig captures foraging-specific config
 type ForagingConfig struct {
 	DeerHuntConfig DeerHuntConfig
 	FishingConfig  FishingConfig
+	FarmingConfig  FarmingConfig
+
+	// Splitting of foraging returns
+	RankingBasis shared.RankingBasis // ranking of participants used by RankProportionalSplit
+	MinimumShare float64             // fraction of total return guaranteed to each participant by InputProportionalWithMinimumShareSplit
+
+	// Expected-return oracle
+	ReturnOracleVisible bool // whether clients can query the expected return of a hypothetical foraging contribution
+	ReturnOracleSamples uint // number of foraging sessions sampled to estimate the return distribution
 }
@@ -86,3 +86,22 @@ func getSelectivelyVisibleInt(value int, valid bool) SelectivelyVisibleInt {
 		Valid: valid,
 	}
 }
+
+// SelectivelyVisibleMitigationCurve represents a wrapped MitigationCurve whose value is valid only if the Valid flag is set to true
+type SelectivelyVisibleMitigationCurve struct {
+	Value MitigationCurve
+	Valid bool
+}
+
+func getSelectivelyVisibleMitigationCurve(value MitigationCurve, valid bool) SelectivelyVisibleMitigationCurve {
+	var res MitigationCurve
+	if valid {
+		res = value
+		res.Points = make([]shared.MitigationPoint, len(value.Points))
+		copy(res.Points, value.Points) // prevent clients from modifying the server's config
+	}
+	return SelectivelyVisibleMitigationCurve{
+		Value: res,
+		Valid: valid,
+	}
+}
@@ -111,3 +111,47 @@ func TestGetSelectivelyVisibleInteger(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSelectivelyVisibleMitigationCurve(t *testing.T) {
+	origVal := MitigationCurve{
+		Type:   shared.StepTableMitigation,
+		Points: []shared.MitigationPoint{{Level: 10, Multiplier: 0.5}},
+	}
+	cases := []struct {
+		name  string
+		valid bool
+		want  SelectivelyVisibleMitigationCurve
+	}{
+		{
+			name:  "valid",
+			valid: true,
+			want: SelectivelyVisibleMitigationCurve{
+				Value: origVal,
+				Valid: true,
+			},
+		},
+		{
+			name:  "not valid",
+			valid: false,
+			want: SelectivelyVisibleMitigationCurve{
+				Value: MitigationCurve{},
+				Valid: false,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := getSelectivelyVisibleMitigationCurve(origVal, tc.valid)
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+			if tc.valid {
+				got.Value.Points[0].Multiplier = 1
+				if origVal.Points[0].Multiplier != 0.5 {
+					t.Errorf("modifying visible curve modified the original config")
+				}
+			}
+		})
+	}
+}
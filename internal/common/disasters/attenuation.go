@@ -0,0 +1,45 @@
+package disasters
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// attenuate returns the magnitude felt at a given distance from the epicentre of a disaster of the given
+// (peak) magnitude. The shape of the decay is set by dConf.AttenuationType and, where applicable, dConf.AttenuationScale.
+// The effect never exceeds the peak magnitude.
+func attenuate(magnitude shared.Magnitude, distance float64, dConf config.DisasterConfig) shared.Magnitude {
+	scale := dConf.AttenuationScale
+
+	switch dConf.AttenuationType {
+	case shared.InverseSquare:
+		return magnitude / math.Max(distance*distance, 1) // cap at 1 to prevent divide by zero -> inf
+	case shared.ExponentialDecay:
+		if scale <= 0 {
+			return pointEffect(magnitude, distance)
+		}
+		return magnitude * math.Exp(-distance/scale)
+	case shared.Gaussian:
+		if scale <= 0 {
+			return pointEffect(magnitude, distance)
+		}
+		return magnitude * math.Exp(-(distance*distance)/(2*scale*scale))
+	case shared.HardCutoff:
+		if distance <= scale {
+			return magnitude
+		}
+		return 0
+	default: // shared.InverseDistance
+		return magnitude / math.Max(distance, 1) // cap at 1 to prevent divide by zero -> inf
+	}
+}
+
+// pointEffect is the limiting case of a decay with zero length scale: only the epicentre is affected
+func pointEffect(magnitude shared.Magnitude, distance float64) shared.Magnitude {
+	if distance == 0 {
+		return magnitude
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package disasters
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestAttenuate(t *testing.T) {
+	magnitude := 2.0
+	scale := 2.0
+
+	var tests = []struct {
+		name     string
+		aType    shared.AttenuationType
+		distance float64
+		want     float64
+	}{
+		{"inverse at epicentre", shared.InverseDistance, 0, magnitude},
+		{"inverse within unit distance", shared.InverseDistance, 0.5, magnitude},
+		{"inverse", shared.InverseDistance, 4, magnitude / 4},
+		{"inverse square at epicentre", shared.InverseSquare, 0, magnitude},
+		{"inverse square", shared.InverseSquare, 4, magnitude / 16},
+		{"exponential at epicentre", shared.ExponentialDecay, 0, magnitude},
+		{"exponential", shared.ExponentialDecay, 4, magnitude * math.Exp(-2)},
+		{"gaussian at epicentre", shared.Gaussian, 0, magnitude},
+		{"gaussian", shared.Gaussian, 4, magnitude * math.Exp(-2)},
+		{"cutoff inside radius", shared.HardCutoff, 1.5, magnitude},
+		{"cutoff on radius", shared.HardCutoff, 2, magnitude},
+		{"cutoff outside radius", shared.HardCutoff, 2.5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dConf := config.DisasterConfig{AttenuationType: tt.aType, AttenuationScale: scale}
+			got := attenuate(magnitude, tt.distance, dConf)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttenuateZeroScale(t *testing.T) {
+	for _, aType := range []shared.AttenuationType{shared.ExponentialDecay, shared.Gaussian, shared.HardCutoff} {
+		t.Run(aType.String(), func(t *testing.T) {
+			dConf := config.DisasterConfig{AttenuationType: aType, AttenuationScale: 0}
+			if got := attenuate(1, 0, dConf); got != 1 {
+				t.Errorf("expected full magnitude at epicentre, got %v", got)
+			}
+			if got := attenuate(1, 0.1, dConf); got != 0 {
+				t.Errorf("expected no effect away from epicentre, got %v", got)
+			}
+		})
+	}
+}
+
+func TestAttenuationMonotonic(t *testing.T) {
+	for i := 0; i < int(attenuationTypeCount()); i++ {
+		aType := shared.AttenuationType(i)
+		t.Run(aType.String(), func(t *testing.T) {
+			dConf := config.DisasterConfig{AttenuationType: aType, AttenuationScale: 3}
+			prev := math.Inf(1)
+			for d := 0.0; d <= 10; d += 0.25 {
+				effect := attenuate(1, d, dConf)
+				if effect > prev || effect > 1 || effect < 0 {
+					t.Fatalf("effect %v at distance %v is not within [0, %v]", effect, d, prev)
+				}
+				prev = effect
+			}
+		})
+	}
+}
+
+// attenuationTypeCount finds the number of valid attenuation types using the shared parser
+func attenuationTypeCount() uint {
+	n := 0
+	for {
+		if _, err := shared.ParseAttenuationType(n); err != nil {
+			return uint(n)
+		}
+		n++
+	}
+}
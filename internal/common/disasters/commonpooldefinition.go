@@ -1,6 +1,8 @@
 package disasters
 
 import (
+	"math"
+
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -9,9 +11,8 @@ import (
 // 	+ If cp have enough resource to fully mitigate the disaster, island's personal resources won't be affected
 //	+ Else, island will be affected with leftover damaged. Each island receives proportional helps from cp with respect to the total damage on 6 islands
 //	+ EX: if island 1 gets hit the most then cp will help island 1 more than the rest
-//	+ There is a threshold T that incentivizes contributing to the cp from islands
-//	+ If threshold T is met, which mean islands have prepared well before the disaster hit, the damage that disaster have on cp and islands will be half
-//	+ If not, cp and islands will feel the full effect of disaster
+//	+ The damage that the disaster has on cp and islands is scaled by a multiplier that depends on how well the cp was prepared before the disaster hit
+//	+ The multiplier is given by DisasterConfig.MitigationCurve. By default, if cp meets threshold T the damage will be half, if not, cp and islands will feel the full effect of disaster
 //	+ For now, each 1 effect of disaster is equivalent to DisasterConfig.MagnitudeResourceMulitplier resources
 func (e Environment) MitigateDisaster(cpResources shared.Resources, effects DisasterEffects, dConf config.DisasterConfig) map[shared.ClientID]shared.Magnitude {
 	// compute total effect of disaster on 6 islands
@@ -40,8 +41,54 @@ func GetDisasterResourceImpact(cpResources shared.Resources, effects DisasterEff
 		totalEffect = totalEffect + effect
 	}
 
-	if cpResources >= dConf.CommonpoolThreshold { //exceeds cp threshold
-		return shared.Resources((totalEffect / 2) * dConf.MagnitudeResourceMultiplier) // better prep means less effect
+	multiplier := EvaluateMitigationCurve(dConf.MitigationCurve, dConf.CommonpoolThreshold, cpResources) // better prep means less effect
+	return shared.Resources(totalEffect * multiplier * dConf.MagnitudeResourceMultiplier)
+}
+
+// EvaluateMitigationCurve returns the multiplier applied to disaster damage for a given cp level (1 => no mitigation).
+// cpThreshold is only used by the ThresholdMitigation curve. Clients can evaluate the curve in ClientDisasterConfig with this.
+func EvaluateMitigationCurve(curve config.MitigationCurve, cpThreshold shared.Resources, cpResources shared.Resources) float64 {
+	switch curve.Type {
+	case shared.PiecewiseLinearMitigation:
+		return piecewiseLinearMultiplier(curve.Points, cpResources)
+	case shared.LogisticMitigation:
+		x := float64(cpResources - curve.LogisticMidpoint)
+		return curve.MinMultiplier + (1-curve.MinMultiplier)/(1+math.Exp(curve.LogisticSteepness*x))
+	case shared.StepTableMitigation:
+		return stepTableMultiplier(curve.Points, cpResources)
+	default: // shared.ThresholdMitigation
+		return stepTableMultiplier([]shared.MitigationPoint{{Level: cpThreshold, Multiplier: 0.5}}, cpResources)
+	}
+}
+
+// piecewiseLinearMultiplier interpolates between points sorted by level. Beyond the end points, the multiplier of the
+// nearest end point applies. No points => no mitigation.
+func piecewiseLinearMultiplier(points []shared.MitigationPoint, cpResources shared.Resources) float64 {
+	if len(points) == 0 {
+		return 1
+	}
+	if cpResources <= points[0].Level {
+		return points[0].Multiplier
+	}
+	for i := 1; i < len(points); i++ {
+		lo, hi := points[i-1], points[i]
+		if cpResources <= hi.Level {
+			frac := float64((cpResources - lo.Level) / (hi.Level - lo.Level))
+			return lo.Multiplier + frac*(hi.Multiplier-lo.Multiplier)
+		}
+	}
+	return points[len(points)-1].Multiplier
+}
+
+// stepTableMultiplier returns the multiplier of the highest point (sorted by level) whose level the cp meets.
+// Below the first point there is no mitigation.
+func stepTableMultiplier(points []shared.MitigationPoint, cpResources shared.Resources) float64 {
+	multiplier := 1.0
+	for _, p := range points {
+		if cpResources < p.Level {
+			break
+		}
+		multiplier = p.Multiplier
 	}
-	return shared.Resources(totalEffect * dConf.MagnitudeResourceMultiplier) // no prep so goodluck
+	return multiplier
 }
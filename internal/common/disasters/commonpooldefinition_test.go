@@ -0,0 +1,75 @@
+package disasters
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestEvaluateMitigationCurve(t *testing.T) {
+	points := []shared.MitigationPoint{
+		{Level: 100, Multiplier: 0.8},
+		{Level: 200, Multiplier: 0.4},
+	}
+	threshold := shared.Resources(150)
+
+	var tests = []struct {
+		curve config.MitigationCurve
+		cp    shared.Resources
+		want  float64
+	}{
+		// legacy threshold behaviour
+		{config.MitigationCurve{Type: shared.ThresholdMitigation}, 149, 1},
+		{config.MitigationCurve{Type: shared.ThresholdMitigation}, 150, 0.5},
+		{config.MitigationCurve{Type: shared.ThresholdMitigation}, 1000, 0.5},
+		// piecewise linear
+		{config.MitigationCurve{Type: shared.PiecewiseLinearMitigation, Points: points}, 0, 0.8},
+		{config.MitigationCurve{Type: shared.PiecewiseLinearMitigation, Points: points}, 100, 0.8},
+		{config.MitigationCurve{Type: shared.PiecewiseLinearMitigation, Points: points}, 150, 0.6},
+		{config.MitigationCurve{Type: shared.PiecewiseLinearMitigation, Points: points}, 175, 0.5},
+		{config.MitigationCurve{Type: shared.PiecewiseLinearMitigation, Points: points}, 300, 0.4},
+		{config.MitigationCurve{Type: shared.PiecewiseLinearMitigation}, 300, 1},
+		// step table
+		{config.MitigationCurve{Type: shared.StepTableMitigation, Points: points}, 99, 1},
+		{config.MitigationCurve{Type: shared.StepTableMitigation, Points: points}, 100, 0.8},
+		{config.MitigationCurve{Type: shared.StepTableMitigation, Points: points}, 199, 0.8},
+		{config.MitigationCurve{Type: shared.StepTableMitigation, Points: points}, 200, 0.4},
+		// logistic
+		{config.MitigationCurve{Type: shared.LogisticMitigation, LogisticMidpoint: 100, LogisticSteepness: 0.1, MinMultiplier: 0.2}, 100, 0.6},
+		{config.MitigationCurve{Type: shared.LogisticMitigation, LogisticMidpoint: 100, LogisticSteepness: 0.1, MinMultiplier: 0.2}, 1000, 0.2},
+		{config.MitigationCurve{Type: shared.LogisticMitigation, LogisticMidpoint: 100, LogisticSteepness: 0.1, MinMultiplier: 0.2}, -1000, 1},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v at %v", tt.curve.Type, tt.cp), func(t *testing.T) {
+			got := EvaluateMitigationCurve(tt.curve, threshold, tt.cp)
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMitigationHasMarginalValue(t *testing.T) {
+	effects := DisasterEffects{Absolute: map[shared.ClientID]shared.Magnitude{shared.Team1: 1, shared.Team2: 0.5}}
+	dConf := config.DisasterConfig{
+		MagnitudeResourceMultiplier: 100,
+		MitigationCurve: config.MitigationCurve{
+			Type:   shared.PiecewiseLinearMitigation,
+			Points: []shared.MitigationPoint{{Level: 0, Multiplier: 1}, {Level: 100, Multiplier: 0.25}},
+		},
+	}
+	prev := GetDisasterResourceImpact(0, effects, dConf)
+	if prev != 150 {
+		t.Errorf("Expected unmitigated impact of 150, got %v", prev)
+	}
+	for cp := shared.Resources(10); cp <= 100; cp += 10 {
+		impact := GetDisasterResourceImpact(cp, effects, dConf)
+		if impact >= prev {
+			t.Errorf("Expected impact to decrease with each CP contribution. Got %v at CP %v, %v at CP %v", impact, cp, prev, cp-10)
+		}
+		prev = impact
+	}
+}
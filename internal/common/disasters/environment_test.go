@@ -20,7 +20,10 @@ func TestSamplingOfCertainties(t *testing.T) {
 		MagnitudeLambda:  1.0,
 		StochasticPeriod: true,
 	}
-	env := InitEnvironment(clientIDs, disasterConf)
+	env, err := InitEnvironment(clientIDs, disasterConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
 	updatedEnv := env.SampleForDisaster(disasterConf, 1)
 	if updatedEnv.LastDisasterReport.Magnitude == 0.0 {
 		t.Error("No disaster recorded despite global prob. set to one")
@@ -52,7 +55,10 @@ func TestDeterministicPeriod(t *testing.T) {
 		StochasticPeriod: false,
 	}
 	clientIDs := []shared.ClientID{shared.Team1, shared.Team2} // arbitrarily chosen for test
-	env := InitEnvironment(clientIDs, disasterConf)
+	env, err := InitEnvironment(clientIDs, disasterConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
 	nDisasters := uint(0)
 	for i := uint(1); i <= nTurns; i++ {
 		env = env.SampleForDisaster(disasterConf, uint(i))
@@ -81,7 +87,10 @@ func TestDisasterEffects(t *testing.T) {
 		MagnitudeResourceMultiplier: 100,
 	}
 
-	env := InitEnvironment(clientIDs, disasterConf)
+	env, err := InitEnvironment(clientIDs, disasterConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
 	env.LastDisasterReport = DisasterReport{Magnitude: 1.0, X: env.Geography.XMax, Y: 0} // right on team 3
 
 	// test complete mitigation
@@ -18,6 +18,7 @@ type IslandLocationInfo struct {
 type ArchipelagoGeography struct {
 	Islands                map[shared.ClientID]IslandLocationInfo
 	XMin, XMax, YMin, YMax shared.Coordinate
+	Layout                 shared.IslandLayout // arrangement used to place the islands
 }
 
 // disasterParameters encapsulates a disaster's information - when and how it occurs. Disaster occurring is a Bernoulli random var with `p`=GlobalProb
@@ -46,9 +47,10 @@ type DisasterEffects struct {
 type Environment struct {
 	Geography          ArchipelagoGeography
 	LastDisasterReport DisasterReport
+	Process            ProcessState // memory of the temporal disaster process
 }
 
-// SampleForDisaster samples the stochastic disaster process to see if a disaster occurred
+// SampleForDisaster samples the disaster process (set by DisasterConfig.DisasterProcess) to see if a disaster occurred
 func (e Environment) SampleForDisaster(dConf config.DisasterConfig, turn uint) Environment {
 	// spatial distr info
 	pdfX := distuv.Uniform{Min: e.Geography.XMin, Max: e.Geography.XMax}
@@ -58,35 +60,28 @@ func (e Environment) SampleForDisaster(dConf config.DisasterConfig, turn uint) E
 
 	dR := DisasterReport{Magnitude: 0, X: -1, Y: -1} // default: no disaster. Zero magnitude with arb co-ords
 
-	if dConf.StochasticPeriod {
-		// if T is the disaster period (time between occurrences), we need:
-		// E[T] = T (stochastic and deterministic cases respectively). Since
-		// T is a geometric RV in the stochastic case, p = 1/E[T]
-		p := 1 / float64(dConf.Period)
-		pdfGlobal := distuv.Bernoulli{P: p} // Bernoulli RV where `P` = P(X=1)
-
-		if pdfGlobal.Rand() == 1.0 { // D Day
-			dR = DisasterReport{Magnitude: pdfMag.Rand(), X: pdfX.Rand(), Y: pdfY.Rand()}
-		}
-	} else {
-		if turn%uint(dConf.Period) == 0 && turn > 0 {
-			dR = DisasterReport{Magnitude: pdfMag.Rand(), X: pdfX.Rand(), Y: pdfY.Rand()}
+	if dConf.DisasterProcess == shared.ReplayProcess {
+		if d, ok := scheduledDisaster(dConf.ReplaySchedule, turn); ok && d.Magnitude > 0 {
+			dR = DisasterReport{Magnitude: d.Magnitude, X: d.X, Y: d.Y}
 		}
+	} else if e.disasterOccurs(dConf, turn) { // D Day
+		dR = DisasterReport{Magnitude: pdfMag.Rand(), X: pdfX.Rand(), Y: pdfY.Rand()}
 	}
 
+	e.Process = e.Process.nextProcessState(dConf, dR.Magnitude)
 	e.LastDisasterReport = dR // record last report in env state
 	return e                  // return same env back but with updated disaster report
 }
 
-func (e Environment) computeUnmitigatedDisasterEffects() DisasterEffects {
+func (e Environment) computeUnmitigatedDisasterEffects(dConf config.DisasterConfig) DisasterEffects {
 	individualEffect := map[shared.ClientID]shared.Magnitude{}
 	proportionalEffect := map[shared.ClientID]shared.Magnitude{}
 	totalEffect := 0.0
 
 	epiX, epiY := e.LastDisasterReport.X, e.LastDisasterReport.Y // epicentre of the disaster (peak mag)
 	for _, island := range e.Geography.Islands {
-		effect := e.LastDisasterReport.Magnitude / math.Hypot(island.X-epiX, island.Y-epiY) // effect on island i is inverse prop. to square of distance to epicentre
-		individualEffect[island.ID] = math.Min(effect, e.LastDisasterReport.Magnitude)      // to prevent divide by zero -> inf
+		distance := math.Hypot(island.X-epiX, island.Y-epiY)
+		individualEffect[island.ID] = attenuate(e.LastDisasterReport.Magnitude, distance, dConf) // effect on island i decays with distance to epicentre
 		totalEffect = totalEffect + individualEffect[island.ID]
 	}
 	if totalEffect == 0 {
@@ -105,7 +100,7 @@ func (e Environment) computeUnmitigatedDisasterEffects() DisasterEffects {
 // This method uses the latest disaster report stored in environment
 func (e Environment) ComputeDisasterEffects(cpResources shared.Resources, dConf config.DisasterConfig) DisasterEffects {
 
-	unmitigatedEffects := e.computeUnmitigatedDisasterEffects()
+	unmitigatedEffects := e.computeUnmitigatedDisasterEffects(dConf)
 	mitigatedEffects := e.MitigateDisaster(cpResources, unmitigatedEffects, dConf)
 
 	return DisasterEffects{Absolute: unmitigatedEffects.Absolute, Proportional: unmitigatedEffects.Proportional, CommonPoolMitigated: mitigatedEffects}
@@ -0,0 +1,126 @@
+package disasters
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// PendingPrediction is a disaster prediction made by an island that is yet to be scored.
+// A prediction is scored against the first disaster that occurs within the time tolerance of its predicted turn. If no
+// disaster occurs in that window, the prediction expires and is scored as a false alarm.
+type PendingPrediction struct {
+	TurnMade   uint
+	Prediction shared.DisasterPrediction
+}
+
+// PredictedTurn is the turn in which the prediction expects the disaster to strike
+func (p PendingPrediction) PredictedTurn() uint {
+	return p.TurnMade + p.Prediction.TimeLeft
+}
+
+// InWindow checks whether turn is within timeTolerance turns of the predicted turn
+func (p PendingPrediction) InWindow(turn uint, timeTolerance uint) bool {
+	return absInt(int(turn)-int(p.PredictedTurn())) <= int(timeTolerance)
+}
+
+// Expired checks whether the window of the prediction closes in turn or has closed before it
+func (p PendingPrediction) Expired(turn uint, timeTolerance uint) bool {
+	return turn >= p.PredictedTurn()+timeTolerance
+}
+
+// PredictionScore captures how a single disaster prediction compared to the disaster that actually happened
+type PredictionScore struct {
+	TurnMade       uint
+	DisasterTurn   uint
+	SpatialError   float64                     // distance between predicted and actual epicentre
+	MagnitudeError float64                     // abs. difference between predicted and actual magnitude
+	TimingError    int                         // actual minus predicted disaster turn. Positive => disaster came later than predicted
+	Confidence     shared.PredictionConfidence // confidence the island attached to the prediction [0, 100]
+	Accurate       bool                        // whether all errors are within the configured tolerances
+	Expired        bool                        // no disaster occurred within the time tolerance of the predicted turn. Errors are not set
+}
+
+// ForecastingAccuracy is the running record of how well an island's disaster predictions have matched reality
+type ForecastingAccuracy struct {
+	NumScored          uint              // number of predictions scored so far
+	NumAccurate        uint              // number of those predictions deemed accurate
+	NumExpired         uint              // number of those predictions that expired without a disaster (false alarms)
+	MeanSpatialError   float64           // mean distance between predicted and actual epicentres, over non-expired predictions
+	MeanMagnitudeError float64           // mean abs. difference between predicted and actual magnitudes, over non-expired predictions
+	MeanTimingError    float64           // mean abs. difference (in turns) between predicted and actual disaster turns, over non-expired predictions
+	BrierScore         float64           // mean squared difference between confidence (as a probability) and the accuracy outcome. 0 => perfectly calibrated
+	LastScores         []PredictionScore // scores of the predictions resolved by the most recent update
+}
+
+// ScorePrediction compares a prediction to the disaster that happened in disasterTurn
+func ScorePrediction(p PendingPrediction, report DisasterReport, disasterTurn uint, iifoConf config.IIFOConfig) PredictionScore {
+	spatialError := math.Hypot(p.Prediction.CoordinateX-report.X, p.Prediction.CoordinateY-report.Y)
+	magnitudeError := math.Abs(p.Prediction.Magnitude - report.Magnitude)
+	timingError := int(disasterTurn) - int(p.PredictedTurn())
+
+	accurate := spatialError <= iifoConf.PredictionSpatialTolerance &&
+		magnitudeError <= iifoConf.PredictionMagnitudeTolerance &&
+		absInt(timingError) <= int(iifoConf.PredictionTimeTolerance)
+
+	return PredictionScore{
+		TurnMade:       p.TurnMade,
+		DisasterTurn:   disasterTurn,
+		SpatialError:   spatialError,
+		MagnitudeError: magnitudeError,
+		TimingError:    timingError,
+		Confidence:     p.Prediction.Confidence,
+		Accurate:       accurate,
+	}
+}
+
+// ScoreExpiredPrediction scores a prediction whose window passed with no disaster as an inaccurate false alarm
+func ScoreExpiredPrediction(p PendingPrediction) PredictionScore {
+	return PredictionScore{
+		TurnMade:   p.TurnMade,
+		Confidence: p.Prediction.Confidence,
+		Expired:    true,
+	}
+}
+
+// Update folds newly resolved prediction scores into the running accuracy record
+func (f ForecastingAccuracy) Update(scores []PredictionScore) ForecastingAccuracy {
+	for _, s := range scores {
+		n := float64(f.NumScored)
+		outcome := 0.0
+		if s.Accurate {
+			outcome = 1
+			f.NumAccurate++
+		}
+		prob := math.Max(0, math.Min(s.Confidence/100, 1)) // confidence is bounded between 0 and 100
+		f.BrierScore = (f.BrierScore*n + (prob-outcome)*(prob-outcome)) / (n + 1)
+		f.NumScored++
+
+		if s.Expired {
+			f.NumExpired++
+			continue
+		}
+		m := float64(f.NumScored - f.NumExpired - 1) // number of non-expired predictions before this one
+		f.MeanSpatialError = (f.MeanSpatialError*m + s.SpatialError) / (m + 1)
+		f.MeanMagnitudeError = (f.MeanMagnitudeError*m + s.MagnitudeError) / (m + 1)
+		f.MeanTimingError = (f.MeanTimingError*m + float64(absInt(s.TimingError))) / (m + 1)
+	}
+	f.LastScores = scores
+	return f
+}
+
+// Copy returns a deep copy of ForecastingAccuracy
+func (f ForecastingAccuracy) Copy() ForecastingAccuracy {
+	ret := f
+	ret.LastScores = make([]PredictionScore, len(f.LastScores))
+	copy(ret.LastScores, f.LastScores)
+	return ret
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
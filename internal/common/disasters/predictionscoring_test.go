@@ -0,0 +1,111 @@
+package disasters
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestScorePrediction(t *testing.T) {
+	iifoConf := config.IIFOConfig{
+		PredictionSpatialTolerance:   1.0,
+		PredictionMagnitudeTolerance: 0.5,
+		PredictionTimeTolerance:      1,
+	}
+	report := DisasterReport{Magnitude: 2, X: 3, Y: 4}
+
+	var tests = []struct {
+		name       string
+		prediction PendingPrediction
+		want       PredictionScore
+	}{
+		{
+			name: "perfect",
+			prediction: PendingPrediction{
+				TurnMade:   5,
+				Prediction: shared.DisasterPrediction{CoordinateX: 3, CoordinateY: 4, Magnitude: 2, TimeLeft: 5, Confidence: 80},
+			},
+			want: PredictionScore{TurnMade: 5, DisasterTurn: 10, Confidence: 80, Accurate: true},
+		},
+		{
+			name: "disaster came late",
+			prediction: PendingPrediction{
+				TurnMade:   5,
+				Prediction: shared.DisasterPrediction{CoordinateX: 3, CoordinateY: 4, Magnitude: 2, TimeLeft: 2, Confidence: 80},
+			},
+			want: PredictionScore{TurnMade: 5, DisasterTurn: 10, TimingError: 3, Confidence: 80, Accurate: false},
+		},
+		{
+			name: "wrong place and size",
+			prediction: PendingPrediction{
+				TurnMade:   9,
+				Prediction: shared.DisasterPrediction{CoordinateX: 0, CoordinateY: 0, Magnitude: 1, TimeLeft: 2, Confidence: 10},
+			},
+			want: PredictionScore{TurnMade: 9, DisasterTurn: 10, SpatialError: 5, MagnitudeError: 1, TimingError: -1, Confidence: 10, Accurate: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScorePrediction(tt.prediction, report, 10, iifoConf)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPredictionWindow(t *testing.T) {
+	p := PendingPrediction{TurnMade: 5, Prediction: shared.DisasterPrediction{TimeLeft: 3}}
+	var tests = []struct {
+		turn        uint
+		wantWindow  bool
+		wantExpired bool
+	}{
+		{turn: 6, wantWindow: false, wantExpired: false},
+		{turn: 7, wantWindow: true, wantExpired: false},
+		{turn: 8, wantWindow: true, wantExpired: false},
+		{turn: 9, wantWindow: true, wantExpired: true},
+		{turn: 10, wantWindow: false, wantExpired: true},
+	}
+	for _, tt := range tests {
+		if got := p.InWindow(tt.turn, 1); got != tt.wantWindow {
+			t.Errorf("InWindow(%v): got %v, want %v", tt.turn, got, tt.wantWindow)
+		}
+		if got := p.Expired(tt.turn, 1); got != tt.wantExpired {
+			t.Errorf("Expired(%v): got %v, want %v", tt.turn, got, tt.wantExpired)
+		}
+	}
+}
+
+func TestForecastingAccuracyUpdate(t *testing.T) {
+	scores := []PredictionScore{
+		{SpatialError: 1, MagnitudeError: 0.5, TimingError: -2, Confidence: 100, Accurate: true},
+		ScoreExpiredPrediction(PendingPrediction{Prediction: shared.DisasterPrediction{Confidence: 50}}),
+		{SpatialError: 3, MagnitudeError: 1.5, TimingError: 4, Confidence: 50, Accurate: false},
+	}
+	acc := ForecastingAccuracy{}.Update(scores[:2])
+	acc = acc.Update(scores[2:])
+
+	if acc.NumScored != 3 || acc.NumAccurate != 1 || acc.NumExpired != 1 {
+		t.Errorf("Expected 1/3 accurate predictions and 1 expired, got %v/%v and %v", acc.NumAccurate, acc.NumScored, acc.NumExpired)
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"MeanSpatialError", acc.MeanSpatialError, 2},
+		{"MeanMagnitudeError", acc.MeanMagnitudeError, 1},
+		{"MeanTimingError", acc.MeanTimingError, 3},
+		{"BrierScore", acc.BrierScore, (0 + 0.25 + 0.25) / 3},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%v: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if len(acc.LastScores) != 1 || acc.LastScores[0] != scores[2] {
+		t.Errorf("Expected LastScores to hold only the latest scores, got %+v", acc.LastScores)
+	}
+}
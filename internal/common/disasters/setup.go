@@ -5,31 +5,155 @@ import (
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/stat/distuv"
 )
 
-// InitEnvironment initialises environment according to definitions
-func InitEnvironment(islandIDs []shared.ClientID, envConf config.DisasterConfig) Environment {
+// maxScatterAttempts is the number of candidate layouts sampled when trying to satisfy the min. island separation
+const maxScatterAttempts = 1000
+
+// InitEnvironment initialises environment according to definitions. It returns an error if the islands cannot be
+// placed as the config requires.
+func InitEnvironment(islandIDs []shared.ClientID, envConf config.DisasterConfig) (Environment, error) {
 	ag := ArchipelagoGeography{
 		Islands: map[shared.ClientID]IslandLocationInfo{},
 		XMin:    envConf.XMin,
 		XMax:    envConf.XMax,
 		YMin:    envConf.YMin,
 		YMax:    envConf.YMax,
+		Layout:  envConf.IslandLayout,
+	}
+
+	var positions []shared.Position
+	var err error
+	n := uint(len(islandIDs))
+	switch envConf.IslandLayout {
+	case shared.CircleLayout:
+		positions = circlePositions(envConf, n)
+	case shared.ScatterLayout:
+		positions, err = scatterPositions(envConf, n)
+	case shared.GridLayout:
+		positions = gridPositions(envConf, n)
+	case shared.ExplicitLayout:
+		positions, err = explicitPositions(envConf, islandIDs)
+	default: // shared.LineLayout
+		positions = linePositions(envConf, n)
+	}
+	if err != nil {
+		return Environment{}, errors.Errorf("Cannot place islands in %v: %v", envConf.IslandLayout, err)
 	}
 
-	xPoints := equidistantPoints(envConf.XMin, envConf.XMax, uint(len(islandIDs)))
 	for i, id := range islandIDs {
-		island := IslandLocationInfo{id, xPoints[i], float64(0)} // begin with equidistant points on x axis
-		ag.Islands[id] = island
+		ag.Islands[id] = IslandLocationInfo{id, positions[i].X, positions[i].Y}
+	}
+	return Environment{Geography: ag, LastDisasterReport: DisasterReport{}}, nil
+}
+
+// linePositions places n islands on equidistant points on the line y = YMin, from XMin to XMax. With the default
+// bounds (XMin = YMin = 0) this is the x axis.
+func linePositions(envConf config.DisasterConfig, n uint) []shared.Position {
+	positions := make([]shared.Position, 0, n)
+	for _, x := range equidistantPoints(envConf.XMin, envConf.XMax, n) {
+		positions = append(positions, shared.Position{X: x, Y: envConf.YMin})
+	}
+	return positions
+}
+
+// circlePositions places n islands on equidistant points on the largest circle inscribed in the bounds
+func circlePositions(envConf config.DisasterConfig, n uint) []shared.Position {
+	cX, cY := (envConf.XMin+envConf.XMax)/2, (envConf.YMin+envConf.YMax)/2
+	radius := math.Min(envConf.XMax-envConf.XMin, envConf.YMax-envConf.YMin) / 2
+
+	positions := make([]shared.Position, 0, n)
+	for i := uint(0); i < n; i++ {
+		theta := 2 * math.Pi * float64(i) / float64(n)
+		positions = append(positions, shared.Position{X: cX + radius*math.Cos(theta), Y: cY + radius*math.Sin(theta)})
+	}
+	return positions
+}
+
+// gridPositions places n islands in the centres of the cells of the smallest near-square grid with >= n cells.
+// Cells are filled row by row.
+func gridPositions(envConf config.DisasterConfig, n uint) []shared.Position {
+	if n == 0 {
+		return []shared.Position{}
+	}
+	cols := uint(math.Ceil(math.Sqrt(float64(n))))
+	rows := (n + cols - 1) / cols
+	cellW := (envConf.XMax - envConf.XMin) / float64(cols)
+	cellH := (envConf.YMax - envConf.YMin) / float64(rows)
+
+	positions := make([]shared.Position, 0, n)
+	for i := uint(0); i < n; i++ {
+		row, col := i/cols, i%cols
+		positions = append(positions, shared.Position{
+			X: envConf.XMin + cellW*(float64(col)+0.5),
+			Y: envConf.YMin + cellH*(float64(row)+0.5),
+		})
+	}
+	return positions
+}
+
+// scatterPositions places n islands uniformly at random in the bounds such that no two islands are closer than
+// envConf.IslandMinSeparation. It returns an error if no such layout is found within maxScatterAttempts samples.
+func scatterPositions(envConf config.DisasterConfig, n uint) ([]shared.Position, error) {
+	pdfX := distuv.Uniform{Min: envConf.XMin, Max: envConf.XMax}
+	pdfY := distuv.Uniform{Min: envConf.YMin, Max: envConf.YMax}
+
+	var best []shared.Position
+	bestSeparation := -1.0
+	for attempt := 0; attempt < maxScatterAttempts; attempt++ {
+		candidate := make([]shared.Position, 0, n)
+		for i := uint(0); i < n; i++ {
+			candidate = append(candidate, shared.Position{X: pdfX.Rand(), Y: pdfY.Rand()})
+		}
+		separation := minSeparation(candidate)
+		if separation > bestSeparation {
+			best, bestSeparation = candidate, separation
+		}
+		if bestSeparation >= envConf.IslandMinSeparation {
+			return best, nil
+		}
+	}
+	return nil, errors.Errorf("no layout with islands at least %v apart found in %v attempts (best: %v apart)",
+		envConf.IslandMinSeparation, maxScatterAttempts, bestSeparation)
+}
+
+// explicitPositions places islands at the co-ordinates given in config. Islands without a specified position
+// fall back to their position in the line layout. It returns an error if a specified position is outside the bounds.
+func explicitPositions(envConf config.DisasterConfig, islandIDs []shared.ClientID) ([]shared.Position, error) {
+	positions := linePositions(envConf, uint(len(islandIDs)))
+	for i, id := range islandIDs {
+		pos, ok := envConf.IslandPositions[id]
+		if !ok {
+			continue
+		}
+		if pos.X < envConf.XMin || pos.X > envConf.XMax || pos.Y < envConf.YMin || pos.Y > envConf.YMax {
+			return nil, errors.Errorf("position (%v, %v) of %v is outside the bounds [%v, %v] x [%v, %v]",
+				pos.X, pos.Y, id, envConf.XMin, envConf.XMax, envConf.YMin, envConf.YMax)
+		}
+		positions[i] = pos
+	}
+	return positions, nil
+}
+
+// minSeparation returns the smallest distance between any two positions (+Inf if there are fewer than 2)
+func minSeparation(positions []shared.Position) float64 {
+	sep := math.Inf(1)
+	for i := range positions {
+		for j := i + 1; j < len(positions); j++ {
+			sep = math.Min(sep, math.Hypot(positions[i].X-positions[j].X, positions[i].Y-positions[j].Y))
+		}
 	}
-	return Environment{Geography: ag, LastDisasterReport: DisasterReport{}}
+	return sep
 }
 
-// get n equally spaced points on a line connecting x0, x1
+// get n equally spaced points on a line connecting x0, x1. The points start at x0 itself, not at 0, so a non-zero
+// XMin shifts the line layout along with the bounds.
 func equidistantPoints(x0, x1 float64, n uint) (points []float64) {
-	delta := (x1 - x0) / math.Max(float64(n-1), 1) // prevent /0 error
+	delta := (x1 - x0) / math.Max(float64(n)-1, 1) // prevent /0 error
 	for i := uint(0); i < n; i++ {
-		points = append(points, delta*float64(i))
+		points = append(points, x0+delta*float64(i))
 	}
 	return points
 }
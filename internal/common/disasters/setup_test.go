@@ -0,0 +1,146 @@
+package disasters
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestIslandLayouts(t *testing.T) {
+	clientIDs := shared.TeamIDs[:]
+	layouts := []shared.IslandLayout{
+		shared.LineLayout,
+		shared.CircleLayout,
+		shared.ScatterLayout,
+		shared.GridLayout,
+		shared.ExplicitLayout,
+	}
+
+	for _, layout := range layouts {
+		t.Run(layout.String(), func(t *testing.T) {
+			disasterConf := config.DisasterConfig{
+				XMin:                2.0,
+				XMax:                12.0,
+				YMin:                -5.0,
+				YMax:                5.0,
+				IslandLayout:        layout,
+				IslandMinSeparation: 1.0,
+				IslandPositions: map[shared.ClientID]shared.Position{
+					shared.Team1: {X: 3, Y: 4},
+					shared.Team2: {X: 4, Y: 3},
+				},
+			}
+			env, err := InitEnvironment(clientIDs, disasterConf)
+			if err != nil {
+				t.Fatalf("Cannot initialise environment: %v", err)
+			}
+
+			if env.Geography.Layout != layout {
+				t.Errorf("Expected geography to record layout %v, got %v", layout, env.Geography.Layout)
+			}
+			if len(env.Geography.Islands) != len(clientIDs) {
+				t.Fatalf("Expected %v islands, got %v", len(clientIDs), len(env.Geography.Islands))
+			}
+			positions := []shared.Position{}
+			for _, id := range clientIDs {
+				island := env.Geography.Islands[id]
+				if island.ID != id {
+					t.Errorf("Island stored under %v has ID %v", id, island.ID)
+				}
+				if island.X < disasterConf.XMin || island.X > disasterConf.XMax || island.Y < disasterConf.YMin || island.Y > disasterConf.YMax {
+					t.Errorf("Island %v at (%v, %v) is outside of config bounds", id, island.X, island.Y)
+				}
+				positions = append(positions, shared.Position{X: island.X, Y: island.Y})
+			}
+			if sep := minSeparation(positions); sep < disasterConf.IslandMinSeparation {
+				t.Errorf("Expected islands to be at least %v apart, got %v", disasterConf.IslandMinSeparation, sep)
+			}
+		})
+	}
+}
+
+func TestCircleLayoutRadius(t *testing.T) {
+	disasterConf := config.DisasterConfig{XMin: 0, XMax: 10, YMin: 0, YMax: 6, IslandLayout: shared.CircleLayout}
+	env, err := InitEnvironment(shared.TeamIDs[:], disasterConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
+	for _, island := range env.Geography.Islands {
+		if r := math.Hypot(island.X-5, island.Y-3); math.Abs(r-3) > 1e-9 {
+			t.Errorf("Expected island %v to be at distance 3 from centre, got %v", island.ID, r)
+		}
+	}
+}
+
+func TestExplicitLayout(t *testing.T) {
+	clientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+	disasterConf := config.DisasterConfig{
+		XMax:         10,
+		YMax:         10,
+		IslandLayout: shared.ExplicitLayout,
+		IslandPositions: map[shared.ClientID]shared.Position{
+			shared.Team1: {X: 1, Y: 9},
+			shared.Team3: {X: 7, Y: 2},
+		},
+	}
+	env, err := InitEnvironment(clientIDs, disasterConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
+
+	for id, want := range disasterConf.IslandPositions {
+		x, y := env.Geography.IslandLocation(id)
+		if x != want.X || y != want.Y {
+			t.Errorf("Expected %v at (%v, %v), got (%v, %v)", id, want.X, want.Y, x, y)
+		}
+	}
+	// Team2 has no explicit position so falls back to the line layout
+	if x, y := env.Geography.IslandLocation(shared.Team2); x != 5 || y != 0 {
+		t.Errorf("Expected Team2 to fall back to (5, 0), got (%v, %v)", x, y)
+	}
+}
+
+func TestIslandLayoutErrors(t *testing.T) {
+	var tests = []struct {
+		name string
+		conf config.DisasterConfig
+	}{
+		{
+			name: "separation too large for bounds",
+			conf: config.DisasterConfig{XMax: 1, YMax: 1, IslandLayout: shared.ScatterLayout, IslandMinSeparation: 5},
+		},
+		{
+			name: "explicit position outside bounds",
+			conf: config.DisasterConfig{
+				XMax:            10,
+				YMax:            10,
+				IslandLayout:    shared.ExplicitLayout,
+				IslandPositions: map[shared.ClientID]shared.Position{shared.Team2: {X: 11, Y: 5}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := InitEnvironment(shared.TeamIDs[:], tt.conf); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestLineLayoutFollowsBounds(t *testing.T) {
+	disasterConf := config.DisasterConfig{XMin: 2, XMax: 8, YMin: 3, YMax: 5, IslandLayout: shared.LineLayout}
+	clientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+	env, err := InitEnvironment(clientIDs, disasterConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
+	for i, id := range clientIDs {
+		wantX := 2 + 3*float64(i)
+		if x, y := env.Geography.IslandLocation(id); x != wantX || y != 3 {
+			t.Errorf("Expected %v at (%v, 3), got (%v, %v)", id, wantX, x, y)
+		}
+	}
+}
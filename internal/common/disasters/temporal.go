@@ -0,0 +1,124 @@
+package disasters
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ProcessState captures the memory of temporally correlated disaster processes between turns
+type ProcessState struct {
+	Stormy     bool    // whether a MarkovModulatedProcess is in its stormy regime
+	Excitation float64 // intensity a HawkesProcess has accumulated from past disasters
+}
+
+// disasterOccurs samples whether a disaster occurs this turn for the non-replay disaster processes
+func (e Environment) disasterOccurs(dConf config.DisasterConfig, turn uint) bool {
+	switch dConf.DisasterProcess {
+	case shared.MarkovModulatedProcess:
+		period := dConf.MarkovProcess.CalmPeriod
+		if e.Process.Stormy {
+			period = dConf.MarkovProcess.StormyPeriod
+		}
+		return bernoulli(1 / math.Max(float64(period), 1))
+	case shared.HawkesProcess:
+		intensity := dConf.HawkesProcess.BaseRate + e.Process.Excitation
+		return bernoulli(1 - math.Exp(-intensity)) // P(at least one event) in a unit time step
+	default: // shared.PeriodicProcess
+		if dConf.StochasticPeriod {
+			// if T is the disaster period (time between occurrences), we need:
+			// E[T] = T (stochastic and deterministic cases respectively). Since
+			// T is a geometric RV in the stochastic case, p = 1/E[T]
+			return bernoulli(1 / float64(dConf.Period))
+		}
+		return turn%uint(dConf.Period) == 0 && turn > 0
+	}
+}
+
+// nextProcessState advances the process memory to the next turn given the magnitude of this turn's disaster
+func (s ProcessState) nextProcessState(dConf config.DisasterConfig, magnitude shared.Magnitude) ProcessState {
+	switch dConf.DisasterProcess {
+	case shared.MarkovModulatedProcess:
+		if s.Stormy {
+			s.Stormy = !bernoulli(dConf.MarkovProcess.StormyToCalmProb)
+		} else {
+			s.Stormy = bernoulli(dConf.MarkovProcess.CalmToStormyProb)
+		}
+	case shared.HawkesProcess:
+		s.Excitation = (s.Excitation + dConf.HawkesProcess.Excitation*magnitude) * math.Exp(-dConf.HawkesProcess.Decay)
+	}
+	return s
+}
+
+// scheduledDisaster returns the disaster scheduled for a given turn, if any
+func scheduledDisaster(schedule []shared.ScheduledDisaster, turn uint) (shared.ScheduledDisaster, bool) {
+	i := sort.Search(len(schedule), func(i int) bool { return schedule[i].Turn >= turn })
+	if i < len(schedule) && schedule[i].Turn == turn {
+		return schedule[i], true
+	}
+	return shared.ScheduledDisaster{}, false
+}
+
+// bernoulli returns true with prob. p
+func bernoulli(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	if p >= 1 {
+		return true
+	}
+	return distuv.Bernoulli{P: p}.Rand() == 1.0
+}
+
+// ParseDisasterSchedule reads a disaster schedule from CSV records of the form `turn,x,y,magnitude`.
+// An optional header row starting with `turn` and lines starting with `#` are skipped.
+// The schedule is returned sorted by turn. There can be at most one disaster per turn.
+func ParseDisasterSchedule(r io.Reader) ([]shared.ScheduledDisaster, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Errorf("Invalid disaster schedule: %v", err)
+	}
+
+	schedule := []shared.ScheduledDisaster{}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "turn") {
+			continue // header
+		}
+		turn, err := strconv.ParseUint(strings.TrimSpace(record[0]), 10, 0)
+		if err != nil {
+			return nil, errors.Errorf("Invalid turn in disaster schedule record %v: %v", record, err)
+		}
+		values := [3]float64{}
+		for j := range values {
+			values[j], err = strconv.ParseFloat(strings.TrimSpace(record[j+1]), 64)
+			if err != nil {
+				return nil, errors.Errorf("Invalid value in disaster schedule record %v: %v", record, err)
+			}
+		}
+		if values[2] < 0 {
+			return nil, errors.Errorf("Negative magnitude in disaster schedule record %v", record)
+		}
+		schedule = append(schedule, shared.ScheduledDisaster{Turn: uint(turn), X: values[0], Y: values[1], Magnitude: values[2]})
+	}
+
+	sort.SliceStable(schedule, func(i, j int) bool { return schedule[i].Turn < schedule[j].Turn })
+	for i := 1; i < len(schedule); i++ {
+		if schedule[i].Turn == schedule[i-1].Turn {
+			return nil, errors.Errorf("Disaster schedule has more than one disaster in turn %v", schedule[i].Turn)
+		}
+	}
+	return schedule, nil
+}
@@ -0,0 +1,149 @@
+package disasters
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestMarkovModulatedRegimes(t *testing.T) {
+	disasterConf := config.DisasterConfig{
+		XMax:            10.0,
+		YMax:            10.0,
+		MagnitudeLambda: 1.0,
+		DisasterProcess: shared.MarkovModulatedProcess,
+		MarkovProcess: config.MarkovProcessConfig{
+			CalmPeriod:       1000000, // practically no disasters when calm
+			StormyPeriod:     1,       // disaster every turn when stormy
+			CalmToStormyProb: 1,
+			StormyToCalmProb: 1,
+		},
+	}
+	env, err := InitEnvironment([]shared.ClientID{shared.Team1}, disasterConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
+	for turn := uint(1); turn <= 10; turn++ {
+		stormy := env.Process.Stormy
+		env = env.SampleForDisaster(disasterConf, turn)
+		if stormy && env.LastDisasterReport.Magnitude == 0 {
+			t.Errorf("Turn %v: no disaster in stormy regime with period 1", turn)
+		}
+		if env.Process.Stormy == stormy {
+			t.Errorf("Turn %v: expected regime to switch with transition prob. 1", turn)
+		}
+	}
+}
+
+func TestHawkesSelfExcitation(t *testing.T) {
+	disasterConf := config.DisasterConfig{
+		DisasterProcess: shared.HawkesProcess,
+		HawkesProcess: config.HawkesProcessConfig{
+			BaseRate:   0,
+			Excitation: 0.5,
+			Decay:      math.Log(2), // intensity halves every turn
+		},
+	}
+	s := ProcessState{}
+	s = s.nextProcessState(disasterConf, 4)
+	if math.Abs(s.Excitation-1) > 1e-9 {
+		t.Errorf("Expected excitation of 1 after magnitude 4 disaster, got %v", s.Excitation)
+	}
+	s = s.nextProcessState(disasterConf, 0)
+	if math.Abs(s.Excitation-0.5) > 1e-9 {
+		t.Errorf("Expected excitation to decay to 0.5, got %v", s.Excitation)
+	}
+
+	env := Environment{}
+	for turn := uint(1); turn <= 20; turn++ {
+		env = env.SampleForDisaster(disasterConf, turn)
+		if env.LastDisasterReport.Magnitude > 0 {
+			t.Fatalf("Turn %v: disaster occurred with zero intensity", turn)
+		}
+	}
+}
+
+func TestReplayProcess(t *testing.T) {
+	schedule := []shared.ScheduledDisaster{
+		{Turn: 2, X: 1, Y: 2, Magnitude: 3},
+		{Turn: 5, X: 4, Y: 5, Magnitude: 6},
+	}
+	disasterConf := config.DisasterConfig{DisasterProcess: shared.ReplayProcess, ReplaySchedule: schedule}
+
+	env := Environment{}
+	for turn := uint(1); turn <= 6; turn++ {
+		env = env.SampleForDisaster(disasterConf, turn)
+		want := DisasterReport{Magnitude: 0, X: -1, Y: -1}
+		if d, ok := scheduledDisaster(schedule, turn); ok {
+			want = DisasterReport{Magnitude: d.Magnitude, X: d.X, Y: d.Y}
+		}
+		if !reflect.DeepEqual(want, env.LastDisasterReport) {
+			t.Errorf("Turn %v: want %v got %v", turn, want, env.LastDisasterReport)
+		}
+	}
+}
+
+func TestParseDisasterSchedule(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    []shared.ScheduledDisaster
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  []shared.ScheduledDisaster{},
+		},
+		{
+			name:  "header, comments and unsorted turns",
+			input: "turn,x,y,magnitude\n# a comment\n7, 1.5, 2, 0.5\n3,0,0,1\n",
+			want: []shared.ScheduledDisaster{
+				{Turn: 3, X: 0, Y: 0, Magnitude: 1},
+				{Turn: 7, X: 1.5, Y: 2, Magnitude: 0.5},
+			},
+		},
+		{
+			name:    "missing field",
+			input:   "3,0,0\n",
+			wantErr: true,
+		},
+		{
+			name:    "negative turn",
+			input:   "-3,0,0,1\n",
+			wantErr: true,
+		},
+		{
+			name:    "negative magnitude",
+			input:   "3,0,0,-1\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate turn",
+			input:   "3,0,0,1\n3,1,1,1\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseDisasterSchedule(strings.NewReader(tc.input))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got '%v'", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
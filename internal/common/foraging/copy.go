@@ -5,3 +5,9 @@ func (dp DeerPopulationModel) Copy() DeerPopulationModel {
 	ret := dp
 	return ret
 }
+
+// Copy returns a deep copy of the FishPopulationModel.
+func (fp FishPopulationModel) Copy() FishPopulationModel {
+	ret := fp
+	return ret
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/stat/distuv"
 )
 
@@ -21,6 +22,7 @@ type DeerHunt struct {
 	ParticipantContributions map[shared.ClientID]shared.Resources
 	params                   deerHuntParams
 	logger                   shared.Logger
+	src                      rand.Source // source of random numbers. nil for the global source
 }
 
 // TotalInput simply sums the total group resource input of hunt participants
@@ -34,10 +36,11 @@ func (d DeerHunt) Hunt(dhConf config.DeerHuntConfig, deerPopulation uint) Foragi
 	// get max number of deer allowed for given resource input
 	nDeerFromInput := utilityTier(input, dhConf.MaxDeerPerHunt, dhConf.IncrementalInputDecay, dhConf.InputScaler)
 	returns := []shared.Resources{}
+	cooperation := stagHuntMultiplier(dhConf, d.numberOfParticipants())
 
 	for i := uint(0); i < nDeerFromInput; i++ {
-		d.params.p = d.getPopulationLinkedProbability(dhConf, deerPopulation)
-		utility := deerReturn(d.params) * shared.Resources(dhConf.OutputScaler) // scale raw deerReturn to be in range with other resource quantities
+		d.params.p = cooperation * d.getPopulationLinkedProbability(dhConf, deerPopulation)
+		utility := deerReturn(d.params, d.src) * shared.Resources(dhConf.OutputScaler) // scale raw deerReturn to be in range with other resource quantities
 		returns = append(returns, utility)
 		if utility > 0 { // a deer was caught and so should be removed from population
 			deerPopulation = uint(math.Max(0, float64(deerPopulation)-1)) // min pop is zero. Assume no population growth (from DE) effects during short hunt
@@ -52,36 +55,47 @@ func (d DeerHunt) Hunt(dhConf config.DeerHuntConfig, deerPopulation uint) Foragi
 // - W: A continuous RV that adds some variance to the return. This could be interpreted as the weight of the deer that is caught. W is
 // exponentially distributed such that the prevalence of deer of certain size is inversely prop. to the size.
 // returns H, where H = D*(1+W) is an other random variable
-func deerReturn(params deerHuntParams) shared.Resources {
-	W := distuv.Exponential{Rate: params.lam} // Rate = lambda
-	D := distuv.Bernoulli{P: params.p}        // Bernoulli RV where `P` = P(X=1)
+func deerReturn(params deerHuntParams, src rand.Source) shared.Resources {
+	W := distuv.Exponential{Rate: params.lam, Src: src} // Rate = lambda
+	D := distuv.Bernoulli{P: params.p, Src: src}        // Bernoulli RV where `P` = P(X=1)
 	return shared.Resources(D.Rand() * (1 + W.Rand()))
 }
 
 // getPopulationLinkedProbability returns the Bernoulli probability of catching a deer given the current running deer population.
 // The dynamics and variables implemented in this function are documented in the README in this package.
 func (d DeerHunt) getPopulationLinkedProbability(dhConf config.DeerHuntConfig, population uint) float64 {
-	pCritical := 1.0
-	pMax := float64(dhConf.MaxDeerPopulation) / float64(dhConf.MaxDeerPerHunt)
-	thetaCritical := dhConf.ThetaCritical
-	thetaMax := dhConf.ThetaMax
-	p := float64(population) / float64(dhConf.MaxDeerPerHunt)
+	return populationLinkedProbability(population, dhConf.MaxDeerPopulation, dhConf.MaxDeerPerHunt, dhConf.ThetaCritical, dhConf.ThetaMax, d.Logf)
+}
 
-	if pMax == pCritical { // catch the special case where max deer per hunt = max population. You've got params wrong if you're here though :)
-		d.Logf("Warning: detected pMax = pCritical. This is likely because you've set max deer per hunt to max deer population. Should have max pop. > max per hunt.")
-		return thetaCritical * float64(p) // same as f1. Avoid calculating alpha
+// numberOfParticipants returns the number of participants that contributed resources to the hunt
+func (d DeerHunt) numberOfParticipants() uint {
+	n := uint(0)
+	for _, contribution := range d.ParticipantContributions {
+		if contribution > 0 {
+			n++
+		}
 	}
-	alpha := (thetaMax - thetaCritical) / (pMax - float64(pCritical))
-
-	f1 := func(p float64) float64 { return thetaCritical * float64(p) }
-	f2 := func(p float64) float64 { return alpha*float64((p-pCritical)) + thetaCritical }
+	return n
+}
 
-	if p < pCritical {
-		theta := f1(p)
-		d.Logf("Deer population ratio below critical level: P(t)=%v, p=%v, p_crit=%v, theta=%.2f", population, p, pCritical, theta)
-		return theta
+// stagHuntMultiplier returns the multiplier on the chance of catching each deer for a hunt with n participants,
+// according to the configured stag hunt rule. Without a rule, a single island can hunt as well as a coalition.
+func stagHuntMultiplier(dhConf config.DeerHuntConfig, n uint) float64 {
+	k := dhConf.MinHuntParticipants
+	switch dhConf.StagHuntRule {
+	case shared.MinimumParticipantsStagHunt:
+		if n < k {
+			return 0
+		}
+		return 1
+	case shared.ParticipantScaledStagHunt:
+		if k == 0 {
+			return 1
+		}
+		return math.Min(1, float64(n)/float64(k))
+	default: // shared.NoStagHuntRule
+		return 1
 	}
-	return f2(p)
 }
 
 // Logf is a this type's custom logger
@@ -62,7 +62,7 @@ func TestDeerReturn(t *testing.T) {
 	params := deerHuntParams{p: 0.95, lam: 1.0}
 	avReturn := 0.0
 	for i := 1; i <= 1000; i++ { // calculate empirical mean return over 1000 trials
-		d := deerReturn(params)
+		d := deerReturn(params, nil)
 		avReturn = (avReturn*(float64(i)-1) + float64(d)) / float64(i)
 	}
 	expectedReturn := params.p * (1 + 1/params.lam) // theoretical mean based on def of expectation
@@ -70,3 +70,57 @@ func TestDeerReturn(t *testing.T) {
 		t.Errorf("Empirical mean return deviated from theoretical by > 5 percent: got %.3f, want %.3f", avReturn, expectedReturn)
 	}
 }
+
+func TestStagHuntMultiplier(t *testing.T) {
+	cases := []struct {
+		name string
+		rule shared.StagHuntRule
+		n    uint
+		want float64
+	}{
+		{"no rule, lone hunter", shared.NoStagHuntRule, 1, 1},
+		{"minimum participants not met", shared.MinimumParticipantsStagHunt, 2, 0},
+		{"minimum participants met", shared.MinimumParticipantsStagHunt, 3, 1},
+		{"scaled, lone hunter", shared.ParticipantScaledStagHunt, 1, 1.0 / 3},
+		{"scaled, full party", shared.ParticipantScaledStagHunt, 3, 1},
+		{"scaled, more than needed", shared.ParticipantScaledStagHunt, 5, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dhConf := config.DeerHuntConfig{StagHuntRule: tc.rule, MinHuntParticipants: 3}
+			if got := stagHuntMultiplier(dhConf, tc.n); math.Abs(got-tc.want) > 1e-9 {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestStagHuntLoneHunter(t *testing.T) {
+	dhConf := config.DeerHuntConfig{
+		MaxDeerPerHunt:        4,
+		IncrementalInputDecay: 0.8,
+		ExponentialRate:       1,
+		InputScaler:           1,
+		OutputScaler:          1,
+		MaxDeerPopulation:     12,
+		ThetaCritical:         0.95,
+		ThetaMax:              0.95,
+		StagHuntRule:          shared.MinimumParticipantsStagHunt,
+		MinHuntParticipants:   2,
+	}
+	logger := func(format string, a ...interface{}) {}
+
+	rich, _ := CreateDeerHunt(map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 0}, dhConf, logger)
+	if report := rich.Hunt(dhConf, 12); report.NumberCaught != 0 {
+		t.Errorf("a lone hunter should catch no deer, caught %v", report.NumberCaught)
+	}
+
+	coalition, _ := CreateDeerHunt(map[shared.ClientID]shared.Resources{shared.Team1: 50, shared.Team2: 50}, dhConf, logger)
+	caught := uint(0)
+	for i := 0; i < 10; i++ {
+		caught += coalition.Hunt(dhConf, 12).NumberCaught
+	}
+	if caught == 0 {
+		t.Errorf("a coalition meeting the minimum should catch deer")
+	}
+}
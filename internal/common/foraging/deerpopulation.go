@@ -22,12 +22,15 @@ func (dp DeerPopulationModel) Logf(format string, a ...interface{}) {
 	dp.logger("[DEERPOPULATION]: %v", fmt.Sprintf(format, a...))
 }
 
-// CreateBasicDeerPopulationModel returns a basic population model based on dP/dt = k(N-y) model. k = growth coeff., N = max deer (constants).
-func createBasicDeerPopulationModel(dhConf config.DeerHuntConfig, logger shared.Logger) DeerPopulationModel {
+// createDeerPopulationModelFromConfig returns a population model governed by the growth model selected in config.
+// k = growth coeff., N = max deer (constants). See populationGrowthModels for the available models.
+func createDeerPopulationModelFromConfig(dhConf config.DeerHuntConfig, logger shared.Logger) DeerPopulationModel {
 	maxDeer := dhConf.MaxDeerPopulation
-	deerPopulationGrowth := func(t, y float64) float64 {
-		return dhConf.DeerGrowthCoefficient * (float64(maxDeer) - y) // DE of form dy/dt = k(N-y) where k, N are constants
-	}
+	deerPopulationGrowth := getPopulationGrowth(dhConf.DeerPopulationModel, populationGrowthParams{
+		growthCoeff:    dhConf.DeerGrowthCoefficient,
+		capacity:       float64(maxDeer),
+		alleeThreshold: dhConf.DeerAlleeThreshold,
+	})
 	dp := DeerPopulationModel{
 		deProblem:  simulation.ODEProblem{YPrime: deerPopulationGrowth, Y0: float64(maxDeer), T0: 0, DtStep: 0.1},
 		Population: float64(maxDeer),
@@ -1 +1,87 @@
 package foraging
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// harvestLongRun simulates a population under a constant harvest for a number of turns and returns the final population.
+// No more than the whole (integer) population can be harvested in a turn.
+func harvestLongRun(dp DeerPopulationModel, harvest int, turns int) float64 {
+	for i := 0; i < turns; i++ {
+		h := int(math.Min(float64(harvest), math.Floor(dp.Population)))
+		dp = dp.Simulate([]int{h})
+	}
+	return dp.Population
+}
+
+func TestPopulationModelsUnderConstantHarvest(t *testing.T) {
+	cases := []struct {
+		name     string
+		model    shared.PopulationModelType
+		harvest  int
+		survives bool
+	}{
+		{"basic, light harvest", shared.BasicPopulationModel, 5, true},
+		{"basic, heavy harvest", shared.BasicPopulationModel, 30, true}, // regenerates from zero
+		{"logistic, below MSY", shared.LogisticPopulationModel, 5, true},
+		{"logistic, above MSY", shared.LogisticPopulationModel, 15, false}, // MSY = kN/4 = 10
+		{"allee, below MSY", shared.AlleePopulationModel, 10, true},
+		{"allee, above MSY", shared.AlleePopulationModel, 30, false}, // MSY ~= 19 (at P = 60)
+		{"gompertz, below MSY", shared.GompertzPopulationModel, 10, true},
+		{"gompertz, above MSY", shared.GompertzPopulationModel, 20, false}, // MSY = kN/e ~= 14.7
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dhConf := config.DeerHuntConfig{
+				MaxDeerPopulation:     100,
+				DeerGrowthCoefficient: 0.4,
+				DeerPopulationModel:   tc.model,
+				DeerAlleeThreshold:    20,
+			}
+			dp := CreateDeerPopulationModel(dhConf, func(format string, a ...interface{}) {})
+			final := harvestLongRun(dp, tc.harvest, 200)
+			if final > float64(dhConf.MaxDeerPopulation)+1e-6 {
+				t.Errorf("Population %v exceeded capacity %v", final, dhConf.MaxDeerPopulation)
+			}
+			collapsed := final < 0.1*float64(dhConf.MaxDeerPopulation) // a Gompertz population never quite goes extinct
+			if tc.survives && collapsed {
+				t.Errorf("Expected population to survive a harvest of %v per turn, got %v", tc.harvest, final)
+			}
+			if !tc.survives && !collapsed {
+				t.Errorf("Expected population to collapse under a harvest of %v per turn, got %v", tc.harvest, final)
+			}
+		})
+	}
+}
+
+func TestAlleeCollapseBelowCriticalPopulation(t *testing.T) {
+	dhConf := config.DeerHuntConfig{
+		MaxDeerPopulation:     100,
+		DeerGrowthCoefficient: 0.4,
+		DeerPopulationModel:   shared.AlleePopulationModel,
+		DeerAlleeThreshold:    20,
+	}
+	logger := func(format string, a ...interface{}) {}
+
+	// push the population just below / above the critical level, then stop harvesting
+	below := CreateDeerPopulationModel(dhConf, logger).Simulate([]int{85})
+	if final := harvestLongRun(below, 0, 100); final >= 1 {
+		t.Errorf("Expected population below Allee threshold to collapse without harvesting, got %v", final)
+	}
+	above := CreateDeerPopulationModel(dhConf, logger).Simulate([]int{75})
+	if final := harvestLongRun(above, 0, 100); math.Abs(final-100) > 1 {
+		t.Errorf("Expected population above Allee threshold to recover to capacity, got %v", final)
+	}
+
+	// the logistic model recovers from the same level
+	dhConf.DeerPopulationModel = shared.LogisticPopulationModel
+	logistic := CreateDeerPopulationModel(dhConf, logger).Simulate([]int{85})
+	if final := harvestLongRun(logistic, 0, 100); math.Abs(final-100) > 1 {
+		t.Errorf("Expected logistic population to recover to capacity, got %v", final)
+	}
+}
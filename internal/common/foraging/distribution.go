@@ -0,0 +1,102 @@
+package foraging
+
+import (
+	"math"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// SplitForageReturn splits the total return of a foraging session amongst its participants according to strategy.
+// rankScores are only used by RankProportionalSplit (higher score => higher rank) and minShare only by
+// InputProportionalWithMinimumShareSplit. No return is given if the participants did not contribute anything.
+func SplitForageReturn(
+	total shared.Resources,
+	contributions map[shared.ClientID]shared.Resources,
+	strategy shared.ResourceDistributionStrategy,
+	rankScores map[shared.ClientID]float64,
+	minShare float64) map[shared.ClientID]shared.Resources {
+
+	returns := make(map[shared.ClientID]shared.Resources, len(contributions))
+	totalContributions := getTotalInput(contributions)
+	if totalContributions <= 0 {
+		for id := range contributions {
+			returns[id] = 0
+		}
+		return returns
+	}
+
+	n := float64(len(contributions))
+	switch strategy {
+	case shared.InputProportionalSplit:
+		for id, c := range contributions {
+			returns[id] = (c / totalContributions) * total
+		}
+	case shared.RankProportionalSplit:
+		weights := rankWeights(contributions, rankScores)
+		totalWeight := 0.0
+		for _, w := range weights {
+			totalWeight += w
+		}
+		for id, w := range weights {
+			returns[id] = shared.Resources(w/totalWeight) * total
+		}
+	case shared.InputProportionalWithMinimumShareSplit:
+		share := math.Max(0, math.Min(minShare, 1/n)) // can't guarantee more than an equal split
+		guaranteed := shared.Resources(share) * total
+		remainder := total - guaranteed*shared.Resources(n)
+		for id, c := range contributions {
+			returns[id] = guaranteed + (c/totalContributions)*remainder
+		}
+	default: // shared.EqualSplit
+		for id := range contributions {
+			returns[id] = total / shared.Resources(n)
+		}
+	}
+	return returns
+}
+
+// rankWeights assigns each participant a weight by rank: with n participants, the highest ranked gets n, the next
+// n-1, down to 1 for the lowest ranked. Participants with equal scores share the mean weight of the ranks they span.
+// Participants without a score are treated as having a score of 0.
+func rankWeights(participants map[shared.ClientID]shared.Resources, rankScores map[shared.ClientID]float64) map[shared.ClientID]float64 {
+	ids := make([]shared.ClientID, 0, len(participants))
+	for id := range participants {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if rankScores[ids[i]] != rankScores[ids[j]] {
+			return rankScores[ids[i]] > rankScores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	weights := make(map[shared.ClientID]float64, len(ids))
+	n := len(ids)
+	for start := 0; start < n; {
+		end := start + 1
+		for end < n && rankScores[ids[end]] == rankScores[ids[start]] {
+			end++
+		}
+		// ranks start..end-1 have weights n-start..n-end+1
+		meanWeight := float64(2*n-start-end+1) / 2
+		for _, id := range ids[start:end] {
+			weights[id] = meanWeight
+		}
+		start = end
+	}
+	return weights
+}
+
+// CumulativeContributions returns the total contribution of each island over all foraging sessions in a foraging history
+func CumulativeContributions(history map[shared.ForageType][]ForagingReport) map[shared.ClientID]shared.Resources {
+	totals := map[shared.ClientID]shared.Resources{}
+	for _, reports := range history {
+		for _, report := range reports {
+			for id, c := range report.ParticipantContributions {
+				totals[id] += c
+			}
+		}
+	}
+	return totals
+}
@@ -0,0 +1,108 @@
+package foraging
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestSplitForageReturn(t *testing.T) {
+	contributions := map[shared.ClientID]shared.Resources{shared.Team1: 30, shared.Team2: 20, shared.Team3: 10}
+	scores := map[shared.ClientID]float64{shared.Team1: 1, shared.Team2: 3, shared.Team3: 2}
+	total := shared.Resources(120)
+
+	cases := []struct {
+		name          string
+		contributions map[shared.ClientID]shared.Resources
+		strategy      shared.ResourceDistributionStrategy
+		rankScores    map[shared.ClientID]float64
+		minShare      float64
+		want          map[shared.ClientID]shared.Resources
+	}{
+		{
+			name:          "equal",
+			contributions: contributions,
+			strategy:      shared.EqualSplit,
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 40, shared.Team2: 40, shared.Team3: 40},
+		},
+		{
+			name:          "input proportional",
+			contributions: contributions,
+			strategy:      shared.InputProportionalSplit,
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 60, shared.Team2: 40, shared.Team3: 20},
+		},
+		{
+			name:          "rank proportional",
+			contributions: contributions,
+			strategy:      shared.RankProportionalSplit,
+			rankScores:    scores,
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 20, shared.Team2: 60, shared.Team3: 40}, // weights 1, 3, 2
+		},
+		{
+			name:          "rank proportional with ties",
+			contributions: contributions,
+			strategy:      shared.RankProportionalSplit,
+			rankScores:    map[shared.ClientID]float64{shared.Team1: 5, shared.Team2: 5, shared.Team3: 0},
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 50, shared.Team2: 50, shared.Team3: 20}, // weights 2.5, 2.5, 1
+		},
+		{
+			name:          "rank proportional without scores",
+			contributions: contributions,
+			strategy:      shared.RankProportionalSplit,
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 40, shared.Team2: 40, shared.Team3: 40},
+		},
+		{
+			name:          "minimum share",
+			contributions: contributions,
+			strategy:      shared.InputProportionalWithMinimumShareSplit,
+			minShare:      0.25,
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 45, shared.Team2: 40, shared.Team3: 35}, // 30 each + 30 split 3:2:1
+		},
+		{
+			name:          "minimum share capped at equal split",
+			contributions: contributions,
+			strategy:      shared.InputProportionalWithMinimumShareSplit,
+			minShare:      0.9,
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 40, shared.Team2: 40, shared.Team3: 40},
+		},
+		{
+			name:          "no contributions",
+			contributions: map[shared.ClientID]shared.Resources{shared.Team1: 0, shared.Team2: 0},
+			strategy:      shared.EqualSplit,
+			want:          map[shared.ClientID]shared.Resources{shared.Team1: 0, shared.Team2: 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SplitForageReturn(total, tc.contributions, tc.strategy, tc.rankScores, tc.minShare)
+			if len(got) != len(tc.want) {
+				t.Fatalf("want %v got %v", tc.want, got)
+			}
+			for id, want := range tc.want {
+				if math.Abs(float64(got[id]-want)) > 1e-9 {
+					t.Errorf("%v: want %v got %v", id, want, got[id])
+				}
+			}
+		})
+	}
+}
+
+func TestCumulativeContributions(t *testing.T) {
+	history := map[shared.ForageType][]ForagingReport{
+		shared.DeerForageType: {
+			{ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team1: 10, shared.Team2: 5}},
+			{ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team1: 3}},
+		},
+		shared.FishForageType: {
+			{ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team2: 7, shared.Team3: 1}},
+		},
+	}
+	want := map[shared.ClientID]shared.Resources{shared.Team1: 13, shared.Team2: 12, shared.Team3: 1}
+	got := CumulativeContributions(history)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v got %v", want, got)
+	}
+}
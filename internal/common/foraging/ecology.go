@@ -0,0 +1,71 @@
+package foraging
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// CatchShock is a temporary change to the probability of catching deer and fish, caused by a disaster.
+// The zero value has no effect.
+type CatchShock struct {
+	Multiplier  float64 // multiplier applied to catch probabilities while the shock is in effect
+	ExpiresTurn uint    // first turn in which the shock is no longer in effect
+}
+
+// NewCatchShock returns the catch shock of a disaster in the given turn, felt with the given magnitude by the islands.
+// The shock is in effect for the dConf.CatchShockDuration turns following the disaster.
+func NewCatchShock(dConf config.DisasterConfig, felt shared.Magnitude, turn uint) CatchShock {
+	return CatchShock{
+		Multiplier:  math.Max(0, 1-dConf.CatchProbabilityShock*felt),
+		ExpiresTurn: turn + 1 + dConf.CatchShockDuration,
+	}
+}
+
+// CatchProbabilityMultiplier returns the multiplier on catch probabilities in the given turn
+func (c CatchShock) CatchProbabilityMultiplier(turn uint) float64 {
+	if turn < c.ExpiresTurn {
+		return c.Multiplier
+	}
+	return 1
+}
+
+// ShockDeerHuntConfig returns the deer hunt config with catch probabilities adjusted for the shock in the given turn.
+// The population-linked catch probability is linear in ThetaCritical and ThetaMax, so scaling both scales it.
+func (c CatchShock) ShockDeerHuntConfig(dhConf config.DeerHuntConfig, turn uint) config.DeerHuntConfig {
+	m := c.CatchProbabilityMultiplier(turn)
+	dhConf.ThetaCritical *= m
+	dhConf.ThetaMax *= m
+	return dhConf
+}
+
+// ShockFishingConfig returns the fishing config with catch probabilities adjusted for the shock in the given turn.
+// Catch probabilities only apply to a limited fish stock (MaxFishPopulation > 0).
+func (c CatchShock) ShockFishingConfig(fConf config.FishingConfig, turn uint) config.FishingConfig {
+	m := c.CatchProbabilityMultiplier(turn)
+	fConf.ThetaCritical *= m
+	fConf.ThetaMax *= m
+	return fConf
+}
+
+// DisasterStockLoss returns the fraction of a forage population killed by a disaster felt with the given magnitude
+func DisasterStockLoss(sensitivity float64, felt shared.Magnitude) float64 {
+	return math.Max(0, math.Min(sensitivity*felt, 1))
+}
+
+// Deplete removes a fraction of the deer population (e.g. killed by a disaster) without stepping the population model
+func (dp DeerPopulationModel) Deplete(fraction float64) DeerPopulationModel {
+	dp.Population *= 1 - math.Max(0, math.Min(fraction, 1))
+	dp.deProblem.Y0, dp.deProblem.T0 = dp.Population, int(dp.T)
+	dp.deState = dp.deProblem.StepDeltaY() // restart the DE from the depleted population
+	return dp
+}
+
+// Deplete removes a fraction of the fish population (e.g. killed by a disaster) without stepping the population model
+func (fp FishPopulationModel) Deplete(fraction float64) FishPopulationModel {
+	fp.Population *= 1 - math.Max(0, math.Min(fraction, 1))
+	fp.deProblem.Y0, fp.deProblem.T0 = fp.Population, int(fp.T)
+	fp.deState = fp.deProblem.StepDeltaY() // restart the DE from the depleted population
+	return fp
+}
@@ -0,0 +1,63 @@
+package foraging
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestCatchShock(t *testing.T) {
+	dConf := config.DisasterConfig{CatchProbabilityShock: 0.2, CatchShockDuration: 2}
+	shock := NewCatchShock(dConf, 2, 5) // disaster at the end of turn 5
+
+	cases := []struct {
+		turn uint
+		want float64
+	}{
+		{turn: 6, want: 0.6},
+		{turn: 7, want: 0.6},
+		{turn: 8, want: 1},
+	}
+	for _, tc := range cases {
+		if got := shock.CatchProbabilityMultiplier(tc.turn); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("turn %v: want multiplier %v, got %v", tc.turn, tc.want, got)
+		}
+	}
+
+	if got := NewCatchShock(dConf, 10, 5).Multiplier; got != 0 {
+		t.Errorf("expected the multiplier to be bounded at 0, got %v", got)
+	}
+	if got := (CatchShock{}).CatchProbabilityMultiplier(0); got != 1 {
+		t.Errorf("expected no shock by default, got %v", got)
+	}
+
+	dhConf := shock.ShockDeerHuntConfig(config.DeerHuntConfig{ThetaCritical: 0.5, ThetaMax: 0.9}, 6)
+	if math.Abs(dhConf.ThetaCritical-0.3) > 1e-9 || math.Abs(dhConf.ThetaMax-0.54) > 1e-9 {
+		t.Errorf("catch probabilities not scaled: %+v", dhConf)
+	}
+}
+
+func TestDeplete(t *testing.T) {
+	dhConf := config.DeerHuntConfig{MaxDeerPopulation: 20, DeerGrowthCoefficient: 0.4}
+	logger := func(format string, a ...interface{}) {}
+	dp := CreateDeerPopulationModel(dhConf, logger).Simulate([]int{5})
+
+	depleted := dp.Deplete(0.5)
+	if math.Abs(depleted.Population-dp.Population/2) > 1e-9 {
+		t.Errorf("want population %v, got %v", dp.Population/2, depleted.Population)
+	}
+	// the population regenerates from the depleted population
+	regenerated := depleted.Simulate([]int{0})
+	if regenerated.Population <= depleted.Population || regenerated.Population >= dp.Population {
+		t.Errorf("expected regeneration from %v towards but below %v, got %v", depleted.Population, dp.Population, regenerated.Population)
+	}
+	if regenerated.T != dp.T+1 {
+		t.Errorf("expected time to continue from %v, got %v", dp.T, regenerated.T)
+	}
+
+	if got := DisasterStockLoss(0.1, shared.Magnitude(20)); got != 1 {
+		t.Errorf("expected stock loss to be bounded at 1, got %v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package foraging
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// logisticFarmGrowthSteepness is the steepness of the LogisticFarmGrowth curve around halfway to maturity
+const logisticFarmGrowthSteepness = 10.0
+
+// FarmPlot captures an island's crop investment, which matures over several turns before it is harvested
+type FarmPlot struct {
+	Owner       shared.ClientID
+	Investment  shared.Resources
+	TurnPlanted uint
+	Grown       float64 // fraction of the potential yield grown so far, net of disaster damage
+}
+
+// PlantFarmPlot returns a new plot for an island's investment in crops
+func PlantFarmPlot(owner shared.ClientID, investment shared.Resources, turn uint) FarmPlot {
+	return FarmPlot{Owner: owner, Investment: investment, TurnPlanted: turn}
+}
+
+// Grow grows the crops in a plot over the given turn. Crops grow for fConf.MaturityTurns turns (including the turn in
+// which they were planted), following fConf.GrowthCurve. Returns the updated plot and whether it is ready to be harvested.
+func (p FarmPlot) Grow(fConf config.FarmingConfig, turn uint) (FarmPlot, bool) {
+	maturity := float64(maturityTurns(fConf))
+	age := float64(turn-p.TurnPlanted) + 1 // number of turns grown after this turn
+	if age < 1 {
+		return p, false
+	}
+	p.Grown += farmGrowth(fConf.GrowthCurve, math.Min(age/maturity, 1)) - farmGrowth(fConf.GrowthCurve, math.Min((age-1)/maturity, 1))
+	return p, age >= maturity
+}
+
+// Damage destroys the given fraction of the crops grown so far. Crops yet to grow are unaffected.
+func (p FarmPlot) Damage(fraction float64) FarmPlot {
+	p.Grown *= 1 - math.Max(0, math.Min(fraction, 1))
+	return p
+}
+
+// Harvest returns the foraging report of harvesting the crops in a plot. Turn should be populated by caller.
+func (p FarmPlot) Harvest(fConf config.FarmingConfig) ForagingReport {
+	yield := p.Investment * shared.Resources(fConf.YieldMultiplier*p.Grown)
+	return compileForagingReport(
+		shared.FarmForageType,
+		map[shared.ClientID]shared.Resources{p.Owner: p.Investment},
+		[]shared.Resources{yield},
+	)
+}
+
+// maturityTurns returns the number of turns crops grow for. Crops take at least a turn to grow.
+func maturityTurns(fConf config.FarmingConfig) uint {
+	if fConf.MaturityTurns == 0 {
+		return 1
+	}
+	return fConf.MaturityTurns
+}
+
+// farmGrowth returns the fraction of the potential yield grown after a fraction x in [0, 1] of the time to maturity.
+// All curves grow from 0 at x = 0 to 1 at x = 1.
+func farmGrowth(curve shared.FarmGrowthCurve, x float64) float64 {
+	switch curve {
+	case shared.LogisticFarmGrowth:
+		sigmoid := func(x float64) float64 { return 1 / (1 + math.Exp(-logisticFarmGrowthSteepness*(x-0.5))) }
+		return (sigmoid(x) - sigmoid(0)) / (sigmoid(1) - sigmoid(0)) // normalise to [0, 1]
+	case shared.ConcaveFarmGrowth:
+		return 1 - (1-x)*(1-x)
+	default: // shared.LinearFarmGrowth
+		return x
+	}
+}
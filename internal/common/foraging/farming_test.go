@@ -0,0 +1,72 @@
+package foraging
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestFarmGrowthCurves(t *testing.T) {
+	curves := []shared.FarmGrowthCurve{shared.LinearFarmGrowth, shared.LogisticFarmGrowth, shared.ConcaveFarmGrowth}
+	for _, curve := range curves {
+		t.Run(curve.String(), func(t *testing.T) {
+			if g := farmGrowth(curve, 0); math.Abs(g) > 1e-9 {
+				t.Errorf("Expected no growth at planting, got %v", g)
+			}
+			if g := farmGrowth(curve, 1); math.Abs(g-1) > 1e-9 {
+				t.Errorf("Expected full growth at maturity, got %v", g)
+			}
+			prev := 0.0
+			for x := 0.05; x <= 1; x += 0.05 {
+				g := farmGrowth(curve, x)
+				if g < prev {
+					t.Errorf("Growth decreased from %v to %v at %v", prev, g, x)
+				}
+				prev = g
+			}
+		})
+	}
+}
+
+func TestFarmPlotLifecycle(t *testing.T) {
+	fConf := config.FarmingConfig{MaturityTurns: 4, GrowthCurve: shared.LinearFarmGrowth, YieldMultiplier: 2}
+	plot := PlantFarmPlot(shared.Team1, 10, 3)
+
+	for turn := uint(3); turn <= 6; turn++ {
+		var mature bool
+		plot, mature = plot.Grow(fConf, turn)
+		if mature != (turn == 6) {
+			t.Errorf("Turn %v: got mature = %v", turn, mature)
+		}
+		if turn == 4 {
+			plot = plot.Damage(0.5) // half of the 50% grown so far is destroyed
+		}
+	}
+	if math.Abs(plot.Grown-0.75) > 1e-9 {
+		t.Errorf("Expected 75%% of the potential yield to be grown, got %v", plot.Grown)
+	}
+
+	report := plot.Harvest(fConf)
+	if report.ForageType != shared.FarmForageType || math.Abs(float64(report.TotalUtility)-15) > 1e-9 {
+		t.Errorf("Expected a farming harvest of 15, got %+v", report)
+	}
+	if report.ParticipantContributions[shared.Team1] != 10 {
+		t.Errorf("Expected the plot owner's investment in the report, got %v", report.ParticipantContributions)
+	}
+}
+
+func TestFarmPlotDestroyed(t *testing.T) {
+	fConf := config.FarmingConfig{MaturityTurns: 2, GrowthCurve: shared.ConcaveFarmGrowth, YieldMultiplier: 2}
+	plot := PlantFarmPlot(shared.Team1, 10, 1)
+	plot, _ = plot.Grow(fConf, 1)
+	plot = plot.Damage(1)
+	if plot.Grown != 0 {
+		t.Errorf("Expected all crops to be destroyed, got %v", plot.Grown)
+	}
+	plot, _ = plot.Grow(fConf, 2)
+	if math.Abs(plot.Grown-0.25) > 1e-9 { // concave growth: 75% in first turn, 25% in second
+		t.Errorf("Expected crops to regrow for the rest of the season only, got %v", plot.Grown)
+	}
+}
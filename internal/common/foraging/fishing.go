@@ -2,9 +2,11 @@ package foraging
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/stat/distuv"
 )
 
@@ -13,12 +15,14 @@ type FishingExpedition struct {
 	ParticipantContributions map[shared.ClientID]shared.Resources
 	params                   fishingParams
 	logger                   shared.Logger
+	src                      rand.Source // source of random numbers. nil for the global source
 }
 
-// fishingParams : Defines the parameters for the normal distibution for the fishing returns
+// fishingParams : Defines the parameters for the distribution of fishing returns
 type fishingParams struct {
-	Mu    float64
-	Sigma float64
+	Mu           float64 // mean fish size
+	Sigma        float64 // std. deviation of fish size
+	Distribution shared.FishSizeDistribution
 }
 
 // FishingReport holds information about the result of a fishing expedition
@@ -36,27 +40,79 @@ func (f FishingExpedition) TotalInput() shared.Resources {
 	return getTotalInput(f.ParticipantContributions)
 }
 
-// fishingReturn is the normal distibtuion
-func fishingReturn(params fishingParams) shared.Resources {
-	F := distuv.Normal{
-		Mu:    params.Mu,    // mean of the normal dist
-		Sigma: params.Sigma, // Var of the normal dist
+// fishingReturn samples the size of a fish from the distribution selected in params
+func fishingReturn(params fishingParams, src rand.Source) shared.Resources {
+	if params.Distribution == shared.LegacyNormalFishSize {
+		F := distuv.Normal{
+			Mu:    params.Mu,    // mean of the normal dist
+			Sigma: params.Sigma, // Var of the normal dist
+			Src:   src,
+		}
+		return shared.Resources(F.Rand())
 	}
-	return shared.Resources(F.Rand())
+	if params.Sigma <= 0 || params.Mu <= 0 { // degenerate distribution. Fish never have a negative size
+		return shared.Resources(math.Max(params.Mu, 0))
+	}
+
+	switch params.Distribution {
+	case shared.LogNormalFishSize:
+		// log-normal params giving the desired mean and variance
+		logVariance := math.Log1p((params.Sigma * params.Sigma) / (params.Mu * params.Mu))
+		F := distuv.LogNormal{Mu: math.Log(params.Mu) - logVariance/2, Sigma: math.Sqrt(logVariance), Src: src}
+		return shared.Resources(F.Rand())
+	case shared.GammaFishSize:
+		// shape and rate giving the desired mean and variance
+		variance := params.Sigma * params.Sigma
+		F := distuv.Gamma{Alpha: params.Mu * params.Mu / variance, Beta: params.Mu / variance, Src: src}
+		return shared.Resources(F.Rand())
+	default: // shared.TruncatedNormalFishSize
+		return shared.Resources(truncatedNormal(params.Mu, params.Sigma, src))
+	}
+}
+
+// truncatedNormal samples a normal distribution truncated below at zero by inverse transform sampling
+func truncatedNormal(mu, sigma float64, src rand.Source) float64 {
+	F := distuv.Normal{Mu: mu, Sigma: sigma}
+	lower := F.CDF(0)
+	if lower >= 1 { // (almost) all of the mass is below zero
+		return 0
+	}
+	U := distuv.Uniform{Min: lower, Max: 1, Src: src}
+	return math.Max(F.Quantile(U.Rand()), 0)
 }
 
-// Fish computes the return from a fishing expedition
-func (f FishingExpedition) Fish(fConf config.FishingConfig) ForagingReport {
+// Fish computes the return from a fishing expedition. Fish are caught with a probability linked to the running fish population,
+// unless the fish stock is unlimited (fConf.MaxFishPopulation = 0), in which case every fish attempted is caught.
+func (f FishingExpedition) Fish(fConf config.FishingConfig, fishPopulation uint) ForagingReport {
 	input := f.TotalInput()
 	// get max number of deer allowed for given resource input
 	nFishFromInput := utilityTier(input, fConf.MaxFishPerHunt, fConf.IncrementalInputDecay, fConf.InputScaler)
 	returns := []shared.Resources{} // store return for each potential fish we could catch
 
 	for i := uint(0); i < nFishFromInput; i++ {
-		utility := fishingReturn(f.params) * shared.Resources(fConf.OutputScaler) // scale return by resource multiplier
+		if fConf.MaxFishPopulation > 0 {
+			D := distuv.Bernoulli{P: math.Min(math.Max(f.getPopulationLinkedProbability(fConf, fishPopulation), 0), 1), Src: f.src} // P(X=1) bounded to [0, 1]
+			if fishPopulation == 0 || D.Rand() == 0 {
+				returns = append(returns, 0) // no fish caught
+				continue
+			}
+		}
+		utility := fishingReturn(f.params, f.src) * shared.Resources(fConf.OutputScaler) // scale return by resource multiplier
 		returns = append(returns, utility)
+		if utility > 0 && fishPopulation > 0 { // a fish was caught and so should be removed from population
+			fishPopulation--
+		}
 	}
-	return compileForagingReport(shared.FishForageType, f.ParticipantContributions, returns)
+	report := compileForagingReport(shared.FishForageType, f.ParticipantContributions, returns)
+	distribution := f.params.Distribution
+	report.FishSizeDistribution = &distribution
+	return report
+}
+
+// getPopulationLinkedProbability returns the Bernoulli probability of catching a fish given the current running fish population.
+// The mapping is the same as for deer, and is documented in the README in this package.
+func (f FishingExpedition) getPopulationLinkedProbability(fConf config.FishingConfig, population uint) float64 {
+	return populationLinkedProbability(population, fConf.MaxFishPopulation, fConf.MaxFishPerHunt, fConf.ThetaCritical, fConf.ThetaMax, f.Logf)
 }
 
 // Logf is a this type's custom logger
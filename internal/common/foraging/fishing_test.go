@@ -66,7 +66,7 @@ func TestFishReturn(t *testing.T) {
 	params := fishingParams{Mu: 0.9, Sigma: 0.2}
 	avReturn := 0.0
 	for i := 1; i <= 1000; i++ { // calculate empirical mean return over 1000 trials
-		d := fishingReturn(params)
+		d := fishingReturn(params, nil)
 		avReturn = (avReturn*(float64(i)-1) + float64(d)) / float64(i)
 	}
 	expectedReturn := params.Mu                                 // theoretical mean based on defined expectation
@@ -75,3 +75,62 @@ func TestFishReturn(t *testing.T) {
 		t.Errorf("Empirical mean return deviated from theoretical for > 1.2 percent: got %.5f, want %.5f", avReturn, expectedReturn)
 	}
 }
+
+func TestFishSizeDistributions(t *testing.T) {
+	distributions := []shared.FishSizeDistribution{shared.TruncatedNormalFishSize, shared.LogNormalFishSize, shared.GammaFishSize}
+	for _, distribution := range distributions {
+		t.Run(distribution.String(), func(t *testing.T) {
+			params := fishingParams{Mu: 1.45, Sigma: math.Sqrt(0.1), Distribution: distribution}
+			n := 20000
+			samples := make([]float64, n)
+			mean := 0.0
+			for i := range samples {
+				samples[i] = float64(fishingReturn(params, nil))
+				if samples[i] < 0 {
+					t.Fatalf("sampled a negative fish size: %v", samples[i])
+				}
+				mean += samples[i] / float64(n)
+			}
+			variance := 0.0
+			for _, x := range samples {
+				variance += (x - mean) * (x - mean) / float64(n-1)
+			}
+			if math.Abs(mean-params.Mu) > 0.02 {
+				t.Errorf("want mean %v, got %v", params.Mu, mean)
+			}
+			if math.Abs(variance-0.1) > 0.01 {
+				t.Errorf("want variance 0.1, got %v", variance)
+			}
+		})
+	}
+}
+
+func TestTruncatedNormalNeverNegative(t *testing.T) {
+	params := fishingParams{Mu: 0.1, Sigma: 1, Distribution: shared.TruncatedNormalFishSize}
+	for i := 0; i < 1000; i++ {
+		if d := fishingReturn(params, nil); d < 0 {
+			t.Fatalf("sampled a negative fish size: %v", d)
+		}
+	}
+	if d := truncatedNormal(-100, 1, nil); d != 0 {
+		t.Errorf("expected 0 when all of the mass is below zero, got %v", d)
+	}
+}
+
+func TestFishingReportRecordsSizeDistribution(t *testing.T) {
+	fConf := config.FishingConfig{
+		MaxFishPerHunt:        4,
+		IncrementalInputDecay: 0.8,
+		Mean:                  1,
+		Variance:              0.1,
+		InputScaler:           1,
+		OutputScaler:          1,
+		FishSizeDistribution:  shared.GammaFishSize,
+	}
+	logger := func(format string, a ...interface{}) {}
+	expedition, _ := CreateFishingExpedition(map[shared.ClientID]shared.Resources{shared.Team1: 3}, fConf, logger)
+	report := expedition.Fish(fConf, 0)
+	if report.FishSizeDistribution == nil || *report.FishSizeDistribution != shared.GammaFishSize {
+		t.Errorf("want fish size distribution %v recorded in report, got %v", shared.GammaFishSize, report.FishSizeDistribution)
+	}
+}
@@ -0,0 +1,56 @@
+package foraging
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/simulation"
+)
+
+// FishPopulationModel encapsulates a fish population over time (governed by a predefined DE)
+type FishPopulationModel struct {
+	deProblem  simulation.ODEProblem        // definition of DE governing rate of change of fish pop.
+	Population float64                      // current number of fish in env
+	T          float64                      // temporal parameter. Time, turn or whatever other incarnation
+	deState    func(float64) (t, y float64) // holds internal state of DE and allows to call next solution step
+	logger     shared.Logger
+}
+
+// Logf is a this type's custom logger
+func (fp FishPopulationModel) Logf(format string, a ...interface{}) {
+	fp.logger("[FISHPOPULATION]: %v", fmt.Sprintf(format, a...))
+}
+
+// createLogisticFishPopulationModel returns a population model based on the logistic equation dP/dt = rP(1-P/K).
+// r = growth rate, K = max fish (constants). Note that a fished-out population (P=0) never recovers.
+func createLogisticFishPopulationModel(fConf config.FishingConfig, logger shared.Logger) FishPopulationModel {
+	maxFish := float64(fConf.MaxFishPopulation)
+	fishPopulationGrowth := getPopulationGrowth(shared.LogisticPopulationModel, populationGrowthParams{
+		growthCoeff: fConf.FishGrowthRate,
+		capacity:    maxFish,
+	})
+	fp := FishPopulationModel{
+		deProblem:  simulation.ODEProblem{YPrime: fishPopulationGrowth, Y0: maxFish, T0: 0, DtStep: 0.1},
+		Population: maxFish,
+		T:          .0,
+		logger:     logger,
+	}
+	fp.deState = fp.deProblem.StepDeltaY() // initialise DE state
+	return fp
+}
+
+// Simulate method simulates the reaction of a fish pop. over i=len(fishConsumption) days where [0, maxFish] are caught each day i.
+// Note: if only simulating for one turn ('step'), len(fishConsumption) = 1
+func (fp FishPopulationModel) Simulate(fishConsumption []int) FishPopulationModel {
+	deStep := fp.deState // this is gets a function from deState closure containing latest state. Allows us to step next solution.
+
+	for i := 0; i < len(fishConsumption); i++ {
+		y0 := fp.Population - float64(fishConsumption[i])
+		t, y := deStep(float64(-fishConsumption[i]))
+
+		fp.Population, fp.T = y, t
+		fp.Logf("P(t): %.2f. \tPopulation after %v fish caught: %v, \tpopulation at end of turn (after regeneration): %v\n", y, fishConsumption[i], int(y0), int(y))
+	}
+	return fp
+}
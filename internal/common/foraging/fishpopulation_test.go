@@ -0,0 +1,75 @@
+package foraging
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestFishPopulationRegeneration(t *testing.T) {
+	fConf := config.FishingConfig{MaxFishPopulation: 50, FishGrowthRate: 0.5}
+	dummyLogger := func(format string, a ...interface{}) {
+		t.Logf("[FISHPOPULATION]: %v", fmt.Sprintf(format, a...))
+	}
+	fp := CreateFishPopulationModel(fConf, dummyLogger)
+	if fp.Population != 50 {
+		t.Errorf("Expected initial population at carrying capacity 50, got %v", fp.Population)
+	}
+
+	fp = fp.Simulate([]int{40})
+	depleted := fp.Population
+	if depleted <= 10 || depleted >= 50 {
+		t.Errorf("Expected population to regenerate to between 10 and 50 after 40 fish caught, got %v", depleted)
+	}
+
+	fp = fp.Simulate(make([]int, 30)) // 30 turns without fishing
+	if fp.Population <= depleted || fp.Population > 50 {
+		t.Errorf("Expected population to recover towards 50 without fishing, got %v", fp.Population)
+	}
+	if fp.T != 31 {
+		t.Errorf("Expected T = 31 after 31 steps, got %v", fp.T)
+	}
+}
+
+func TestFishPopulationCollapse(t *testing.T) {
+	fConf := config.FishingConfig{MaxFishPopulation: 50, FishGrowthRate: 0.5}
+	fp := CreateFishPopulationModel(fConf, func(format string, a ...interface{}) {})
+
+	fp = fp.Simulate([]int{50}) // fished out
+	fp = fp.Simulate(make([]int, 10))
+	if fp.Population != 0 {
+		t.Errorf("Expected fished out population to stay extinct, got %v", fp.Population)
+	}
+}
+
+func TestFishingDepletedStock(t *testing.T) {
+	fConf := config.FishingConfig{
+		MaxFishPerHunt:        6,
+		IncrementalInputDecay: 0.8,
+		Mean:                  1,
+		Variance:              0.1,
+		InputScaler:           1,
+		OutputScaler:          1,
+		MaxFishPopulation:     30,
+		ThetaCritical:         0.97,
+		ThetaMax:              0.99,
+	}
+	participants := map[shared.ClientID]shared.Resources{shared.Team1: 1000}
+	dummyLogger := func(format string, a ...interface{}) {}
+	expedition, _ := CreateFishingExpedition(participants, fConf, dummyLogger)
+
+	for population := uint(0); population <= 3; population++ {
+		report := expedition.Fish(fConf, population)
+		if report.NumberCaught > population {
+			t.Errorf("Caught %v fish from a population of %v", report.NumberCaught, population)
+		}
+	}
+
+	fConf.MaxFishPopulation = 0 // unlimited stock
+	report := expedition.Fish(fConf, 0)
+	if report.NumberCaught != fConf.MaxFishPerHunt {
+		t.Errorf("Expected to catch %v fish from unlimited stock, got %v", fConf.MaxFishPerHunt, report.NumberCaught)
+	}
+}
@@ -17,6 +17,18 @@ type ForagingReport struct {
 	TotalUtility             shared.Resources // total return of foraging session before distribution
 	CatchSizes               []float64        // sizes/weights of individual deer/fish/... caught
 	Turn                     uint             // turn in which this report was generated. Should be populated by caller
+
+	// Party is the hunting party that foraged in this session. nil if the session was open to all islands
+	Party *shared.HuntingParty
+	// FishSizeDistribution is the distribution fish sizes were sampled from. nil for other forage types
+	FishSizeDistribution *shared.FishSizeDistribution
+}
+
+// PopulationRecord captures the population of a forage type at the end of a turn
+type PopulationRecord struct {
+	Turn        uint    // turn at the end of which the population was recorded
+	Population  float64 // population after consumption and regeneration
+	Consumption uint    // number of deer/fish/... caught in the turn
 }
 
 func getTotalInput(contribs map[shared.ClientID]shared.Resources) shared.Resources {
@@ -78,5 +90,37 @@ func (f ForagingReport) Copy() ForagingReport {
 		catchSizes[i] = cs
 	}
 	ret.CatchSizes = catchSizes
+	if f.Party != nil {
+		party := f.Party.Copy()
+		ret.Party = &party
+	}
+	if f.FishSizeDistribution != nil {
+		distribution := *f.FishSizeDistribution
+		ret.FishSizeDistribution = &distribution
+	}
 	return ret
 }
+
+// populationLinkedProbability returns the Bernoulli probability of catching a single animal given the current running population.
+// The dynamics and variables implemented in this function are documented in the README in this package.
+func populationLinkedProbability(population, maxPopulation, maxPerHunt uint, thetaCritical, thetaMax float64, logf shared.Logger) float64 {
+	pCritical := 1.0
+	pMax := float64(maxPopulation) / float64(maxPerHunt)
+	p := float64(population) / float64(maxPerHunt)
+
+	if pMax == pCritical { // catch the special case where max per hunt = max population. You've got params wrong if you're here though :)
+		logf("Warning: detected pMax = pCritical. This is likely because you've set max per hunt to max population. Should have max pop. > max per hunt.")
+		return thetaCritical * float64(p) // same as f1. Avoid calculating alpha
+	}
+	alpha := (thetaMax - thetaCritical) / (pMax - float64(pCritical))
+
+	f1 := func(p float64) float64 { return thetaCritical * float64(p) }
+	f2 := func(p float64) float64 { return alpha*float64((p-pCritical)) + thetaCritical }
+
+	if p < pCritical {
+		theta := f1(p)
+		logf("Population ratio below critical level: P(t)=%v, p=%v, p_crit=%v, theta=%.2f", population, p, pCritical, theta)
+		return theta
+	}
+	return f2(p)
+}
@@ -0,0 +1,116 @@
+package foraging
+
+import (
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat"
+)
+
+// oracleSeed seeds the random numbers of the sessions sampled by EstimateForageReturn
+const oracleSeed = 1
+
+// ReturnQuantileLevels are the levels of the quantiles reported in a ReturnDistribution
+var ReturnQuantileLevels = []float64{0.05, 0.25, 0.5, 0.75, 0.95}
+
+// ReturnQuantile is the return that is not exceeded with probability Level
+type ReturnQuantile struct {
+	Level float64
+	Value shared.Resources
+}
+
+// ReturnDistribution summarises the distribution of the resources returned to a participant of a foraging session.
+// The return is gross, i.e. before subtracting the participant's contribution.
+type ReturnDistribution struct {
+	Mean      shared.Resources
+	Variance  float64
+	Quantiles []ReturnQuantile // quantiles at ReturnQuantileLevels
+}
+
+// ForageReturnQuery describes a hypothetical foraging session whose return is to be estimated
+type ForageReturnQuery struct {
+	ForageType    shared.ForageType
+	Participant   shared.ClientID                      // participant whose return is estimated
+	Contributions map[shared.ClientID]shared.Resources // contributions of all participants, including Participant
+	Population    uint                                 // running population of the forage type. Ignored for farming
+	SplitStrategy shared.ResourceDistributionStrategy  // basis on which the return is split amongst participants
+	RankScores    map[shared.ClientID]float64          // only used by RankProportionalSplit. Higher score => higher rank
+}
+
+// EstimateForageReturn estimates the distribution of the return to q.Participant by sampling nSamples foraging sessions
+// described by q. Farming returns are deterministic (disasters aside), so a single harvest is evaluated instead.
+// Sessions are sampled from a private source of random numbers, so estimates do not affect the game's random numbers
+// and the same query always gives the same estimate.
+func EstimateForageReturn(q ForageReturnQuery, fConf config.ForagingConfig, nSamples uint) (ReturnDistribution, error) {
+	if _, ok := q.Contributions[q.Participant]; !ok {
+		return ReturnDistribution{}, errors.Errorf("No contribution specified for participant %v", q.Participant)
+	}
+	if nSamples == 0 {
+		return ReturnDistribution{}, errors.Errorf("Cannot estimate foraging return from zero samples")
+	}
+	silent := func(format string, a ...interface{}) {} // sampled sessions should not flood the logs
+	src := rand.New(rand.NewSource(oracleSeed))
+
+	var sample func() shared.Resources
+	switch q.ForageType {
+	case shared.DeerForageType:
+		hunt, err := CreateDeerHunt(q.Contributions, fConf.DeerHuntConfig, silent)
+		if err != nil {
+			return ReturnDistribution{}, err
+		}
+		hunt.src = src
+		sample = func() shared.Resources { return hunt.Hunt(fConf.DeerHuntConfig, q.Population).TotalUtility }
+	case shared.FishForageType:
+		expedition, err := CreateFishingExpedition(q.Contributions, fConf.FishingConfig, silent)
+		if err != nil {
+			return ReturnDistribution{}, err
+		}
+		expedition.src = src
+		sample = func() shared.Resources { return expedition.Fish(fConf.FishingConfig, q.Population).TotalUtility }
+	case shared.FarmForageType:
+		harvest := harvestUndamaged(q.Participant, q.Contributions[q.Participant], fConf.FarmingConfig)
+		return summariseReturns([]float64{float64(harvest)}), nil
+	default:
+		return ReturnDistribution{}, errors.Errorf("Unknown forage type %v", q.ForageType)
+	}
+
+	returns := make([]float64, nSamples)
+	for i := range returns {
+		shares := SplitForageReturn(sample(), q.Contributions, q.SplitStrategy, q.RankScores, fConf.MinimumShare)
+		returns[i] = float64(shares[q.Participant])
+	}
+	return summariseReturns(returns), nil
+}
+
+// harvestUndamaged returns the harvest of a farm plot that grows to maturity without being hit by a disaster
+func harvestUndamaged(owner shared.ClientID, investment shared.Resources, fConf config.FarmingConfig) shared.Resources {
+	plot := PlantFarmPlot(owner, investment, 0)
+	for turn, mature := uint(0), false; !mature; turn++ {
+		plot, mature = plot.Grow(fConf, turn)
+	}
+	return plot.Harvest(fConf).TotalUtility
+}
+
+// summariseReturns computes the mean, variance and quantiles of sampled returns
+func summariseReturns(returns []float64) ReturnDistribution {
+	sort.Float64s(returns)
+	mean, variance := stat.MeanVariance(returns, nil)
+	if len(returns) < 2 {
+		variance = 0 // undefined for a single sample, but a single sample is only used for deterministic returns
+	}
+	quantiles := make([]ReturnQuantile, len(ReturnQuantileLevels))
+	for i, level := range ReturnQuantileLevels {
+		quantiles[i] = ReturnQuantile{
+			Level: level,
+			Value: shared.Resources(stat.Quantile(level, stat.Empirical, returns, nil)),
+		}
+	}
+	return ReturnDistribution{
+		Mean:      shared.Resources(mean),
+		Variance:  variance,
+		Quantiles: quantiles,
+	}
+}
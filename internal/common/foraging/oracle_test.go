@@ -0,0 +1,157 @@
+package foraging
+
+import (
+	"math"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"golang.org/x/exp/rand"
+)
+
+func TestEstimateForageReturnDeer(t *testing.T) {
+	fConf := config.ForagingConfig{
+		DeerHuntConfig: config.DeerHuntConfig{
+			MaxDeerPerHunt:        4,
+			IncrementalInputDecay: 0.8,
+			BernoulliProb:         0.95,
+			ExponentialRate:       1,
+			InputScaler:           1,
+			OutputScaler:          1,
+			MaxDeerPopulation:     12,
+			ThetaCritical:         0.8,
+			ThetaMax:              0.95,
+		},
+	}
+	q := ForageReturnQuery{
+		ForageType:    shared.DeerForageType,
+		Participant:   shared.Team1,
+		Contributions: map[shared.ClientID]shared.Resources{shared.Team1: 3, shared.Team2: 3},
+		Population:    12,
+		SplitStrategy: shared.InputProportionalSplit,
+	}
+	got, err := EstimateForageReturn(q, fConf, 2000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Mean <= 0 || got.Variance <= 0 {
+		t.Errorf("expected a positive mean and variance, got %+v", got)
+	}
+	if len(got.Quantiles) != len(ReturnQuantileLevels) {
+		t.Fatalf("want %v quantiles, got %v", len(ReturnQuantileLevels), len(got.Quantiles))
+	}
+	for i := 1; i < len(got.Quantiles); i++ {
+		if got.Quantiles[i].Value < got.Quantiles[i-1].Value {
+			t.Errorf("quantiles not increasing: %+v", got.Quantiles)
+		}
+	}
+
+	// an equal contribution to an input proportional split gets half of the total return
+	total, _ := EstimateForageReturn(ForageReturnQuery{
+		ForageType:    shared.DeerForageType,
+		Participant:   shared.Team1,
+		Contributions: map[shared.ClientID]shared.Resources{shared.Team1: 6},
+		Population:    12,
+	}, fConf, 2000)
+	if math.Abs(float64(got.Mean)-float64(total.Mean)/2) > 0.15*float64(total.Mean) {
+		t.Errorf("expected a mean of about half of %v, got %v", total.Mean, got.Mean)
+	}
+
+	// no deer => no return
+	q.Population = 0
+	extinct, _ := EstimateForageReturn(q, fConf, 100)
+	if extinct.Mean != 0 || extinct.Variance != 0 {
+		t.Errorf("expected no return from an extinct population, got %+v", extinct)
+	}
+}
+
+func TestEstimateForageReturnLeavesGlobalRandomSources(t *testing.T) {
+	fConf := config.ForagingConfig{
+		FishingConfig: config.FishingConfig{
+			MaxFishPerHunt:        6,
+			IncrementalInputDecay: 0.8,
+			Mean:                  1,
+			Variance:              0.2,
+			InputScaler:           1,
+			OutputScaler:          1,
+		},
+	}
+	q := ForageReturnQuery{
+		ForageType:    shared.FishForageType,
+		Participant:   shared.Team1,
+		Contributions: map[shared.ClientID]shared.Resources{shared.Team1: 5},
+	}
+
+	rand.Seed(7)
+	mathrand.Seed(7)
+	want, wantMath := rand.Uint64(), mathrand.Int63()
+	rand.Seed(7)
+	mathrand.Seed(7)
+	first, err := EstimateForageReturn(q, fConf, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, gotMath := rand.Uint64(), mathrand.Int63(); got != want || gotMath != wantMath {
+		t.Errorf("estimating a return changed the global random numbers")
+	}
+
+	second, _ := EstimateForageReturn(q, fConf, 100)
+	if first.Mean != second.Mean || first.Variance != second.Variance {
+		t.Errorf("expected the same estimate for the same query, got %+v and %+v", first, second)
+	}
+}
+
+func TestEstimateForageReturnFarm(t *testing.T) {
+	fConf := config.ForagingConfig{
+		FarmingConfig: config.FarmingConfig{MaturityTurns: 3, GrowthCurve: shared.LogisticFarmGrowth, YieldMultiplier: 1.5},
+	}
+	q := ForageReturnQuery{
+		ForageType:    shared.FarmForageType,
+		Participant:   shared.Team1,
+		Contributions: map[shared.ClientID]shared.Resources{shared.Team1: 10},
+	}
+	got, err := EstimateForageReturn(q, fConf, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(float64(got.Mean)-15) > 1e-9 || got.Variance != 0 {
+		t.Errorf("want a certain return of 15, got %+v", got)
+	}
+	for _, quantile := range got.Quantiles {
+		if quantile.Value != got.Mean {
+			t.Errorf("want all quantiles equal to the mean, got %+v", got.Quantiles)
+		}
+	}
+}
+
+func TestEstimateForageReturnErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		q        ForageReturnQuery
+		nSamples uint
+	}{
+		{
+			name:     "participant without contribution",
+			q:        ForageReturnQuery{Participant: shared.Team1, Contributions: map[shared.ClientID]shared.Resources{shared.Team2: 1}},
+			nSamples: 10,
+		},
+		{
+			name:     "zero samples",
+			q:        ForageReturnQuery{Participant: shared.Team1, Contributions: map[shared.ClientID]shared.Resources{shared.Team1: 1}},
+			nSamples: 0,
+		},
+		{
+			name:     "unknown forage type",
+			q:        ForageReturnQuery{ForageType: -1, Participant: shared.Team1, Contributions: map[shared.ClientID]shared.Resources{shared.Team1: 1}},
+			nSamples: 10,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := EstimateForageReturn(tc.q, config.ForagingConfig{}, tc.nSamples); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package foraging
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// populationGrowth is the rate of change dP/dt of a population P=y at time t
+type populationGrowth = func(t, y float64) float64
+
+// populationGrowthParams holds the constants a population growth model may depend on
+type populationGrowthParams struct {
+	growthCoeff    float64 // k. Larger coeff => population regenerates faster
+	capacity       float64 // N. Carrying capacity (max population)
+	alleeThreshold float64 // A. Critical population below which an Allee population collapses
+}
+
+// populationGrowthModels is the registry of population growth models that can be selected in config.
+// To add a new model, add a shared.PopulationModelType and register its DE here.
+var populationGrowthModels = map[shared.PopulationModelType]func(populationGrowthParams) populationGrowth{
+	shared.BasicPopulationModel:    basicPopulationGrowth,
+	shared.LogisticPopulationModel: logisticPopulationGrowth,
+	shared.AlleePopulationModel:    alleePopulationGrowth,
+	shared.GompertzPopulationModel: gompertzPopulationGrowth,
+}
+
+// getPopulationGrowth returns the DE of the given model type. Unknown types fall back to BasicPopulationModel
+func getPopulationGrowth(modelType shared.PopulationModelType, params populationGrowthParams) populationGrowth {
+	if model, ok := populationGrowthModels[modelType]; ok {
+		return model(params)
+	}
+	return basicPopulationGrowth(params)
+}
+
+// basicPopulationGrowth is dP/dt = k(N-P). Note that the population regenerates even from zero
+func basicPopulationGrowth(params populationGrowthParams) populationGrowth {
+	return func(t, y float64) float64 {
+		return params.growthCoeff * (params.capacity - y)
+	}
+}
+
+// logisticPopulationGrowth is dP/dt = kP(1-P/N). An extinct population (P=0) never recovers
+func logisticPopulationGrowth(params populationGrowthParams) populationGrowth {
+	return func(t, y float64) float64 {
+		if y <= 0 || params.capacity == 0 {
+			return 0
+		}
+		return params.growthCoeff * y * (1 - y/params.capacity)
+	}
+}
+
+// alleePopulationGrowth is dP/dt = kP(1-P/N)(P/A-1). Below the critical population A, growth is negative and the
+// population collapses. A <= 0 reduces to logistic growth
+func alleePopulationGrowth(params populationGrowthParams) populationGrowth {
+	if params.alleeThreshold <= 0 {
+		return logisticPopulationGrowth(params)
+	}
+	logistic := logisticPopulationGrowth(params)
+	return func(t, y float64) float64 {
+		return logistic(t, y) * (y/params.alleeThreshold - 1)
+	}
+}
+
+// gompertzPopulationGrowth is dP/dt = kP ln(N/P). Growth is fastest at P=N/e and, compared to logistic growth,
+// slower near capacity
+func gompertzPopulationGrowth(params populationGrowthParams) populationGrowth {
+	return func(t, y float64) float64 {
+		if y <= 0 || params.capacity == 0 {
+			return 0
+		}
+		return params.growthCoeff * y * math.Log(params.capacity/y)
+	}
+}
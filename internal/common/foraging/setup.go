@@ -1,6 +1,8 @@
 package foraging
 
 import (
+	"math"
+
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 	"github.com/pkg/errors"
@@ -21,11 +23,19 @@ func CreateFishingExpedition(teamResourceInputs map[shared.ClientID]shared.Resou
 	if len(teamResourceInputs) == 0 {
 		return FishingExpedition{}, errors.Errorf("No fishing resource contributions specified!")
 	}
-	params := fishingParams{Mu: fConf.Mean, Sigma: fConf.Variance}
+	params := fishingParams{Mu: fConf.Mean, Sigma: math.Sqrt(math.Max(fConf.Variance, 0)), Distribution: fConf.FishSizeDistribution}
+	if fConf.FishSizeDistribution == shared.LegacyNormalFishSize {
+		params.Sigma = fConf.Variance // the legacy distribution uses the variance as std. deviation
+	}
 	return FishingExpedition{ParticipantContributions: teamResourceInputs, params: params, logger: logger}, nil // returning error too for future use
 }
 
-// CreateDeerPopulationModel returns the target population model. The formulation of this model should be changed here before runtime
+// CreateDeerPopulationModel returns the target population model. The formulation of this model is selected by dhConf.DeerPopulationModel
 func CreateDeerPopulationModel(dhConf config.DeerHuntConfig, logger shared.Logger) DeerPopulationModel {
-	return createBasicDeerPopulationModel(dhConf, logger)
+	return createDeerPopulationModelFromConfig(dhConf, logger)
+}
+
+// CreateFishPopulationModel returns the target fish population model
+func CreateFishPopulationModel(fConf config.FishingConfig, logger shared.Logger) FishPopulationModel {
+	return createLogisticFishPopulationModel(fConf, logger)
 }
@@ -38,4 +38,7 @@ type ClientGameState struct {
 
 	// RuleInfo contains the global rules information for clients to access
 	RulesInfo RulesContext
+
+	// ForecastingAccuracy is the record of how well each island's disaster predictions have matched reality
+	ForecastingAccuracy map[shared.ClientID]disasters.ForecastingAccuracy
 }
@@ -2,10 +2,13 @@
 package gamestate
 
 import (
+	"fmt"
+
 	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
 )
 
 // GameState represents the game's state.
@@ -23,9 +26,16 @@ type GameState struct {
 	ClientInfos    map[shared.ClientID]ClientInfo
 	Environment    disasters.Environment
 	DeerPopulation foraging.DeerPopulationModel
+	FishPopulation foraging.FishPopulationModel
 
 	// Foraging History
 	ForagingHistory map[shared.ForageType][]foraging.ForagingReport
+	// Population of each forage type at the end of each turn
+	PopulationHistory map[shared.ForageType][]foraging.PopulationRecord
+	// Farm plots that are yet to be harvested
+	FarmPlots []foraging.FarmPlot
+	// Temporary change to catch probabilities after a disaster
+	CatchShock foraging.CatchShock
 
 	// All global rules information
 	RulesInfo RulesContext
@@ -75,6 +85,12 @@ type GameState struct {
 	// IITO Transactions
 	IITOTransactions map[shared.ClientID]shared.GiftResponseDict
 
+	// IIFO disaster predictions waiting to be scored against the next disaster
+	IIFOPendingPredictions map[shared.ClientID][]disasters.PendingPrediction
+
+	// IIFO forecasting accuracy of each island's disaster predictions
+	IIFOForecastingAccuracy map[shared.ClientID]disasters.ForecastingAccuracy
+
 	// Orchestration
 	SpeakerID   shared.ClientID
 	JudgeID     shared.ClientID
@@ -90,7 +106,10 @@ func (g GameState) Copy() GameState {
 	ret.ClientInfos = copyClientInfos(g.ClientInfos)
 	ret.Environment = g.Environment.Copy()
 	ret.DeerPopulation = g.DeerPopulation.Copy()
+	ret.FishPopulation = g.FishPopulation.Copy()
 	ret.ForagingHistory = copyForagingHistory(g.ForagingHistory)
+	ret.PopulationHistory = copyPopulationHistory(g.PopulationHistory)
+	ret.FarmPlots = copyFarmPlots(g.FarmPlots)
 	ret.RulesInfo = copyRulesContext(g.RulesInfo)
 	ret.IIGOHistory = copyIIGOHistory(g.IIGOHistory)
 	ret.IIGORolesBudget = copyRolesBudget(g.IIGORolesBudget)
@@ -103,6 +122,8 @@ func (g GameState) Copy() GameState {
 	ret.IIGORoleMonitoringCache = copySingleIIGOEntry(g.IIGORoleMonitoringCache)
 	ret.IITOTransactions = copyIITOTransactions(g.IITOTransactions)
 	ret.IIGOElection = copyIIGOElection(g.IIGOElection)
+	ret.IIFOPendingPredictions = copyIIFOPendingPredictions(g.IIFOPendingPredictions)
+	ret.IIFOForecastingAccuracy = copyIIFOForecastingAccuracy(g.IIFOForecastingAccuracy)
 	return ret
 }
 
@@ -114,18 +135,19 @@ func (g *GameState) GetClientGameStateCopy(id shared.ClientID) ClientGameState {
 	}
 
 	return ClientGameState{
-		Season:             g.Season,
-		Turn:               g.Turn,
-		ClientInfo:         g.ClientInfos[id].Copy(),
-		ClientLifeStatuses: clientLifeStatuses,
-		CommonPool:         g.CommonPool,
-		Geography:          g.Environment.Geography,
-		SpeakerID:          g.SpeakerID,
-		JudgeID:            g.JudgeID,
-		PresidentID:        g.PresidentID,
-		IIGORolesBudget:    copyRolesBudget(g.IIGORolesBudget),
-		IIGOTurnsInPower:   copyTurnsInPower(g.IIGOTurnsInPower),
-		RulesInfo:          copyRulesContext(g.RulesInfo),
+		Season:              g.Season,
+		Turn:                g.Turn,
+		ClientInfo:          g.ClientInfos[id].Copy(),
+		ClientLifeStatuses:  clientLifeStatuses,
+		CommonPool:          g.CommonPool,
+		Geography:           g.Environment.Geography,
+		SpeakerID:           g.SpeakerID,
+		JudgeID:             g.JudgeID,
+		PresidentID:         g.PresidentID,
+		IIGORolesBudget:     copyRolesBudget(g.IIGORolesBudget),
+		IIGOTurnsInPower:    copyTurnsInPower(g.IIGOTurnsInPower),
+		RulesInfo:           copyRulesContext(g.RulesInfo),
+		ForecastingAccuracy: copyIIFOForecastingAccuracy(g.IIFOForecastingAccuracy),
 	}
 }
 
@@ -184,7 +206,22 @@ func copyRulesContext(oldContext RulesContext) RulesContext {
 		VariableMap:        copyVariableMap(oldContext.VariableMap),
 		CurrentRulesInPlay: rules.CopyRulesMap(oldContext.CurrentRulesInPlay),
 		AvailableRules:     rules.CopyRulesMap(oldContext.AvailableRules),
+		RuleHistory:        copyRuleHistory(oldContext.RuleHistory),
+	}
+}
+
+func copyRuleHistory(history map[string][]RuleVersion) map[string][]RuleVersion {
+	targetMap := make(map[string][]RuleVersion, len(history))
+	for name, versions := range history {
+		targetVersions := make([]RuleVersion, len(versions))
+		for i, version := range versions {
+			targetVersions[i] = version
+			targetVersions[i].PreviousRule = rules.CopyRuleMatrix(version.PreviousRule)
+			targetVersions[i].Rule = rules.CopyRuleMatrix(version.Rule)
+		}
+		targetMap[name] = targetVersions
 	}
+	return targetMap
 }
 
 func copyVariableMap(varMap map[rules.VariableFieldName]rules.VariableValuePair) map[rules.VariableFieldName]rules.VariableValuePair {
@@ -235,12 +272,44 @@ func copyForagingHistory(fHist map[shared.ForageType][]foraging.ForagingReport)
 	return ret
 }
 
+func copyPopulationHistory(pHist map[shared.ForageType][]foraging.PopulationRecord) map[shared.ForageType][]foraging.PopulationRecord {
+	ret := make(map[shared.ForageType][]foraging.PopulationRecord, len(pHist))
+	for k, v := range pHist {
+		ret[k] = make([]foraging.PopulationRecord, len(v))
+		copy(ret[k], v)
+	}
+	return ret
+}
+
+func copyFarmPlots(plots []foraging.FarmPlot) []foraging.FarmPlot {
+	ret := make([]foraging.FarmPlot, len(plots))
+	copy(ret, plots)
+	return ret
+}
+
 func copyIIGOElection(input []VotingInfo) []VotingInfo {
 	ret := make([]VotingInfo, len(input))
 	copy(ret, input)
 	return ret
 }
 
+func copyIIFOPendingPredictions(m map[shared.ClientID][]disasters.PendingPrediction) map[shared.ClientID][]disasters.PendingPrediction {
+	ret := make(map[shared.ClientID][]disasters.PendingPrediction, len(m))
+	for k, v := range m {
+		ret[k] = make([]disasters.PendingPrediction, len(v))
+		copy(ret[k], v)
+	}
+	return ret
+}
+
+func copyIIFOForecastingAccuracy(m map[shared.ClientID]disasters.ForecastingAccuracy) map[shared.ClientID]disasters.ForecastingAccuracy {
+	ret := make(map[shared.ClientID]disasters.ForecastingAccuracy, len(m))
+	for k, v := range m {
+		ret[k] = v.Copy()
+	}
+	return ret
+}
+
 // ClientInfo contains the client struct as well as the client's attributes
 type ClientInfo struct {
 	// Resources contains the amount of resources owned by the client.
@@ -261,6 +330,27 @@ type VotingInfo struct {
 	VotingMethod shared.ElectionVotingMethod
 	VoterList    []shared.ClientID
 	Votes        [][]shared.ClientID
+	Rounds       []ElectionRound // tallies of each stage of counting, in order
+
+	// Audit trail of the result
+	ElectionHeld    bool              // false if the role holder decided not to hold an election
+	CandidateList   []shared.ClientID // candidates standing in the election
+	CountWinner     shared.ClientID   // winner of the count. Only valid if ElectionHeld
+	AppointedWinner shared.ClientID   // island appointed to the role, which differs from CountWinner if the result was overridden
+}
+
+// ResultOverridden checks whether the role holder appointed an island other than the winner of the election
+func (v VotingInfo) ResultOverridden() bool {
+	return v.ElectionHeld && v.AppointedWinner != v.CountWinner
+}
+
+// ElectionRound is the tally of one stage of counting an election
+type ElectionRound struct {
+	Stage      string            // name of the counting stage, e.g. "PairwisePreferences"
+	Candidates []shared.ClientID // candidates counted in this stage. Scores and Pairwise follow this order
+	Scores     []float64         // score of each candidate, if the stage scores candidates
+	Pairwise   [][]float64       // tally of each pair of candidates, if the stage compares candidates pairwise
+	Eliminated []shared.ClientID // candidates eliminated at the end of this stage
 }
 
 // Copy returns a deep copy of the ClientInfo.
@@ -279,4 +369,61 @@ type RulesContext struct {
 
 	// Rules Currently In Play
 	CurrentRulesInPlay map[string]rules.RuleMatrix
+
+	// Changes made to each rule since the start of the game, oldest first
+	RuleHistory map[string][]RuleVersion
+}
+
+// RuleChangeType is the kind of change recorded in the version history of a rule
+type RuleChangeType int
+
+const (
+	// RulePulledIntoPlay : the rule was voted into play, or entered play with a rule linked to it
+	RulePulledIntoPlay RuleChangeType = iota
+	// RulePulledOutOfPlay : the rule was voted out of play, or left play with a rule linked to it
+	RulePulledOutOfPlay
+	// RuleModified : the matrices or sunset clause of the rule were changed by a vote
+	RuleModified
+	// RuleExpired : the rule was taken out of play by its own or a linked rule's sunset clause
+	RuleExpired
+	// RuleRolledBack : the rule was restored to an earlier version
+	RuleRolledBack
+)
+
+func (r RuleChangeType) String() string {
+	strs := [...]string{"PulledIntoPlay", "PulledOutOfPlay", "Modified", "Expired", "RolledBack"}
+	if r >= 0 && int(r) < len(strs) {
+		return strs[r]
+	}
+	return fmt.Sprintf("UNKNOWN RuleChangeType '%v'", int(r))
+}
+
+// GoString implements GoStringer
+func (r RuleChangeType) GoString() string {
+	return r.String()
+}
+
+// MarshalText implements TextMarshaler
+func (r RuleChangeType) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(r.String())
+}
+
+// MarshalJSON implements RawMessage
+func (r RuleChangeType) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(r.String())
+}
+
+// RuleVersion records one change to a rule
+type RuleVersion struct {
+	Turn   uint
+	Change RuleChangeType
+
+	// Vote that made the change. Only valid if ByVote
+	ByVote        bool
+	Proposer      shared.ClientID // island whose proposal was voted on
+	VotesInFavour uint
+	VotesAgainst  uint
+
+	PreviousRule rules.RuleMatrix // the rule before the change
+	Rule         rules.RuleMatrix // the rule after the change
 }
@@ -57,10 +57,14 @@ func TestGetClientGameStateCopy(t *testing.T) {
 			shared.Speaker:   4,
 		},
 		CommonPool: 20,
+		IIFOForecastingAccuracy: map[shared.ClientID]disasters.ForecastingAccuracy{
+			shared.Team1: {NumScored: 2, NumAccurate: 1, BrierScore: 0.25, LastScores: []disasters.PredictionScore{}},
+		},
 		RulesInfo: RulesContext{
 			VariableMap:        map[rules.VariableFieldName]rules.VariableValuePair{},
 			AvailableRules:     map[string]rules.RuleMatrix{},
 			CurrentRulesInPlay: map[string]rules.RuleMatrix{},
+			RuleHistory:        map[string][]RuleVersion{},
 		},
 	}
 
@@ -75,15 +79,16 @@ func TestGetClientGameStateCopy(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.String(), func(t *testing.T) {
 			expectClientGS := ClientGameState{
-				Season:             gameState.Season,
-				Turn:               gameState.Turn,
-				ClientInfo:         gameState.ClientInfos[tc],
-				ClientLifeStatuses: lifeStatuses,
-				CommonPool:         gameState.CommonPool,
-				Geography:          gameState.Environment.Geography,
-				IIGORolesBudget:    gameState.IIGORolesBudget,
-				IIGOTurnsInPower:   gameState.IIGOTurnsInPower,
-				RulesInfo:          gameState.RulesInfo,
+				Season:              gameState.Season,
+				Turn:                gameState.Turn,
+				ClientInfo:          gameState.ClientInfos[tc],
+				ClientLifeStatuses:  lifeStatuses,
+				CommonPool:          gameState.CommonPool,
+				Geography:           gameState.Environment.Geography,
+				IIGORolesBudget:     gameState.IIGORolesBudget,
+				IIGOTurnsInPower:    gameState.IIGOTurnsInPower,
+				RulesInfo:           gameState.RulesInfo,
+				ForecastingAccuracy: gameState.IIFOForecastingAccuracy,
 			}
 
 			gotClientGS := gameState.GetClientGameStateCopy(tc)
@@ -98,3 +103,60 @@ func TestGetClientGameStateCopy(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleSunsetAndRollback(t *testing.T) {
+	parsed, err := rules.ParseRules(`
+		rule parent: IslandTaxContribution >= 0 link OrLink child expires 3
+		rule child: ExpectedTaxContribution >= 0
+		rule other: TermEnded == 1 expires 5`)
+	if err != nil {
+		t.Fatalf("invalid rules: %v", err)
+	}
+	avail := map[string]rules.RuleMatrix{}
+	for _, rule := range parsed {
+		avail[rule.RuleName] = rule
+	}
+	g := GameState{
+		Turn: 2,
+		RulesInfo: RulesContext{
+			AvailableRules:     avail,
+			CurrentRulesInPlay: rules.CopyRulesMap(avail),
+		},
+	}
+
+	if expired := g.ExpireRules(); expired != nil {
+		t.Errorf("no rules should expire before their sunset clause, got %v", expired)
+	}
+	g.Turn = 3
+	if expired := g.ExpireRules(); !reflect.DeepEqual(expired, []string{"parent"}) {
+		t.Errorf("want parent to expire, got %v", expired)
+	}
+	if _, ok := g.RulesInfo.CurrentRulesInPlay["other"]; len(g.RulesInfo.CurrentRulesInPlay) != 1 || !ok {
+		t.Errorf("want parent and its linked child out of play, got %v", g.RulesInfo.CurrentRulesInPlay)
+	}
+	if g.RulesInfo.AvailableRules["parent"].ExpiryTurn != 0 {
+		t.Errorf("want the sunset clause of an expired rule removed")
+	}
+	for _, name := range []string{"parent", "child"} {
+		if history := g.RulesInfo.RuleHistory[name]; len(history) != 1 || history[0].Change != RuleExpired || history[0].Turn != 3 || history[0].ByVote {
+			t.Errorf("unexpected history of %v: %+v", name, history)
+		}
+	}
+
+	if err := g.RollbackRule("parent", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := g.RulesInfo.AvailableRules["parent"]; got.ExpiryTurn != 3 {
+		t.Errorf("want the sunset clause restored by the rollback, got %v", got)
+	}
+	if history := g.RulesInfo.RuleHistory["parent"]; len(history) != 2 || history[1].Change != RuleRolledBack {
+		t.Errorf("want the rollback recorded, got %+v", history)
+	}
+	if err := g.RollbackRule("other", 0); err == nil {
+		t.Errorf("want an error rolling back a rule with no history")
+	}
+
+	if clientHistory := g.GetClientGameStateCopy(shared.Team1).RulesInfo.RuleHistory; !reflect.DeepEqual(clientHistory, g.RulesInfo.RuleHistory) {
+		t.Errorf("want the rule history visible to clients, got %v", clientHistory)
+	}
+}
@@ -1,7 +1,12 @@
 package gamestate
 
 import (
+	"reflect"
+	"sort"
+
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -23,6 +28,109 @@ func (g *GameState) ModifyRule(rulename string, newMatrix mat.Dense, newAuxiliar
 	return rules.ModifyRuleInternal(rulename, newMatrix, newAuxiliary, g.RulesInfo.AvailableRules, g.RulesInfo.CurrentRulesInPlay)
 }
 
+func (g *GameState) SetRuleExpiry(rulename string, expiryTurn uint) error {
+	return rules.SetRuleExpiryInternal(rulename, expiryTurn, g.RulesInfo.AvailableRules, g.RulesInfo.CurrentRulesInPlay)
+}
+
+// Rule history functions
+
+// RecordRuleVote adds the changes made to the rules since previous, an earlier copy of the rules context, to the
+// history of each changed rule as the outcome of a vote on a proposal by proposer
+func (g *GameState) RecordRuleVote(previous RulesContext, proposer shared.ClientID, votesInFavour uint, votesAgainst uint) {
+	g.recordRuleChanges(previous, RulePulledOutOfPlay, RuleVersion{
+		Turn:          g.Turn,
+		ByVote:        true,
+		Proposer:      proposer,
+		VotesInFavour: votesInFavour,
+		VotesAgainst:  votesAgainst,
+	})
+}
+
+// ExpireRules takes the rules whose sunset clause has been reached out of play, together with the rules linked to them,
+// and removes the sunset clause so that they can be voted back into play. Returns the names of the expired rules.
+func (g *GameState) ExpireRules() []string {
+	expired := rules.ExpiredRules(g.Turn, g.RulesInfo.CurrentRulesInPlay)
+	if len(expired) == 0 {
+		return nil
+	}
+	previous := RulesContext{
+		AvailableRules:     rules.CopyRulesMap(g.RulesInfo.AvailableRules),
+		CurrentRulesInPlay: rules.CopyRulesMap(g.RulesInfo.CurrentRulesInPlay),
+	}
+	for _, ruleName := range expired {
+		if _, ok := g.RulesInfo.CurrentRulesInPlay[ruleName]; ok {
+			// cannot fail, the rule is in play
+			_ = g.PullRuleOutOfPlay(ruleName)
+		}
+		rule := g.RulesInfo.AvailableRules[ruleName]
+		rule.ExpiryTurn = 0
+		g.RulesInfo.AvailableRules[ruleName] = rule
+	}
+	g.recordRuleChanges(previous, RuleExpired, RuleVersion{Turn: g.Turn})
+	return expired
+}
+
+// RollbackRule restores a rule to the state it was in before the given version in its history. Whether the rule is in
+// play is unchanged.
+func (g *GameState) RollbackRule(ruleName string, version int) error {
+	history := g.RulesInfo.RuleHistory[ruleName]
+	if version < 0 || version >= len(history) {
+		return errors.Errorf("Rule '%v' has no version %v, it has %v versions", ruleName, version, len(history))
+	}
+	current, ok := g.RulesInfo.AvailableRules[ruleName]
+	if !ok {
+		return &rules.RuleError{Err: errors.Errorf("Rule '%v' does not exist in available rules cache", ruleName), ErrorType: rules.RuleNotInAvailableRulesCache}
+	}
+	restored := rules.CopyRuleMatrix(history[version].PreviousRule)
+	g.RulesInfo.AvailableRules[ruleName] = restored
+	if _, ok := g.RulesInfo.CurrentRulesInPlay[ruleName]; ok {
+		g.RulesInfo.CurrentRulesInPlay[ruleName] = restored
+	}
+	g.appendRuleVersion(ruleName, RuleVersion{
+		Turn:         g.Turn,
+		Change:       RuleRolledBack,
+		PreviousRule: current,
+		Rule:         rules.CopyRuleMatrix(restored),
+	})
+	return nil
+}
+
+// recordRuleChanges appends version to the history of every rule that entered or left play or was modified since
+// previous. Rules leaving play are recorded with the change leftPlay.
+func (g *GameState) recordRuleChanges(previous RulesContext, leftPlay RuleChangeType, version RuleVersion) {
+	names := []string{}
+	for name := range g.RulesInfo.AvailableRules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rule := g.RulesInfo.AvailableRules[name]
+		previousRule := previous.AvailableRules[name]
+		_, wasInPlay := previous.CurrentRulesInPlay[name]
+		_, inPlay := g.RulesInfo.CurrentRulesInPlay[name]
+		switch {
+		case inPlay && !wasInPlay:
+			version.Change = RulePulledIntoPlay
+		case !inPlay && wasInPlay:
+			version.Change = leftPlay
+		case !reflect.DeepEqual(rule, previousRule):
+			version.Change = RuleModified
+		default:
+			continue
+		}
+		version.PreviousRule = rules.CopyRuleMatrix(previousRule)
+		version.Rule = rules.CopyRuleMatrix(rule)
+		g.appendRuleVersion(name, version)
+	}
+}
+
+func (g *GameState) appendRuleVersion(ruleName string, version RuleVersion) {
+	if g.RulesInfo.RuleHistory == nil {
+		g.RulesInfo.RuleHistory = map[string][]RuleVersion{}
+	}
+	g.RulesInfo.RuleHistory[ruleName] = append(g.RulesInfo.RuleHistory[ruleName], version)
+}
+
 // Variables cache manipulation functions
 
 func (g *GameState) RegisterNewVariable(pair rules.VariableValuePair) error {
@@ -184,25 +184,87 @@ func basicRealValuedRuleEvaluator(rule RuleMatrix, variableCache map[VariableFie
 
 }
 
-// basicLinkedRuleEvaluator evaluates linked rules using the above two evaluators
-func basicLinkedRuleEvaluator(rule RuleMatrix, childRule RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) (bool, error) {
+// basicLinkedRuleEvaluator evaluates linked rules using the above two evaluators. The rule's own matrix (the parent)
+// and its children, which are looked up in rulesCache and may be linked rules themselves, are combined according to
+// the link type. Children are only evaluated as far as needed to decide the result.
+func basicLinkedRuleEvaluator(rule RuleMatrix, rulesCache map[string]RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) (bool, error) {
+	return evaluateLinkedRule(rule, rulesCache, variableCache, map[string]bool{})
+}
+
+func evaluateLinkedRule(rule RuleMatrix, rulesCache map[string]RuleMatrix, variableCache map[VariableFieldName]VariableValuePair, visiting map[string]bool) (bool, error) {
 	link := rule.Link
 	if !link.Linked {
+		if checkForCode4(rule.AuxiliaryVector) {
+			pass, _, err := basicRealValuedRuleEvaluator(rule, variableCache)
+			return pass, err
+		}
 		return basicBooleanRuleEvaluator(rule, variableCache)
 	}
-	if link.LinkType == ParentFailAutoRulePass {
-		parentPass, parentErr := basicBooleanRuleEvaluator(rule, variableCache)
-		if parentErr != nil {
-			return false, errors.Errorf("Parent Rule errored out with : %v", parentErr)
+	if visiting[rule.RuleName] {
+		return false, &RuleError{
+			ErrorType: RuleLinkCycle,
+			Err:       errors.Errorf("Rule '%v' is linked to itself", rule.RuleName),
 		}
+	}
+	visiting[rule.RuleName] = true
+	defer delete(visiting, rule.RuleName)
+
+	children := []RuleMatrix{}
+	for _, childName := range link.Children() {
+		childRule, isInCache := rulesCache[childName]
+		if !isInCache {
+			return false, &RuleError{
+				ErrorType: ChildRuleNotFound,
+				Err:       errors.Errorf("Child rule '%v' of rule '%v' was not found in cache", childName, rule.RuleName),
+			}
+		}
+		children = append(children, childRule)
+	}
+	parentRule := rule
+	parentRule.Link = RuleLink{Linked: false}
+	parentPass, err := evaluateLinkedRule(parentRule, rulesCache, variableCache, visiting)
+	if err != nil {
+		return false, err
+	}
+	// countPasses evaluates the children in order until stopAt of them evaluate to stopOn
+	countPasses := func(stopOn bool, stopAt int) (int, error) {
+		passes, stops := 0, 0
+		for _, child := range children {
+			childPass, err := evaluateLinkedRule(child, rulesCache, variableCache, visiting)
+			if err != nil {
+				return 0, err
+			}
+			if childPass {
+				passes++
+			}
+			if childPass == stopOn {
+				if stops++; stops == stopAt {
+					break
+				}
+			}
+		}
+		return passes, nil
+	}
+
+	switch link.LinkType {
+	case ParentFailAutoRulePass, AndLink:
 		if !parentPass {
+			return link.LinkType == ParentFailAutoRulePass, nil
+		}
+		passes, err := countPasses(false, 1)
+		return passes == len(children), err
+	case OrLink:
+		if parentPass {
 			return true, nil
 		}
-		childPass, childErr := basicBooleanRuleEvaluator(childRule, variableCache)
-		if childErr != nil {
-			return false, errors.Errorf("Parent Rule errored out with : %v", childErr)
+		passes, err := countPasses(true, 1)
+		return passes > 0, err
+	case XorLink:
+		passes, err := countPasses(true, 2)
+		if parentPass {
+			passes++
 		}
-		return childPass, nil
+		return passes == 1, err
 	}
 	return false, errors.Errorf("Unrecognised rule linking %v", link.LinkType)
 }
@@ -219,25 +281,13 @@ func EvaluateRuleFromCaches(ruleName string, rulesCache map[string]RuleMatrix, v
 	if rule, ok := rulesCache[ruleName]; ok {
 		if checkAllVariablesAvailable(rule.RequiredVariables, variableCache) {
 			auxVect := rule.AuxiliaryVector
-			linked := rule.Link.Linked
-			if linked {
-				if childRule, isInCache := rulesCache[rule.Link.LinkedRule]; isInCache {
-					eval, err := basicLinkedRuleEvaluator(rule, childRule, variableCache)
-					return RuleEvaluationReturn{
-						RulePasses:    eval,
-						IsRealOutput:  false,
-						RealOutputVal: 0,
-						EvalError:     err,
-					}
-				}
+			if rule.Link.Linked {
+				eval, err := basicLinkedRuleEvaluator(rule, rulesCache, variableCache)
 				return RuleEvaluationReturn{
-					RulePasses:    false,
+					RulePasses:    eval,
 					IsRealOutput:  false,
 					RealOutputVal: 0,
-					EvalError: &RuleError{
-						ErrorType: ChildRuleNotFound,
-						Err:       errors.Errorf("Child rule was not found in cache"),
-					},
+					EvalError:     err,
 				}
 			}
 			isRealValued := checkForCode4(auxVect)
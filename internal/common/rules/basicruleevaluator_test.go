@@ -46,6 +46,58 @@ func TestBasicLinkedRuleEvaluator(t *testing.T) {
 	}
 }
 
+func TestLinkTypes(t *testing.T) {
+	children := parseRuleSet(t, `
+		rule pass: TermEnded == 1
+		rule fail: TermEnded == 0
+		rule nested: TermEnded == 0 link OrLink pass`)
+	cases := []struct {
+		name     string
+		rule     string
+		expected bool
+	}{
+		{name: "implies with failing parent", rule: "rule r: TermEnded == 0 link ParentFailAutoRulePass fail", expected: true},
+		{name: "implies with passing parent", rule: "rule r: TermEnded == 1 link ParentFailAutoRulePass pass, fail", expected: false},
+		{name: "and", rule: "rule r: TermEnded == 1 link AndLink pass, nested", expected: true},
+		{name: "and with a failing child", rule: "rule r: TermEnded == 1 link AndLink pass, fail", expected: false},
+		{name: "or", rule: "rule r: TermEnded == 0 link OrLink fail, pass", expected: true},
+		{name: "or with no passes", rule: "rule r: TermEnded == 0 link OrLink fail", expected: false},
+		{name: "xor with one pass", rule: "rule r: TermEnded == 0 link XorLink fail, nested", expected: true},
+		{name: "xor with two passes", rule: "rule r: TermEnded == 1 link XorLink fail, pass", expected: false},
+	}
+	variables := map[VariableFieldName]VariableValuePair{TermEnded: MakeVariableValuePair(TermEnded, []float64{1})}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := ParseRule(tc.rule)
+			if err != nil {
+				t.Fatalf("invalid rule: %v", err)
+			}
+			got, err := basicLinkedRuleEvaluator(rule, children, variables)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestLinkedRuleEvaluatorErrors(t *testing.T) {
+	rules := parseRuleSet(t, `
+		rule a: TermEnded == 1 link AndLink b
+		rule b: TermEnded == 1 link AndLink a
+		rule orphan: TermEnded == 1 link AndLink missing`)
+	variables := map[VariableFieldName]VariableValuePair{TermEnded: MakeVariableValuePair(TermEnded, []float64{1})}
+	cases := map[string]RuleErrorType{"a": RuleLinkCycle, "orphan": ChildRuleNotFound}
+	for name, errorType := range cases {
+		ret := EvaluateRuleFromCaches(name, rules, variables)
+		if ruleErr, ok := ret.EvalError.(*RuleError); !ok || ruleErr.Type() != errorType || ret.RulePasses {
+			t.Errorf("%v: expected failure with %v, got %v", name, errorType, ret)
+		}
+	}
+}
+
 func generateMockVarCache() map[VariableFieldName]VariableValuePair {
 	return map[VariableFieldName]VariableValuePair{
 		NumberOfIslandsContributingToCommonPool: {
@@ -10,10 +10,10 @@ import (
 func ComplianceCheck(rule RuleMatrix, variables map[VariableFieldName]VariableValuePair, inPlayRules map[string]RuleMatrix) (compliant bool, ruleError error) {
 	if checkAllVariablesAvailable(rule.RequiredVariables, variables) {
 		ruleCache := map[string]RuleMatrix{}
-		ruleCache[rule.RuleName] = rule
-		if rule.Link.Linked {
-			ruleCache[rule.Link.LinkedRule] = inPlayRules[rule.Link.LinkedRule]
+		for name, inPlayRule := range inPlayRules {
+			ruleCache[name] = inPlayRule
 		}
+		ruleCache[rule.RuleName] = rule
 		evalResult := EvaluateRuleFromCaches(rule.RuleName, ruleCache, variables)
 		return evalResult.RulePasses, evalResult.EvalError
 	}
@@ -24,7 +24,8 @@ func ComplianceCheck(rule RuleMatrix, variables map[VariableFieldName]VariableVa
 }
 
 // ComplianceRecommendation *attempts* to calculate a set of variables that comply with a rule if the
-// input variables don't satisfy it already
+// input variables don't satisfy it already. Rows are corrected one at a time, so a correction may break an earlier
+// row; MinimalComplianceRecommendation satisfies all rows of one or several rules with the smallest change.
 func ComplianceRecommendation(rule RuleMatrix, variables map[VariableFieldName]VariableValuePair) (map[VariableFieldName]VariableValuePair, bool) {
 	slicedValues, fixed, success := fetchRequiredVariables(rule.RequiredVariables, variables)
 	if success {
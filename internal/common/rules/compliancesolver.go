@@ -0,0 +1,418 @@
+package rules
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// maxComplianceAlternatives limits the number of ways of satisfying a set of rules that MinimalComplianceRecommendation
+// tries, each of which takes solving a linear program
+const maxComplianceAlternatives = 256
+
+// complianceMargin is the margin by which recommended values satisfy strict inequalities
+const complianceMargin = 1e-6
+
+// complianceRounding is the precision recommended values are rounded to if they do not satisfy the rules exactly
+const complianceRounding = 1e9
+
+// MinimalComplianceRecommendation finds the smallest change to the adjustable variables under which all the rules
+// named in ruleNames pass, by linear programming. The size of a change is the sum of the absolute changes of the
+// variable values. The rules, and the rules they are linked to, are looked up in rulesCache and the current values of
+// their variables in variables. adjustable says which variables may change and defaults to IsChangeable() if nil.
+// Strict inequalities are satisfied with a margin of complianceMargin. Returns a copy of variables holding the
+// recommended values, or a RuleError of type ComplianceInfeasible if no values of the adjustable variables comply.
+func MinimalComplianceRecommendation(ruleNames []string, rulesCache map[string]RuleMatrix, variables map[VariableFieldName]VariableValuePair, adjustable map[VariableFieldName]bool) (map[VariableFieldName]VariableValuePair, error) {
+	if adjustable == nil {
+		adjustable = IsChangeable()
+	}
+	problem := complianceProblem{rulesCache: rulesCache, variables: variables, columns: map[variableColumn]int{}}
+	alternatives := []constraintConjunction{{}}
+	for _, name := range ruleNames {
+		rule, ok := rulesCache[name]
+		if !ok {
+			return nil, &RuleError{
+				ErrorType: RuleNotInAvailableRulesCache,
+				Err:       errors.Errorf("Rule '%v' was not found in cache", name),
+			}
+		}
+		passing, _, err := problem.alternatives(rule, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		if alternatives, err = conjoin(alternatives, passing); err != nil {
+			return nil, err
+		}
+	}
+
+	values := make([]float64, len(problem.columns))
+	isAdjustable := make([]bool, len(problem.columns))
+	for column, index := range problem.columns {
+		values[index] = variables[column.variable].Values[column.index]
+		isAdjustable[index] = adjustable[column.variable]
+	}
+	var best []float64
+	bestCost := 0.0
+	for _, alternative := range alternatives {
+		change, cost, feasible, err := minimalChange(alternative, values, isAdjustable)
+		if err != nil {
+			return nil, err
+		}
+		if feasible && (best == nil || cost < bestCost) {
+			best, bestCost = change, cost
+		}
+	}
+	if best == nil {
+		return nil, &RuleError{
+			ErrorType: ComplianceInfeasible,
+			Err:       errors.Errorf("No values of the adjustable variables comply with rules %v", ruleNames),
+		}
+	}
+
+	for _, round := range []bool{false, true} {
+		recommended := CopyVariableMap(variables)
+		copied := map[VariableFieldName]bool{}
+		for column, index := range problem.columns {
+			if best[index] == 0 {
+				continue
+			}
+			pair := recommended[column.variable]
+			if !copied[column.variable] {
+				pair.Values = append([]float64{}, pair.Values...)
+				copied[column.variable] = true
+			}
+			pair.Values[column.index] = values[index] + best[index]
+			if round {
+				pair.Values[column.index] = math.Round(pair.Values[column.index]*complianceRounding) / complianceRounding
+			}
+			recommended[column.variable] = pair
+		}
+		compliant, err := allRulesPass(ruleNames, rulesCache, recommended)
+		if err != nil || compliant {
+			return recommended, err
+		}
+	}
+	return nil, errors.Errorf("The values recommended for rules %v do not satisfy them exactly", ruleNames)
+}
+
+func allRulesPass(ruleNames []string, rulesCache map[string]RuleMatrix, variables map[VariableFieldName]VariableValuePair) (bool, error) {
+	for _, name := range ruleNames {
+		passes, err := evaluateLinkedRule(rulesCache[name], rulesCache, variables, map[string]bool{})
+		if err != nil || !passes {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// constraintConjunction is a set of constraints with auxiliary codes 0 to 2 that hold together
+type constraintConjunction []linearConstraint
+
+// conjoin returns the conjunctions, one of which holds exactly when one of a and one of b hold
+func conjoin(a []constraintConjunction, b []constraintConjunction) ([]constraintConjunction, error) {
+	if len(a)*len(b) > maxComplianceAlternatives {
+		return nil, errors.Errorf("Rules can be satisfied in more than %v ways", maxComplianceAlternatives)
+	}
+	product := []constraintConjunction{}
+	for _, x := range a {
+		for _, y := range b {
+			product = append(product, append(append(constraintConjunction{}, x...), y...))
+		}
+	}
+	return product, nil
+}
+
+// complianceProblem numbers the variable values (columns) the rules being solved for depend on
+type complianceProblem struct {
+	rulesCache map[string]RuleMatrix
+	variables  map[VariableFieldName]VariableValuePair
+	columns    map[variableColumn]int
+}
+
+// constraints returns the rows of a rule's own matrix with auxiliary codes 0 to 3 as constraints over the columns
+func (p *complianceProblem) constraints(rule RuleMatrix) ([]linearConstraint, error) {
+	if !checkAllVariablesAvailable(rule.RequiredVariables, p.variables) {
+		return nil, &RuleError{
+			ErrorType: VariableCacheDidNotHaveAllRequiredVariables,
+			Err:       errors.Errorf("Provided variable cache did not contain all variables required by rule '%v'", rule.RuleName),
+		}
+	}
+	widths, err := ruleColumnWidths(rule, p.variables)
+	if err != nil {
+		return nil, err
+	}
+	ruleColumns := []int{}
+	for i, v := range rule.RequiredVariables {
+		for index := 0; index < widths[i]; index++ {
+			column := variableColumn{variable: v, index: index}
+			if _, ok := p.columns[column]; !ok {
+				p.columns[column] = len(p.columns)
+			}
+			ruleColumns = append(ruleColumns, p.columns[column])
+		}
+	}
+	nRows, nCols := rule.ApplicableMatrix.Dims()
+	if rule.AuxiliaryVector.Len() != nRows {
+		return nil, &RuleError{
+			Err:       errors.Errorf("Rule '%v' has %v rows but %v auxiliary codes", rule.RuleName, nRows, rule.AuxiliaryVector.Len()),
+			ErrorType: AuxVectorDimensionDontMatchRuleMatrix,
+		}
+	}
+	constraints := []linearConstraint{}
+	for i := 0; i < nRows; i++ {
+		aux := rule.AuxiliaryVector.AtVec(i)
+		if aux == 4 {
+			continue
+		}
+		if aux != 0 && aux != 1 && aux != 2 && aux != 3 {
+			return nil, &RuleError{
+				Err:       errors.Errorf("Rule '%v' row %v: auxiliary code %v outside of 0-4", rule.RuleName, i, aux),
+				ErrorType: AuxVectorCodeOutOfRange,
+			}
+		}
+		// columns added by later rules are left out, and taken to have coefficients of 0
+		constraint := linearConstraint{coefficients: make([]float64, len(p.columns)), constant: rule.ApplicableMatrix.At(i, nCols-1), aux: aux}
+		for j, column := range ruleColumns {
+			constraint.coefficients[column] += rule.ApplicableMatrix.At(i, j)
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+// alternatives returns the conjunctions of constraints one of which holds exactly when the rule passes, and those one
+// of which holds exactly when it fails, following the semantics of the linked rule evaluator
+func (p *complianceProblem) alternatives(rule RuleMatrix, visiting map[string]bool) (passing []constraintConjunction, failing []constraintConjunction, err error) {
+	constraints, err := p.constraints(rule)
+	if err != nil {
+		return nil, nil, err
+	}
+	passing = []constraintConjunction{{}}
+	failing = []constraintConjunction{}
+	for _, constraint := range constraints {
+		holds := []constraintConjunction{{constraint}}
+		if constraint.aux == 3 {
+			above := linearConstraint{coefficients: constraint.coefficients, constant: constraint.constant, aux: 1}
+			holds = []constraintConjunction{{above}, {constraint.negated(1)}}
+		}
+		if passing, err = conjoin(passing, holds); err != nil {
+			return nil, nil, err
+		}
+		for _, violation := range constraint.violations() {
+			failing = append(failing, constraintConjunction{violation})
+		}
+	}
+	if !rule.Link.Linked {
+		return passing, failing, nil
+	}
+
+	if visiting[rule.RuleName] {
+		return nil, nil, &RuleError{
+			ErrorType: RuleLinkCycle,
+			Err:       errors.Errorf("Rule '%v' is linked to itself", rule.RuleName),
+		}
+	}
+	visiting[rule.RuleName] = true
+	defer delete(visiting, rule.RuleName)
+	// operands are the rule's own matrix (the parent), followed by its children
+	operandsPassing := [][]constraintConjunction{passing}
+	operandsFailing := [][]constraintConjunction{failing}
+	for _, childName := range rule.Link.Children() {
+		child, ok := p.rulesCache[childName]
+		if !ok {
+			return nil, nil, &RuleError{
+				ErrorType: ChildRuleNotFound,
+				Err:       errors.Errorf("Child rule '%v' of rule '%v' was not found in cache", childName, rule.RuleName),
+			}
+		}
+		childPassing, childFailing, err := p.alternatives(child, visiting)
+		if err != nil {
+			return nil, nil, err
+		}
+		operandsPassing = append(operandsPassing, childPassing)
+		operandsFailing = append(operandsFailing, childFailing)
+	}
+	parentPassing, parentFailing := operandsPassing[0], operandsFailing[0]
+	childrenPassing, childrenFailing := operandsPassing[1:], operandsFailing[1:]
+
+	switch rule.Link.LinkType {
+	case ParentFailAutoRulePass:
+		var allChildrenPassing []constraintConjunction
+		allChildrenPassing, err = allOf(childrenPassing)
+		passing = anyOf([][]constraintConjunction{parentFailing, allChildrenPassing})
+		if err == nil {
+			failing, err = conjoin(parentPassing, anyOf(childrenFailing))
+		}
+	case AndLink:
+		passing, err = allOf(operandsPassing)
+		failing = anyOf(operandsFailing)
+	case OrLink:
+		passing = anyOf(operandsPassing)
+		failing, err = allOf(operandsFailing)
+	case XorLink:
+		passing, failing, err = exactlyOneOf(operandsPassing, operandsFailing)
+	default:
+		err = errors.Errorf("Unrecognised rule linking %v", rule.Link.LinkType)
+	}
+	if len(passing) > maxComplianceAlternatives || len(failing) > maxComplianceAlternatives {
+		err = errors.Errorf("Rules can be satisfied in more than %v ways", maxComplianceAlternatives)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return passing, failing, nil
+}
+
+func allOf(operands [][]constraintConjunction) ([]constraintConjunction, error) {
+	result := []constraintConjunction{{}}
+	for _, operand := range operands {
+		var err error
+		if result, err = conjoin(result, operand); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func anyOf(operands [][]constraintConjunction) []constraintConjunction {
+	result := []constraintConjunction{}
+	for _, operand := range operands {
+		result = append(result, operand...)
+	}
+	return result
+}
+
+// exactlyOneOf returns the conjunctions for exactly one operand passing, and for none or several of them passing
+func exactlyOneOf(passing [][]constraintConjunction, failing [][]constraintConjunction) ([]constraintConjunction, []constraintConjunction, error) {
+	exactlyOne := []constraintConjunction{}
+	for i := range passing {
+		operands := [][]constraintConjunction{passing[i]}
+		for j := range failing {
+			if j != i {
+				operands = append(operands, failing[j])
+			}
+		}
+		alternatives, err := allOf(operands)
+		if err != nil {
+			return nil, nil, err
+		}
+		exactlyOne = append(exactlyOne, alternatives...)
+	}
+	notOne, err := allOf(failing)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range passing {
+		for j := i + 1; j < len(passing); j++ {
+			both, err := conjoin(passing[i], passing[j])
+			if err != nil {
+				return nil, nil, err
+			}
+			notOne = append(notOne, both...)
+		}
+	}
+	return exactlyOne, notOne, nil
+}
+
+// minimalChange finds the smallest change to the adjustable columns under which all constraints hold by solving the
+// linear program
+//
+//	minimise Σ (p_j + n_j) subject to
+//	    a·(x + p - n) + c == 0       for == constraints
+//	    a·(x + p - n) + c >= margin  for > constraints
+//	    a·(x + p - n) + c >= 0       for >= constraints
+//	    p, n >= 0
+//
+// where x holds the current values and p - n is the change. Returns whether the program is feasible.
+func minimalChange(constraints []linearConstraint, values []float64, adjustable []bool) ([]float64, float64, bool, error) {
+	change := make([]float64, len(values))
+	coefficient := func(constraint linearConstraint, column int) float64 {
+		if column < len(constraint.coefficients) {
+			return constraint.coefficients[column]
+		}
+		return 0
+	}
+	// only columns used by some constraint are solved for, as lp.Simplex rejects columns of zeros
+	used := []int{}
+	for column := range values {
+		for _, constraint := range constraints {
+			if adjustable[column] && coefficient(constraint, column) != 0 {
+				used = append(used, column)
+				break
+			}
+		}
+	}
+
+	g := [][]float64{}
+	h := []float64{}
+	addRow := func(constraint linearConstraint, sign float64, bound float64) {
+		row := make([]float64, len(used))
+		for j, column := range used {
+			row[j] = sign * coefficient(constraint, column)
+		}
+		g = append(g, row)
+		h = append(h, bound)
+	}
+	for _, constraint := range constraints {
+		current := constraint.constant
+		fixed := true
+		for column, value := range values {
+			current += coefficient(constraint, column) * value
+			if adjustable[column] && coefficient(constraint, column) != 0 {
+				fixed = false
+			}
+		}
+		if fixed {
+			if !satisfy(current, constraint.aux) {
+				return nil, 0, false, nil
+			}
+			continue
+		}
+		switch constraint.aux {
+		case 0:
+			addRow(constraint, 1, -current)
+			addRow(constraint, -1, current)
+		case 1:
+			// a row that already holds by less than the margin is allowed to stay as it is
+			margin := complianceMargin
+			if current > 0 && current < margin {
+				margin = current
+			}
+			addRow(constraint, -1, current-margin)
+		case 2:
+			addRow(constraint, -1, current)
+		}
+	}
+	if len(g) == 0 {
+		return change, 0, true, nil
+	}
+
+	// standard form over [p, n, slack]
+	nUsed := len(used)
+	a := mat.NewDense(len(g), 2*nUsed+len(g), nil)
+	for i, row := range g {
+		for j, value := range row {
+			a.Set(i, j, value)
+			a.Set(i, nUsed+j, -value)
+		}
+		a.Set(i, 2*nUsed+i, 1)
+	}
+	objective := make([]float64, 2*nUsed+len(g))
+	for j := 0; j < 2*nUsed; j++ {
+		objective[j] = 1
+	}
+	optimum, x, err := lp.Simplex(objective, a, h, 1e-10, nil)
+	switch err {
+	case nil:
+		for j, column := range used {
+			change[column] = x[j] - x[nUsed+j]
+		}
+		return change, optimum, true, nil
+	case lp.ErrInfeasible:
+		return nil, 0, false, nil
+	default:
+		return nil, 0, false, errors.Errorf("Could not solve the constraints of the rules: %v", err)
+	}
+}
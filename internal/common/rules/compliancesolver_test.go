@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMinimalComplianceRecommendation(t *testing.T) {
+	rulesCache := parseRuleSet(t, `
+		rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0
+		rule cap: IslandTaxContribution + IslandAllocation <= 10
+		rule above: IslandTaxContribution > 5
+		rule not_four: IslandAllocation != 4
+		rule expected: ExpectedTaxContribution == 3
+		rule low: IslandTaxContribution <= 3
+		rule allocations over IslandAllocation[2]: IslandAllocation[0] - IslandAllocation[1] == 0; IslandAllocation[1] >= 5
+		rule implied: IslandAllocation > 0 link ParentFailAutoRulePass tax
+		rule either: IslandAllocation >= 20 link OrLink low
+		rule one_of: IslandAllocation >= 9 link XorLink tax`)
+	variables := func(tax float64, expected float64, allocation ...float64) map[VariableFieldName]VariableValuePair {
+		return map[VariableFieldName]VariableValuePair{
+			IslandTaxContribution:   MakeVariableValuePair(IslandTaxContribution, []float64{tax}),
+			ExpectedTaxContribution: MakeVariableValuePair(ExpectedTaxContribution, []float64{expected}),
+			IslandAllocation:        MakeVariableValuePair(IslandAllocation, allocation),
+		}
+	}
+	cases := []struct {
+		name       string
+		rules      []string
+		variables  map[VariableFieldName]VariableValuePair
+		adjustable map[VariableFieldName]bool
+		want       map[VariableFieldName]VariableValuePair
+		errorType  RuleErrorType
+	}{
+		{name: "compliant values are kept", rules: []string{"tax", "cap"}, variables: variables(6, 5, 1), want: variables(6, 5, 1)},
+		{name: "single rule", rules: []string{"tax"}, variables: variables(3, 5, 1), want: variables(5, 5, 1)},
+		{name: "several rules at once", rules: []string{"tax", "cap"}, variables: variables(3, 5, 8), want: variables(5, 5, 5)},
+		{
+			name:       "restricted adjustable variables",
+			rules:      []string{"tax", "cap"},
+			variables:  variables(6, 5, 8),
+			adjustable: map[VariableFieldName]bool{IslandAllocation: true},
+			want:       variables(6, 5, 4),
+		},
+		{name: "strict inequality", rules: []string{"above"}, variables: variables(3, 5, 1), want: variables(5+complianceMargin, 5, 1)},
+		{name: "not equal", rules: []string{"not_four"}, variables: variables(3, 5, 4), want: variables(3, 5, 4+complianceMargin)},
+		{name: "multi-valued variable", rules: []string{"allocations"}, variables: variables(3, 5, 4, 1), want: variables(3, 5, 5, 5)},
+		{name: "implication", rules: []string{"implied"}, variables: variables(3, 5, 8), want: variables(5, 5, 8)},
+		{name: "implication with a cheaper failing parent", rules: []string{"implied"}, variables: variables(3, 9, 1), want: variables(3, 9, 0)},
+		{name: "disjunction", rules: []string{"either"}, variables: variables(6, 5, 18), want: variables(6, 5, 20)},
+		{name: "exclusive disjunction", rules: []string{"one_of"}, variables: variables(6, 5, 10), want: variables(5-complianceMargin, 5, 10)},
+		{name: "fixed variable", rules: []string{"expected"}, variables: variables(3, 5, 1), errorType: ComplianceInfeasible},
+		{name: "conflicting rules", rules: []string{"tax", "low"}, variables: variables(3, 5, 1), errorType: ComplianceInfeasible},
+		{name: "unknown rule", rules: []string{"missing"}, variables: variables(3, 5, 1), errorType: RuleNotInAvailableRulesCache},
+		{
+			name:      "missing variable",
+			rules:     []string{"tax"},
+			variables: map[VariableFieldName]VariableValuePair{IslandTaxContribution: MakeVariableValuePair(IslandTaxContribution, []float64{1})},
+			errorType: VariableCacheDidNotHaveAllRequiredVariables,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := CopyVariableMap(tc.variables)
+			got, err := MinimalComplianceRecommendation(tc.rules, rulesCache, tc.variables, tc.adjustable)
+			if tc.want == nil {
+				if ruleErr, ok := err.(*RuleError); !ok || ruleErr.Type() != tc.errorType {
+					t.Fatalf("expected a %v error, got %v", tc.errorType, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+			if !reflect.DeepEqual(tc.variables, original) {
+				t.Errorf("input variables were modified: %v", tc.variables)
+			}
+			for _, rule := range tc.rules {
+				if compliant, err := ComplianceCheck(rulesCache[rule], got, rulesCache); !compliant || err != nil {
+					t.Errorf("recommended values do not comply with rule '%v': %v", rule, err)
+				}
+			}
+		})
+	}
+}
@@ -47,14 +47,15 @@ func registerDemoRule(AvailableRules map[string]RuleMatrix) map[string]RuleMatri
 
 // RawRuleSpecification allows a user to use the CompileRuleCase function to build a rule matrix
 type RawRuleSpecification struct {
-	Name       string
-	ReqVar     []VariableFieldName
-	Values     []float64
-	Aux        []float64
-	Mutable    bool
-	Linked     bool
-	LinkType   LinkTypeOption
-	LinkedRule string
+	Name                  string
+	ReqVar                []VariableFieldName
+	Values                []float64
+	Aux                   []float64
+	Mutable               bool
+	Linked                bool
+	LinkType              LinkTypeOption
+	LinkedRule            string
+	AdditionalLinkedRules []string
 }
 
 func registerRulesByMass(availableRules map[string]RuleMatrix) map[string]RuleMatrix {
@@ -452,6 +453,26 @@ func registerRulesByMass(availableRules map[string]RuleMatrix) map[string]RuleMa
 			Mutable: true,
 			Linked:  false,
 		},
+		{
+			Name: "rule_vote_quorum",
+			ReqVar: []VariableFieldName{
+				RuleVoteTurnout,
+			},
+			Values:  []float64{1, 0},
+			Aux:     []float64{2},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			Name: "rule_vote_supermajority",
+			ReqVar: []VariableFieldName{
+				RuleVoteApproval,
+			},
+			Values:  []float64{1, -2.0 / 3.0},
+			Aux:     []float64{2},
+			Mutable: true,
+			Linked:  false,
+		},
 		{
 			Name: "tax_decision",
 			ReqVar: []VariableFieldName{
@@ -493,9 +514,10 @@ func registerRulesByMass(availableRules map[string]RuleMatrix) map[string]RuleMa
 			}
 		} else {
 			ruleLink = RuleLink{
-				Linked:     rs.Linked,
-				LinkType:   rs.LinkType,
-				LinkedRule: rs.LinkedRule,
+				Linked:                rs.Linked,
+				LinkType:              rs.LinkType,
+				LinkedRule:            rs.LinkedRule,
+				AdditionalLinkedRules: rs.AdditionalLinkedRules,
 			}
 		}
 		_, ruleError := RegisterNewRuleInternal(rs.Name, rs.ReqVar, *CoreMatrix, *AuxiliaryVector, availableRules, rs.Mutable, ruleLink)
@@ -522,9 +544,10 @@ func CompileRuleCase(spec RawRuleSpecification) (RuleMatrix, bool) {
 		}
 	} else {
 		ruleLink = RuleLink{
-			Linked:     spec.Linked,
-			LinkType:   spec.LinkType,
-			LinkedRule: spec.LinkedRule,
+			Linked:                spec.Linked,
+			LinkType:              spec.LinkType,
+			LinkedRule:            spec.LinkedRule,
+			AdditionalLinkedRules: spec.AdditionalLinkedRules,
 		}
 	}
 	finalRuleMatrix := RuleMatrix{
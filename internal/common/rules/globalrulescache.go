@@ -1,6 +1,9 @@
 package rules
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/pkg/errors"
 	"gonum.org/v1/gonum/mat"
 )
@@ -22,11 +25,12 @@ func PullRuleIntoPlayInternal(rulename string, allRules map[string]RuleMatrix, p
 		if _, ok := playRules[rulename]; ok {
 			return &RuleError{Err: errors.Errorf("Rule '%v' is already in play", rulename), ErrorType: RuleIsAlreadyInPlay}
 		}
-		linkRule, linked := checkLinking(rulename, allRules)
-		if linked {
-			playRules[linkRule] = allRules[linkRule]
+		if err := CheckRuleLinks(rulename, allRules); err != nil {
+			return err
+		}
+		for _, linkedRule := range linkGroup(rulename, allRules) {
+			playRules[linkedRule] = allRules[linkedRule]
 		}
-		playRules[rulename] = allRules[rulename]
 		return nil
 	}
 	return &RuleError{Err: errors.Errorf("Rule '%v' does not exist in available rules", rulename), ErrorType: RuleNotInAvailableRulesCache}
@@ -36,11 +40,9 @@ func PullRuleIntoPlayInternal(rulename string, allRules map[string]RuleMatrix, p
 func PullRuleOutOfPlayInternal(rulename string, allRules map[string]RuleMatrix, playRules map[string]RuleMatrix) error {
 	if _, ok := allRules[rulename]; ok {
 		if _, ok := playRules[rulename]; ok {
-			linkRule, linked := checkLinking(rulename, allRules)
-			if linked {
-				delete(playRules, linkRule)
+			for _, linkedRule := range linkGroup(rulename, allRules) {
+				delete(playRules, linkedRule)
 			}
-			delete(playRules, rulename)
 			return nil
 		}
 		return &RuleError{Err: errors.Errorf("Rule '%v' is not in play", rulename), ErrorType: RuleIsNotInPlay}
@@ -70,6 +72,8 @@ func ModifyRuleInternal(rulename string, newMatrix mat.Dense, newAuxiliary mat.V
 			ApplicableMatrix:  newMatrix,
 			AuxiliaryVector:   newAuxiliary,
 			Mutable:           true,
+			Link:              oldRuleMatrix.Link,
+			ExpiryTurn:        oldRuleMatrix.ExpiryTurn,
 		}
 		rulesCache[rulename] = newRuleMatrix
 		if _, ok := inPlayCache[rulename]; ok {
@@ -80,31 +84,118 @@ func ModifyRuleInternal(rulename string, newMatrix mat.Dense, newAuxiliary mat.V
 	return &RuleError{Err: errors.Errorf("Rule '%v' does not exist in available rules cache", rulename), ErrorType: RuleNotInAvailableRulesCache}
 }
 
-func checkLinking(ruleName string, availableRules map[string]RuleMatrix) (string, bool) {
-	if rule, ok := availableRules[ruleName]; ok {
-		if rule.Link.Linked {
-			return rule.Link.LinkedRule, true
-		}
-		for _, ruleVal := range availableRules {
-			if ruleVal.Link.Linked {
-				if ruleVal.Link.LinkedRule == ruleName {
-					return ruleVal.RuleName, true
+// SetRuleExpiryInternal sets the turn at which a rule is taken out of play, or removes its sunset clause if expiryTurn
+// is 0, in any pair of caches
+func SetRuleExpiryInternal(rulename string, expiryTurn uint, rulesCache map[string]RuleMatrix, inPlayCache map[string]RuleMatrix) error {
+	rule, ok := rulesCache[rulename]
+	if !ok {
+		return &RuleError{Err: errors.Errorf("Rule '%v' does not exist in available rules cache", rulename), ErrorType: RuleNotInAvailableRulesCache}
+	}
+	if !rule.Mutable {
+		return &RuleError{Err: errors.Errorf("Rule '%v' is not mutable", rulename), ErrorType: RuleRequestedForModificationWasImmutable}
+	}
+	rule.ExpiryTurn = expiryTurn
+	rulesCache[rulename] = rule
+	if _, ok := inPlayCache[rulename]; ok {
+		inPlayCache[rulename] = rule
+	}
+	return nil
+}
+
+// ExpiredRules returns the names of the rules in play whose sunset clause has been reached by turn, in order
+func ExpiredRules(turn uint, inPlayCache map[string]RuleMatrix) []string {
+	var expired []string
+	for name, rule := range inPlayCache {
+		if rule.ExpiryTurn != 0 && rule.ExpiryTurn <= turn {
+			expired = append(expired, name)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// linkGroup returns the rules connected to ruleName through links in either direction, starting with ruleName. Linked
+// rules enter and leave play together.
+func linkGroup(ruleName string, availableRules map[string]RuleMatrix) []string {
+	group := []string{ruleName}
+	inGroup := map[string]bool{ruleName: true}
+	for i := 0; i < len(group); i++ {
+		parents := []string{}
+		for name, rule := range availableRules {
+			for _, child := range rule.Link.Children() {
+				if child == group[i] {
+					parents = append(parents, name)
 				}
 			}
 		}
+		sort.Strings(parents)
+		for _, name := range append(availableRules[group[i]].Link.Children(), parents...) {
+			if _, ok := availableRules[name]; ok && !inGroup[name] {
+				inGroup[name] = true
+				group = append(group, name)
+			}
+		}
 	}
-	return "", false
+	return group
+}
+
+// CheckRuleLinks checks that all rules linked to from ruleName, directly or through other linked rules, are in
+// availableRules and that the links do not form a cycle
+func CheckRuleLinks(ruleName string, availableRules map[string]RuleMatrix) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	path := []string{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		rule, ok := availableRules[name]
+		if !ok {
+			return &RuleError{
+				Err:       errors.Errorf("Rule '%v' links to rule '%v', which does not exist in available rules", path[len(path)-1], name),
+				ErrorType: ChildRuleNotFound,
+			}
+		}
+		switch state[name] {
+		case visiting:
+			return &RuleError{
+				Err:       errors.Errorf("Rules are linked in a cycle: %v", strings.Join(append(path, name), " -> ")),
+				ErrorType: RuleLinkCycle,
+			}
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, child := range rule.Link.Children() {
+			if err := visit(child); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, name := range linkGroup(ruleName, availableRules) {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func CopyRulesMap(rulesMap map[string]RuleMatrix) map[string]RuleMatrix {
 	targetMap := make(map[string]RuleMatrix)
 	for key, value := range rulesMap {
-		targetMap[key] = copySingleRuleMatrix(value)
+		targetMap[key] = CopyRuleMatrix(value)
 	}
 	return targetMap
 }
 
-func copySingleRuleMatrix(inp RuleMatrix) RuleMatrix {
+// CopyRuleMatrix returns a deep copy of a rule
+func CopyRuleMatrix(inp RuleMatrix) RuleMatrix {
 	return RuleMatrix{
 		RuleName:          inp.RuleName,
 		RequiredVariables: copyRequiredVariables(inp.RequiredVariables),
@@ -112,14 +203,21 @@ func copySingleRuleMatrix(inp RuleMatrix) RuleMatrix {
 		AuxiliaryVector:   *mat.VecDenseCopyOf(&inp.AuxiliaryVector),
 		Mutable:           inp.Mutable,
 		Link:              copyLink(inp.Link),
+		ExpiryTurn:        inp.ExpiryTurn,
 	}
 }
 
 func copyLink(inp RuleLink) RuleLink {
+	var additionalLinkedRules []string
+	if inp.AdditionalLinkedRules != nil {
+		additionalLinkedRules = make([]string, len(inp.AdditionalLinkedRules))
+		copy(additionalLinkedRules, inp.AdditionalLinkedRules)
+	}
 	return RuleLink{
-		Linked:     inp.Linked,
-		LinkType:   inp.LinkType,
-		LinkedRule: inp.LinkedRule,
+		Linked:                inp.Linked,
+		LinkType:              inp.LinkType,
+		LinkedRule:            inp.LinkedRule,
+		AdditionalLinkedRules: additionalLinkedRules,
 	}
 }
 
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/pkg/testutils"
@@ -184,13 +185,40 @@ func TestModifyRule(t *testing.T) {
 	}
 }
 
-func TestCheckLinking(t *testing.T) {
+func TestRuleSunsetClauses(t *testing.T) {
+	matrix, aux := *mat.NewDense(1, 2, []float64{1, 0}), *mat.NewVecDense(1, []float64{0})
+	availableRules := map[string]RuleMatrix{
+		"linked": {RuleName: "linked", ApplicableMatrix: matrix, AuxiliaryVector: aux, Mutable: true, Link: RuleLink{Linked: true, LinkType: OrLink, LinkedRule: "other"}},
+		"other":  {RuleName: "other", ApplicableMatrix: matrix, AuxiliaryVector: aux, Mutable: true, ExpiryTurn: 3},
+		"fixed":  {RuleName: "fixed", ApplicableMatrix: matrix, AuxiliaryVector: aux, ExpiryTurn: 2},
+	}
+	inPlay := CopyRulesMap(availableRules)
+	if err := SetRuleExpiryInternal("linked", 5, availableRules, inPlay); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := SetRuleExpiryInternal("fixed", 5, availableRules, inPlay); err == nil {
+		t.Errorf("expected an error setting the expiry of an immutable rule")
+	}
+	if err := ModifyRuleInternal("linked", *mat.NewDense(1, 2, []float64{1, -1}), *mat.NewVecDense(1, []float64{2}), availableRules, inPlay); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	modified := inPlay["linked"]
+	if modified.ExpiryTurn != 5 || !modified.Link.Linked || modified.Link.LinkedRule != "other" {
+		t.Errorf("modification should keep the link and sunset clause, got %v", modified)
+	}
+	for turn, want := range map[uint][]string{1: nil, 2: {"fixed"}, 3: {"fixed", "other"}, 5: {"fixed", "linked", "other"}} {
+		if got := ExpiredRules(turn, inPlay); !reflect.DeepEqual(got, want) {
+			t.Errorf("turn %v: want %v got %v", turn, want, got)
+		}
+	}
+}
+
+func TestLinkGroup(t *testing.T) {
 	cases := []struct {
-		name               string
-		ruleName           string
-		rulesCache         map[string]RuleMatrix
-		expectedLinkedRule string
-		expectedLinked     bool
+		name          string
+		ruleName      string
+		rulesCache    map[string]RuleMatrix
+		expectedGroup []string
 	}{
 		{
 			name:     "Basic non linked rule",
@@ -203,8 +231,7 @@ func TestCheckLinking(t *testing.T) {
 					},
 				},
 			},
-			expectedLinkedRule: "",
-			expectedLinked:     false,
+			expectedGroup: []string{"Some rule"},
 		},
 		{
 			name:     "Basic linked rule",
@@ -224,24 +251,106 @@ func TestCheckLinking(t *testing.T) {
 					},
 				},
 			},
-			expectedLinkedRule: "Linker Rule",
-			expectedLinked:     true,
+			expectedGroup: []string{"Some rule", "Linker Rule"},
+		},
+		{
+			name:     "Group is found from a child",
+			ruleName: "c",
+			rulesCache: map[string]RuleMatrix{
+				"a": {RuleName: "a", Link: RuleLink{Linked: true, LinkType: AndLink, LinkedRule: "b", AdditionalLinkedRules: []string{"c"}}},
+				"b": {RuleName: "b", Link: RuleLink{Linked: true, LinkType: OrLink, LinkedRule: "d"}},
+				"c": {RuleName: "c"},
+				"d": {RuleName: "d"},
+				"e": {RuleName: "e"},
+			},
+			expectedGroup: []string{"c", "a", "b", "d"},
 		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			lnkRule, linked := checkLinking(tc.ruleName, tc.rulesCache)
+			group := linkGroup(tc.ruleName, tc.rulesCache)
 
-			if lnkRule != tc.expectedLinkedRule {
-				t.Errorf("Expected: %v got %v", tc.expectedLinkedRule, lnkRule)
+			if !reflect.DeepEqual(group, tc.expectedGroup) {
+				t.Errorf("Expected: %v got %v", tc.expectedGroup, group)
 			}
-			if linked != tc.expectedLinked {
-				t.Errorf("Expected: %v got %v", tc.expectedLinked, linked)
+		})
+	}
+}
+
+func TestCheckRuleLinks(t *testing.T) {
+	cases := []struct {
+		name       string
+		ruleName   string
+		rulesCache map[string]RuleMatrix
+		errorType  RuleErrorType
+	}{
+		{
+			name:     "Nested links",
+			ruleName: "a",
+			rulesCache: map[string]RuleMatrix{
+				"a": {RuleName: "a", Link: RuleLink{Linked: true, LinkType: XorLink, LinkedRule: "b", AdditionalLinkedRules: []string{"c"}}},
+				"b": {RuleName: "b", Link: RuleLink{Linked: true, LinkType: AndLink, LinkedRule: "c"}},
+				"c": {RuleName: "c"},
+			},
+			errorType: -1,
+		},
+		{
+			name:     "Missing child",
+			ruleName: "a",
+			rulesCache: map[string]RuleMatrix{
+				"a": {RuleName: "a", Link: RuleLink{Linked: true, LinkType: AndLink, LinkedRule: "b"}},
+			},
+			errorType: ChildRuleNotFound,
+		},
+		{
+			name:     "Cycle",
+			ruleName: "c",
+			rulesCache: map[string]RuleMatrix{
+				"a": {RuleName: "a", Link: RuleLink{Linked: true, LinkType: AndLink, LinkedRule: "b"}},
+				"b": {RuleName: "b", Link: RuleLink{Linked: true, LinkType: OrLink, LinkedRule: "a"}},
+				"c": {RuleName: "c", Link: RuleLink{Linked: true, LinkType: OrLink, LinkedRule: "a"}},
+			},
+			errorType: RuleLinkCycle,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckRuleLinks(tc.ruleName, tc.rulesCache)
+			if tc.errorType == -1 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if ruleErr, ok := err.(*RuleError); !ok || ruleErr.Type() != tc.errorType {
+				t.Errorf("want %v, got %v", tc.errorType, err)
 			}
 		})
 	}
 }
 
+func TestPullLinkGroupIntoAndOutOfPlay(t *testing.T) {
+	availableRules := map[string]RuleMatrix{
+		"a": {RuleName: "a", Link: RuleLink{Linked: true, LinkType: AndLink, LinkedRule: "b", AdditionalLinkedRules: []string{"c"}}},
+		"b": {RuleName: "b"},
+		"c": {RuleName: "c"},
+		"d": {RuleName: "d"},
+	}
+	inPlay := map[string]RuleMatrix{}
+	if err := PullRuleIntoPlayInternal("b", availableRules, inPlay); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inPlay) != 3 || inPlay["a"].RuleName != "a" || inPlay["c"].RuleName != "c" {
+		t.Errorf("expected the whole group in play, got %v", inPlay)
+	}
+	if err := PullRuleOutOfPlayInternal("c", availableRules, inPlay); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inPlay) != 0 {
+		t.Errorf("expected the whole group out of play, got %v", inPlay)
+	}
+}
+
 func generateRulesTestStores() (map[string]RuleMatrix, map[string]RuleMatrix) {
 	return map[string]RuleMatrix{}, map[string]RuleMatrix{}
 }
@@ -100,6 +100,11 @@ const (
 	TermEnded
 	ElectionHeld
 	AppointmentMatchesVote
+	RuleVoteTurnout
+	RuleVoteApproval
+
+	// DO NOT TOUCH THIS
+	variableFieldNameEnd
 )
 
 func (v VariableFieldName) String() string {
@@ -162,6 +167,8 @@ func (v VariableFieldName) String() string {
 		"TermEnded",
 		"ElectionHeld",
 		"AppointmentMatchesVote",
+		"RuleVoteTurnout",
+		"RuleVoteApproval",
 	}
 	if v >= 0 && int(v) < len(strs) {
 		return strs[v]
@@ -183,3 +190,13 @@ func (v VariableFieldName) MarshalText() ([]byte, error) {
 func (v VariableFieldName) MarshalJSON() ([]byte, error) {
 	return miscutils.MarshalJSONForString(v.String())
 }
+
+// UnmarshalText implements TextUnmarshaler, so that variables written to output.json can be read back
+func (v *VariableFieldName) UnmarshalText(text []byte) error {
+	variable, ok := lookupVariableFieldName(string(text))
+	if !ok {
+		return errors.Errorf("unknown variable '%s'", text)
+	}
+	*v = variable
+	return nil
+}
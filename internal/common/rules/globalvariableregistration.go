@@ -242,6 +242,14 @@ var StaticVariables = [...]VariableValuePair{
 		VariableName: AppointmentMatchesVote,
 		Values:       []float64{0},
 	},
+	{
+		VariableName: RuleVoteTurnout,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: RuleVoteApproval,
+		Values:       []float64{0},
+	},
 	{
 		VariableName: TaxDecisionMade,
 		Values:       []float64{1},
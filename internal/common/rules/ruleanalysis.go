@@ -0,0 +1,409 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// RuleAnalysis reports the problems found in a set of rules. The rows of each rule with auxiliary codes 0 to 3 are
+// treated as linear constraints over the values of its variables, which are shared between rules and may take any real
+// value. Linked rules and output rows (auxiliary code 4) do not constrain the variables and are not analysed.
+type RuleAnalysis struct {
+	Unsatisfiable []string   // rules no values of their variables satisfy
+	NeverFail     []string   // rules all values of their variables satisfy
+	Conflicts     [][]string // minimal sets of rules that are satisfiable on their own but not together
+	Redundant     []string   // rules satisfied whenever the other (non-conflicting) rules are
+}
+
+// IsEmpty returns true if no problems were found
+func (a RuleAnalysis) IsEmpty() bool {
+	return len(a.Unsatisfiable) == 0 && len(a.NeverFail) == 0 && len(a.Conflicts) == 0 && len(a.Redundant) == 0
+}
+
+// AnalyseRules checks a set of rules, typically the rules in play, for unsatisfiable, never failing, conflicting and
+// redundant rules. The number of values of multi-valued variables is taken from variableCache.
+func AnalyseRules(ruleSet map[string]RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) (RuleAnalysis, error) {
+	system, err := newConstraintSystem(ruleSet, variableCache)
+	if err != nil {
+		return RuleAnalysis{}, err
+	}
+	analysis := RuleAnalysis{}
+	remaining := []constrainedRule{}
+	for _, rule := range system.rules {
+		satisfiable, err := system.feasible(rule.constraints)
+		if err != nil {
+			return RuleAnalysis{}, err
+		}
+		if !satisfiable {
+			analysis.Unsatisfiable = append(analysis.Unsatisfiable, rule.name)
+			continue
+		}
+		neverFails, err := system.implies(nil, rule)
+		if err != nil {
+			return RuleAnalysis{}, err
+		}
+		if neverFails {
+			analysis.NeverFail = append(analysis.NeverFail, rule.name)
+			continue
+		}
+		remaining = append(remaining, rule)
+	}
+
+	remaining, analysis.Conflicts, err = system.removeConflicts(remaining)
+	if err != nil {
+		return RuleAnalysis{}, err
+	}
+
+	for i := 0; i < len(remaining); {
+		others := append(append([]constrainedRule{}, remaining[:i]...), remaining[i+1:]...)
+		redundant, err := system.implies(others, remaining[i])
+		if err != nil {
+			return RuleAnalysis{}, err
+		}
+		if redundant {
+			analysis.Redundant = append(analysis.Redundant, remaining[i].name)
+			remaining = others
+		} else {
+			i++
+		}
+	}
+	return analysis, nil
+}
+
+// FindConflictingRules returns a minimal set of rules in inPlay that cannot be satisfied together with candidate, or
+// nil if there is none. Conflicts amongst the rules in inPlay alone are disregarded, and a rule in inPlay with the same
+// name as candidate is taken to be replaced by it. If candidate cannot be satisfied on its own, the result only holds
+// its name.
+func FindConflictingRules(candidate RuleMatrix, inPlay map[string]RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) ([]string, error) {
+	ruleSet := CopyRulesMap(inPlay)
+	ruleSet[candidate.RuleName] = candidate
+	system, err := newConstraintSystem(ruleSet, variableCache)
+	if err != nil {
+		return nil, err
+	}
+	var target constrainedRule
+	others := []constrainedRule{}
+	for _, rule := range system.rules {
+		if rule.name == candidate.RuleName {
+			target = rule
+		} else {
+			others = append(others, rule)
+		}
+	}
+	if target.name == "" {
+		return nil, nil // candidate does not constrain the variables
+	}
+	satisfiable, err := system.feasible(target.constraints)
+	if err != nil || !satisfiable {
+		return []string{candidate.RuleName}, err
+	}
+
+	others, _, err = system.removeConflicts(others)
+	if err != nil {
+		return nil, err
+	}
+	conflict, err := system.minimalConflict(others, target.constraints)
+	if err != nil || conflict == nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, rule := range conflict {
+		names = append(names, rule.name)
+	}
+	return names, nil
+}
+
+// linearConstraint requires coefficients·x + constant to compare to zero as given by an auxiliary code from 0 to 3
+type linearConstraint struct {
+	coefficients []float64
+	constant     float64
+	aux          float64
+}
+
+func (c linearConstraint) negated(aux float64) linearConstraint {
+	coefficients := make([]float64, len(c.coefficients))
+	for i, coefficient := range c.coefficients {
+		coefficients[i] = -coefficient
+	}
+	return linearConstraint{coefficients: coefficients, constant: -c.constant, aux: aux}
+}
+
+// violations returns the alternative constraints, one of which holds exactly when c does not
+func (c linearConstraint) violations() []linearConstraint {
+	switch c.aux {
+	case 0:
+		return []linearConstraint{{coefficients: c.coefficients, constant: c.constant, aux: 1}, c.negated(1)}
+	case 1:
+		return []linearConstraint{c.negated(2)}
+	case 2:
+		return []linearConstraint{c.negated(1)}
+	default:
+		return []linearConstraint{{coefficients: c.coefficients, constant: c.constant, aux: 0}}
+	}
+}
+
+type constrainedRule struct {
+	name        string
+	constraints []linearConstraint
+}
+
+// constraintSystem holds the constraints of a set of rules over a shared set of variable values
+type constraintSystem struct {
+	rules    []constrainedRule // sorted by name
+	nColumns int
+}
+
+func newConstraintSystem(ruleSet map[string]RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) (constraintSystem, error) {
+	names := []string{}
+	for name, rule := range ruleSet {
+		if !rule.Link.Linked {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	columns := map[variableColumn]int{}
+	ruleColumns := map[string][]int{}
+	for _, name := range names {
+		rule := ruleSet[name]
+		widths, err := ruleColumnWidths(rule, variableCache)
+		if err != nil {
+			return constraintSystem{}, err
+		}
+		for i, v := range rule.RequiredVariables {
+			for index := 0; index < widths[i]; index++ {
+				column := variableColumn{variable: v, index: index}
+				if _, ok := columns[column]; !ok {
+					columns[column] = len(columns)
+				}
+				ruleColumns[name] = append(ruleColumns[name], columns[column])
+			}
+		}
+	}
+
+	system := constraintSystem{nColumns: len(columns)}
+	for _, name := range names {
+		rule := ruleSet[name]
+		nRows, nCols := rule.ApplicableMatrix.Dims()
+		if rule.AuxiliaryVector.Len() != nRows {
+			return constraintSystem{}, &RuleError{
+				Err:       errors.Errorf("Rule '%v' has %v rows but %v auxiliary codes", name, nRows, rule.AuxiliaryVector.Len()),
+				ErrorType: AuxVectorDimensionDontMatchRuleMatrix,
+			}
+		}
+		constrained := constrainedRule{name: name}
+		for i := 0; i < nRows; i++ {
+			aux := rule.AuxiliaryVector.AtVec(i)
+			if aux == 4 {
+				continue
+			}
+			if aux != 0 && aux != 1 && aux != 2 && aux != 3 {
+				return constraintSystem{}, &RuleError{
+					Err:       errors.Errorf("Rule '%v' row %v: auxiliary code %v outside of 0-4", name, i, aux),
+					ErrorType: AuxVectorCodeOutOfRange,
+				}
+			}
+			constraint := linearConstraint{coefficients: make([]float64, system.nColumns), constant: rule.ApplicableMatrix.At(i, nCols-1), aux: aux}
+			for j, column := range ruleColumns[name] {
+				constraint.coefficients[column] += rule.ApplicableMatrix.At(i, j)
+			}
+			constrained.constraints = append(constrained.constraints, constraint)
+		}
+		if len(constrained.constraints) > 0 {
+			system.rules = append(system.rules, constrained)
+		}
+	}
+	return system, nil
+}
+
+func collectConstraints(rules []constrainedRule, extra []linearConstraint) []linearConstraint {
+	constraints := []linearConstraint{}
+	for _, rule := range rules {
+		constraints = append(constraints, rule.constraints...)
+	}
+	return append(constraints, extra...)
+}
+
+// implies checks whether rule holds whenever all of premises hold
+func (s constraintSystem) implies(premises []constrainedRule, rule constrainedRule) (bool, error) {
+	base := collectConstraints(premises, nil)
+	for _, constraint := range rule.constraints {
+		for _, violation := range constraint.violations() {
+			violable, err := s.feasible(append(base[:len(base):len(base)], violation))
+			if err != nil || violable {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// removeConflicts repeatedly takes minimal conflicting sets out of rules until the rest can be satisfied together
+func (s constraintSystem) removeConflicts(rules []constrainedRule) ([]constrainedRule, [][]string, error) {
+	var conflicts [][]string
+	for {
+		conflict, err := s.minimalConflict(rules, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if conflict == nil {
+			return rules, conflicts, nil
+		}
+		names := []string{}
+		inConflict := map[string]bool{}
+		for _, rule := range conflict {
+			names = append(names, rule.name)
+			inConflict[rule.name] = true
+		}
+		conflicts = append(conflicts, names)
+		remaining := []constrainedRule{}
+		for _, rule := range rules {
+			if !inConflict[rule.name] {
+				remaining = append(remaining, rule)
+			}
+		}
+		rules = remaining
+	}
+}
+
+// minimalConflict finds a minimal subset of rules that cannot be satisfied together with the fixed constraints, or nil
+// if all of rules can be. Rules are dropped one by one as long as the rest still conflict.
+func (s constraintSystem) minimalConflict(rules []constrainedRule, fixed []linearConstraint) ([]constrainedRule, error) {
+	satisfiable, err := s.feasible(collectConstraints(rules, fixed))
+	if err != nil || satisfiable {
+		return nil, err
+	}
+	conflict := append([]constrainedRule{}, rules...)
+	for i := 0; i < len(conflict); {
+		without := append(append([]constrainedRule{}, conflict[:i]...), conflict[i+1:]...)
+		satisfiable, err := s.feasible(collectConstraints(without, fixed))
+		if err != nil {
+			return nil, err
+		}
+		if satisfiable {
+			i++
+		} else {
+			conflict = without
+		}
+	}
+	return conflict, nil
+}
+
+// feasible checks whether some values of the variables satisfy all constraints. A convex set that is not contained in
+// any of finitely many hyperplanes is not covered by them either, so each != constraint is checked on its own.
+func (s constraintSystem) feasible(constraints []linearConstraint) (bool, error) {
+	base := []linearConstraint{}
+	notEqual := []linearConstraint{}
+	for _, constraint := range constraints {
+		if constraint.aux == 3 {
+			notEqual = append(notEqual, constraint)
+		} else {
+			base = append(base, constraint)
+		}
+	}
+	if ok, err := s.feasibleWithoutNotEqual(base); err != nil || !ok {
+		return false, err
+	}
+	for _, constraint := range notEqual {
+		above := linearConstraint{coefficients: constraint.coefficients, constant: constraint.constant, aux: 1}
+		ok, err := s.feasibleWithoutNotEqual(append(base[:len(base):len(base)], above))
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			ok, err = s.feasibleWithoutNotEqual(append(base[:len(base):len(base)], constraint.negated(1)))
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// feasibilityTolerance is the margin by which strict inequalities must hold
+const feasibilityTolerance = 1e-9
+
+// feasibleWithoutNotEqual checks whether some values of the variables satisfy constraints with auxiliary codes 0 to
+// 2 by solving the linear program
+//
+//	maximise t subject to
+//	    a·x + c == 0      for == constraints
+//	    a·x + c - t >= 0  for > constraints
+//	    a·x + c >= 0      for >= constraints
+//	    0 <= t <= 1
+//
+// The constraints can be satisfied if the program is feasible and t can be made positive.
+func (s constraintSystem) feasibleWithoutNotEqual(constraints []linearConstraint) (bool, error) {
+	// only columns used by some constraint are solved for, as lp.Simplex rejects columns of zeros
+	used := []int{}
+	for column := 0; column < s.nColumns; column++ {
+		for _, constraint := range constraints {
+			if constraint.coefficients[column] != 0 {
+				used = append(used, column)
+				break
+			}
+		}
+	}
+	tColumn := len(used)
+	g := [][]float64{}
+	h := []float64{}
+	addRow := func(constraint linearConstraint, sign float64, t float64) {
+		row := make([]float64, len(used)+1)
+		for j, column := range used {
+			row[j] = -sign * constraint.coefficients[column]
+		}
+		row[tColumn] = t
+		g = append(g, row)
+		h = append(h, sign*constraint.constant)
+	}
+	for _, constraint := range constraints {
+		trivial := true
+		for _, column := range used {
+			if constraint.coefficients[column] != 0 {
+				trivial = false
+			}
+		}
+		if trivial {
+			if !satisfy(constraint.constant, constraint.aux) {
+				return false, nil
+			}
+			continue
+		}
+		switch constraint.aux {
+		case 0:
+			addRow(constraint, 1, 0)
+			addRow(constraint, -1, 0)
+		case 1:
+			addRow(constraint, 1, 1)
+		case 2:
+			addRow(constraint, 1, 0)
+		}
+	}
+	if len(g) == 0 {
+		return true, nil
+	}
+	tBounds := [][]float64{make([]float64, len(used)+1), make([]float64, len(used)+1)}
+	tBounds[0][tColumn], tBounds[1][tColumn] = 1, -1
+	g = append(g, tBounds...)
+	h = append(h, 1, 0)
+
+	gMatrix := mat.NewDense(len(g), len(used)+1, nil)
+	for i, row := range g {
+		gMatrix.SetRow(i, row)
+	}
+	objective := make([]float64, len(used)+1)
+	objective[tColumn] = -1
+	cNew, aNew, bNew := lp.Convert(objective, gMatrix, h, nil, nil)
+	optimum, _, err := lp.Simplex(cNew, aNew, bNew, 1e-10, nil)
+	switch err {
+	case nil:
+		return -optimum > feasibilityTolerance, nil
+	case lp.ErrInfeasible:
+		return false, nil
+	default:
+		return false, errors.Errorf("Could not solve the constraints of the rules: %v", err)
+	}
+}
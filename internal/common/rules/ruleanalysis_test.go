@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func parseRuleSet(t *testing.T, text string) map[string]RuleMatrix {
+	parsed, err := ParseRules(text)
+	if err != nil {
+		t.Fatalf("invalid rules: %v", err)
+	}
+	ruleSet := map[string]RuleMatrix{}
+	for _, rule := range parsed {
+		ruleSet[rule.RuleName] = rule
+	}
+	return ruleSet
+}
+
+func TestAnalyseRules(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules string
+		want  RuleAnalysis
+	}{
+		{
+			name: "no problems",
+			rules: `rule a: IslandTaxContribution - ExpectedTaxContribution >= 0
+				rule b: ExpectedTaxContribution > 10`,
+			want: RuleAnalysis{},
+		},
+		{
+			name: "unsatisfiable and never failing rules",
+			rules: `rule contradiction: TermEnded > 0; TermEnded < 0
+				rule constant: 0 == 1
+				rule tautology: 0 * TermEnded >= -1
+				rule outputs_only: output SanctionExpected`,
+			want: RuleAnalysis{Unsatisfiable: []string{"constant", "contradiction"}, NeverFail: []string{"tautology"}},
+		},
+		{
+			name: "conflicting rules",
+			rules: `rule high: IslandTaxContribution >= 5
+				rule low: IslandTaxContribution < 3
+				rule zero: ElectionHeld == 0
+				rule nonzero: 2 * ElectionHeld != 0
+				rule unrelated: TermEnded == 1`,
+			want: RuleAnalysis{Conflicts: [][]string{{"nonzero", "zero"}, {"high", "low"}}},
+		},
+		{
+			name: "conflict between three rules",
+			rules: `rule sum: IslandTaxContribution + IslandAllocation <= 10
+				rule tax: IslandTaxContribution > 6
+				rule allocation: IslandAllocation >= 4`,
+			want: RuleAnalysis{Conflicts: [][]string{{"allocation", "sum", "tax"}}},
+		},
+		{
+			name: "boundary cases are satisfiable",
+			rules: `rule at_least: IslandTaxContribution >= 3
+				rule at_most: IslandTaxContribution <= 3
+				rule not_four: IslandTaxContribution != 4`,
+			want: RuleAnalysis{Redundant: []string{"not_four"}},
+		},
+		{
+			name: "redundant rules",
+			rules: `rule five: IslandTaxContribution >= 5
+				rule three: IslandTaxContribution > 3
+				rule copy: IslandTaxContribution >= 5
+				rule equal: IslandTaxContribution - ExpectedTaxContribution == 0
+				rule expected: ExpectedTaxContribution >= 1`,
+			want: RuleAnalysis{Redundant: []string{"copy", "expected", "three"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := AnalyseRules(parseRuleSet(t, tc.rules), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+			if got.IsEmpty() != reflect.DeepEqual(tc.want, RuleAnalysis{}) {
+				t.Errorf("IsEmpty() returned %v for %+v", got.IsEmpty(), got)
+			}
+		})
+	}
+}
+
+func TestAnalyseRegisteredRules(t *testing.T) {
+	_, inPlay := InitialRuleRegistration(true)
+	analysis, err := AnalyseRules(inPlay, InitialVarRegistration())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(analysis.Unsatisfiable) != 0 || len(analysis.Conflicts) != 0 {
+		t.Errorf("registered rules should be satisfiable together, got %+v", analysis)
+	}
+}
+
+func TestFindConflictingRules(t *testing.T) {
+	inPlay := parseRuleSet(t, `
+		rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0
+		rule expected: ExpectedTaxContribution == 10
+		rule impossible: TermEnded > 1; TermEnded < 0
+		rule high: ElectionHeld >= 5
+		rule low: ElectionHeld <= 3`)
+	cases := []struct {
+		name      string
+		candidate string
+		want      []string
+	}{
+		{name: "compatible rule", candidate: "rule cap: IslandTaxContribution <= 20", want: nil},
+		{name: "conflicting rule", candidate: "rule cap: IslandTaxContribution < 10", want: []string{"expected", "tax"}},
+		{name: "replacing a rule removes its conflict", candidate: "rule expected: ExpectedTaxContribution == 1; IslandTaxContribution < 10", want: nil},
+		{name: "unsatisfiable rule", candidate: "rule never: 0 > 0", want: []string{"never"}},
+		{name: "existing conflicts are disregarded", candidate: "rule turn: TermEnded == 1", want: nil},
+		{name: "linked rules are not analysed", candidate: "rule never: 0 > 0 link ParentFailAutoRulePass tax", want: nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			candidate, err := ParseRule(tc.candidate)
+			if err != nil {
+				t.Fatalf("invalid rule: %v", err)
+			}
+			got, err := FindConflictingRules(candidate, inPlay, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,752 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// The rule language describes a RuleMatrix as a list of linear constraints over its variables, e.g.
+//
+//	rule tax_paid: IslandTaxContribution - ExpectedTaxContribution >= 0
+//
+//	immutable rule "Kinda Complicated Rule":
+//	    NumberOfIslandsContributingToCommonPool - NumberOfFailedForages == 0;
+//	    output 2 * NumberOfBrokenAgreements + MaxSeverityOfSanctions
+//	    link ParentFailAutoRulePass other_rule
+//
+// Each constraint becomes one row of the matrix. The comparisons ==, >, >= and != map to the auxiliary codes 0 to 3;
+// < and <= are rewritten by swapping both sides. An `output` row has auxiliary code 4. Rules are mutable unless
+// preceded by `immutable`. Multi-valued variables are indexed as IslandsAlive[2]; their number of values is the highest
+// index used plus one, unless declared with an `over` clause (`rule r over IslandsAlive[6], TermEnded: ...`), which also
+// fixes the order of the variables. A `link` names the link type (any but NoLink) followed by a comma-separated list of
+// child rules, and a final `expires 12` gives the turn at which the rule is taken out of play. Text from # to the end
+// of a line is a comment.
+
+// ParseRule compiles the text of a single rule into a RuleMatrix
+func ParseRule(text string) (RuleMatrix, error) {
+	parsed, err := ParseRules(text)
+	if err != nil {
+		return RuleMatrix{}, err
+	}
+	if len(parsed) != 1 {
+		return RuleMatrix{}, &RuleError{Err: errors.Errorf("Expected exactly one rule, found %v", len(parsed)), ErrorType: RuleSyntaxError}
+	}
+	return parsed[0], nil
+}
+
+// ParseRules compiles the text of any number of rules into RuleMatrices, in the order they are written
+func ParseRules(text string) ([]RuleMatrix, error) {
+	tokens, err := lexRules(text)
+	if err != nil {
+		return nil, err
+	}
+	p := ruleParser{tokens: tokens}
+	parsed := []RuleMatrix{}
+	for p.peek().kind != dslEOF {
+		rule, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+// FormatRule prints a RuleMatrix in the rule language, assuming every variable holds a single value
+func FormatRule(rule RuleMatrix) (string, error) {
+	return FormatRuleWithVariables(rule, nil)
+}
+
+// FormatRuleWithVariables prints a RuleMatrix in the rule language, taking the number of values of each variable from
+// variableCache. Variables missing from variableCache are assumed to hold a single value.
+func FormatRuleWithVariables(rule RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) (string, error) {
+	for _, v := range rule.RequiredVariables {
+		if v < 0 || v >= variableFieldNameEnd {
+			return "", &RuleError{Err: errors.Errorf("Rule '%v' requires unknown variable %v", rule.RuleName, int(v)), ErrorType: UnknownVariableFieldName}
+		}
+	}
+	widths, err := ruleColumnWidths(rule, variableCache)
+	if err != nil {
+		return "", err
+	}
+	nRows, _ := rule.ApplicableMatrix.Dims()
+	if rule.AuxiliaryVector.Len() != nRows {
+		return "", &RuleError{
+			Err:       errors.Errorf("Rule '%v' has %v rows but %v auxiliary codes", rule.RuleName, nRows, rule.AuxiliaryVector.Len()),
+			ErrorType: AuxVectorDimensionDontMatchRuleMatrix,
+		}
+	}
+
+	columnNames := []string{}
+	for i, v := range rule.RequiredVariables {
+		for j := 0; j < widths[i]; j++ {
+			if widths[i] == 1 {
+				columnNames = append(columnNames, v.String())
+			} else {
+				columnNames = append(columnNames, fmt.Sprintf("%v[%v]", v, j))
+			}
+		}
+	}
+	clauses := make([]string, nRows)
+	for i := 0; i < nRows; i++ {
+		clause, err := formatRow(rule.ApplicableMatrix.RawRowView(i), rule.AuxiliaryVector.AtVec(i), columnNames)
+		if err != nil {
+			return "", &RuleError{Err: errors.Errorf("Rule '%v' row %v: %v", rule.RuleName, i, err), ErrorType: AuxVectorCodeOutOfRange}
+		}
+		clauses[i] = clause
+	}
+
+	var sb strings.Builder
+	if !rule.Mutable {
+		sb.WriteString("immutable ")
+	}
+	sb.WriteString("rule " + formatRuleName(rule.RuleName))
+	if needsOverClause(rule, widths) {
+		declarations := make([]string, len(rule.RequiredVariables))
+		for i, v := range rule.RequiredVariables {
+			declarations[i] = fmt.Sprintf("%v[%v]", v, widths[i])
+		}
+		sb.WriteString(" over " + strings.Join(declarations, ", "))
+	}
+	sb.WriteString(":")
+	separator := " "
+	if nRows > 1 {
+		separator = "\n    "
+	}
+	sb.WriteString(separator + strings.Join(clauses, ";"+separator))
+	if rule.Link.Linked {
+		children := []string{}
+		for _, child := range rule.Link.Children() {
+			children = append(children, formatRuleName(child))
+		}
+		sb.WriteString(fmt.Sprintf("%vlink %v %v", separator, rule.Link.LinkType, strings.Join(children, ", ")))
+	}
+	if rule.ExpiryTurn != 0 {
+		sb.WriteString(fmt.Sprintf("%vexpires %v", separator, rule.ExpiryTurn))
+	}
+	return sb.String(), nil
+}
+
+// formatRow prints one matrix row as a constraint with the variables on the left and the constant on the right, or as
+// an output expression
+func formatRow(row []float64, aux float64, columnNames []string) (string, error) {
+	constant := row[len(row)-1]
+	var sb strings.Builder
+	for j, name := range columnNames {
+		coefficient := row[j]
+		if coefficient == 0 {
+			continue
+		}
+		switch {
+		case sb.Len() == 0 && coefficient < 0:
+			sb.WriteString("-")
+		case sb.Len() > 0 && coefficient < 0:
+			sb.WriteString(" - ")
+		case sb.Len() > 0:
+			sb.WriteString(" + ")
+		}
+		if magnitude := abs(coefficient); magnitude != 1 {
+			sb.WriteString(formatNumber(magnitude) + " * ")
+		}
+		sb.WriteString(name)
+	}
+
+	comparisons := map[float64]string{0: "==", 1: ">", 2: ">=", 3: "!="}
+	if comparison, ok := comparisons[aux]; ok {
+		if sb.Len() == 0 {
+			sb.WriteString("0")
+		}
+		return fmt.Sprintf("%v %v %v", sb.String(), comparison, formatNumber(-constant)), nil
+	}
+	if aux != 4 {
+		return "", errors.Errorf("auxiliary code %v outside of 0-4", aux)
+	}
+	switch {
+	case sb.Len() == 0:
+		sb.WriteString(formatNumber(constant))
+	case constant < 0:
+		sb.WriteString(" - " + formatNumber(-constant))
+	case constant > 0:
+		sb.WriteString(" + " + formatNumber(constant))
+	}
+	return "output " + sb.String(), nil
+}
+
+// needsOverClause checks whether the variables of a rule can be inferred from the constraints alone, i.e. they all hold
+// a single value and appear in the order they are required
+func needsOverClause(rule RuleMatrix, widths []int) bool {
+	for _, width := range widths {
+		if width != 1 {
+			return true
+		}
+	}
+	nRows, _ := rule.ApplicableMatrix.Dims()
+	appearance := []VariableFieldName{}
+	for i := 0; i < nRows; i++ {
+		for j, v := range rule.RequiredVariables {
+			if _, seen := searchForVariableInArray(v, appearance); !seen && rule.ApplicableMatrix.At(i, j) != 0 {
+				appearance = append(appearance, v)
+			}
+		}
+	}
+	if len(appearance) != len(rule.RequiredVariables) {
+		return true
+	}
+	for i, v := range appearance {
+		if rule.RequiredVariables[i] != v {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumber(x float64) string {
+	if x == 0 {
+		return "0" // avoids printing -0
+	}
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
+
+func formatRuleName(name string) string {
+	if isIdentifier(name) && !isRuleKeyword(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isRuleKeyword(s string) bool {
+	switch s {
+	case "rule", "immutable", "over", "output", "link", "expires":
+		return true
+	}
+	return false
+}
+
+// lookupVariableFieldName finds the variable with the given name
+func lookupVariableFieldName(name string) (VariableFieldName, bool) {
+	for v := VariableFieldName(0); v < variableFieldNameEnd; v++ {
+		if v.String() == name {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func unknownVariableError(tok dslToken) error {
+	hint := ""
+	for v := VariableFieldName(0); v < variableFieldNameEnd; v++ {
+		if strings.EqualFold(v.String(), tok.text) {
+			hint = fmt.Sprintf(" (did you mean '%v'?)", v)
+		}
+	}
+	return &RuleError{Err: errors.Errorf("%v: unknown variable '%v'%v", tok.position(), tok.text, hint), ErrorType: UnknownVariableFieldName}
+}
+
+type dslTokenKind int
+
+const (
+	dslEOF dslTokenKind = iota
+	dslIdentifier
+	dslNumber
+	dslString
+	dslSymbol
+)
+
+type dslToken struct {
+	kind   dslTokenKind
+	text   string
+	number float64
+	line   int
+	column int
+}
+
+func (t dslToken) position() string {
+	return fmt.Sprintf("line %v, column %v", t.line, t.column)
+}
+
+func (t dslToken) describe() string {
+	switch t.kind {
+	case dslEOF:
+		return "end of input"
+	case dslString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("'%v'", t.text)
+}
+
+// lexRules splits the text of rules into tokens
+func lexRules(text string) ([]dslToken, error) {
+	runes := []rune(text)
+	tokens := []dslToken{}
+	line, lineStart := 1, 0
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		tok := dslToken{line: line, column: i - lineStart + 1}
+		start := i
+		switch {
+		case r == '\n':
+			i++
+			line, lineStart = line+1, i
+			continue
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tok.kind = dslIdentifier
+		case unicode.IsDigit(r) || r == '.':
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					for i = j; i < len(runes) && unicode.IsDigit(runes[i]); i++ {
+					}
+				}
+			}
+			number, err := strconv.ParseFloat(string(runes[start:i]), 64)
+			if err != nil {
+				return nil, &RuleError{Err: errors.Errorf("%v: invalid number '%v'", tok.position(), string(runes[start:i])), ErrorType: RuleSyntaxError}
+			}
+			tok.kind, tok.number = dslNumber, number
+		case r == '"':
+			for i++; i < len(runes) && runes[i] != '"' && runes[i] != '\n'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(runes) || runes[i] != '"' {
+				return nil, &RuleError{Err: errors.Errorf("%v: unterminated rule name", tok.position()), ErrorType: RuleSyntaxError}
+			}
+			i++
+			name, err := strconv.Unquote(string(runes[start:i]))
+			if err != nil {
+				return nil, &RuleError{Err: errors.Errorf("%v: invalid rule name %v", tok.position(), string(runes[start:i])), ErrorType: RuleSyntaxError}
+			}
+			tokens = append(tokens, dslToken{kind: dslString, text: name, line: tok.line, column: tok.column})
+			continue
+		case strings.ContainsRune("=!<>", r) && i+1 < len(runes) && runes[i+1] == '=':
+			i += 2
+			tok.kind = dslSymbol
+		case strings.ContainsRune(":;,+-*[]<>", r):
+			i++
+			tok.kind = dslSymbol
+		default:
+			return nil, &RuleError{Err: errors.Errorf("%v: unexpected character '%v'", tok.position(), string(r)), ErrorType: RuleSyntaxError}
+		}
+		tok.text = string(runes[start:i])
+		tokens = append(tokens, tok)
+	}
+	tokens = append(tokens, dslToken{kind: dslEOF, line: line, column: len(runes) - lineStart + 1})
+	return tokens, nil
+}
+
+// ruleParser is a recursive descent parser over the tokens of the rule language
+type ruleParser struct {
+	tokens []dslToken
+	pos    int
+
+	// state of the rule being parsed
+	declared  bool
+	variables []VariableFieldName
+	widths    map[VariableFieldName]int
+	rows      []linearExpression
+	auxiliary []float64
+}
+
+// linearExpression is a sum of scaled variable values and a constant
+type linearExpression struct {
+	coefficients map[variableColumn]float64
+	constant     float64
+}
+
+type variableColumn struct {
+	variable VariableFieldName
+	index    int
+}
+
+func (e linearExpression) add(other linearExpression, scale float64) linearExpression {
+	for column, coefficient := range other.coefficients {
+		e.coefficients[column] += scale * coefficient
+	}
+	e.constant += scale * other.constant
+	return e
+}
+
+func (p *ruleParser) peek() dslToken {
+	return p.tokens[p.pos]
+}
+
+func (p *ruleParser) next() dslToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != dslEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *ruleParser) atSymbol(symbol string) bool {
+	tok := p.peek()
+	return tok.kind == dslSymbol && tok.text == symbol
+}
+
+func (p *ruleParser) atKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == dslIdentifier && tok.text == keyword
+}
+
+func (p *ruleParser) errorf(tok dslToken, format string, a ...interface{}) error {
+	return &RuleError{Err: errors.Errorf("%v: %v", tok.position(), fmt.Sprintf(format, a...)), ErrorType: RuleSyntaxError}
+}
+
+func (p *ruleParser) expectSymbol(symbol string) error {
+	if !p.atSymbol(symbol) {
+		return p.errorf(p.peek(), "expected '%v', found %v", symbol, p.peek().describe())
+	}
+	p.next()
+	return nil
+}
+
+func (p *ruleParser) parseRuleName() (string, error) {
+	tok := p.next()
+	if tok.kind == dslString || (tok.kind == dslIdentifier && !isRuleKeyword(tok.text)) {
+		return tok.text, nil
+	}
+	return "", p.errorf(tok, "expected a rule name, found %v", tok.describe())
+}
+
+func (p *ruleParser) parseRule() (RuleMatrix, error) {
+	p.declared, p.variables, p.widths, p.rows, p.auxiliary = false, nil, map[VariableFieldName]int{}, nil, nil
+
+	mutable := true
+	if p.atKeyword("immutable") {
+		p.next()
+		mutable = false
+	}
+	if !p.atKeyword("rule") {
+		return RuleMatrix{}, p.errorf(p.peek(), "expected 'rule', found %v", p.peek().describe())
+	}
+	p.next()
+	name, err := p.parseRuleName()
+	if err != nil {
+		return RuleMatrix{}, err
+	}
+	if p.atKeyword("over") {
+		p.next()
+		if err := p.parseDeclarations(); err != nil {
+			return RuleMatrix{}, err
+		}
+	}
+	if err := p.expectSymbol(":"); err != nil {
+		return RuleMatrix{}, err
+	}
+	for {
+		if err := p.parseClause(); err != nil {
+			return RuleMatrix{}, err
+		}
+		if !p.atSymbol(";") {
+			break
+		}
+		p.next()
+	}
+	link := RuleLink{Linked: false}
+	if p.atKeyword("link") {
+		p.next()
+		if link, err = p.parseLink(); err != nil {
+			return RuleMatrix{}, err
+		}
+	}
+	expiryTurn := 0
+	if p.atKeyword("expires") {
+		p.next()
+		if expiryTurn, err = p.parseInteger(); err != nil {
+			return RuleMatrix{}, err
+		}
+	}
+	if tok := p.peek(); tok.kind != dslEOF && !p.atKeyword("rule") && !p.atKeyword("immutable") {
+		return RuleMatrix{}, p.errorf(tok, "unexpected %v after rule '%v'", tok.describe(), name)
+	}
+
+	variables := p.variables
+	columns := map[variableColumn]int{}
+	for _, v := range variables {
+		for index := 0; index < p.widths[v]; index++ {
+			columns[variableColumn{variable: v, index: index}] = len(columns)
+		}
+	}
+	nCols := len(columns) + 1
+	values := make([]float64, 0, len(p.rows)*nCols)
+	for _, row := range p.rows {
+		rowValues := make([]float64, nCols)
+		for column, coefficient := range row.coefficients {
+			rowValues[columns[column]] = coefficient
+		}
+		rowValues[nCols-1] = row.constant
+		values = append(values, rowValues...)
+	}
+	return RuleMatrix{
+		RuleName:          name,
+		RequiredVariables: variables,
+		ApplicableMatrix:  *mat.NewDense(len(p.rows), nCols, values),
+		AuxiliaryVector:   *mat.NewVecDense(len(p.auxiliary), p.auxiliary),
+		Mutable:           mutable,
+		Link:              link,
+		ExpiryTurn:        uint(expiryTurn),
+	}, nil
+}
+
+// parseDeclarations parses the variables of an over clause and the number of values each of them holds
+func (p *ruleParser) parseDeclarations() error {
+	p.declared = true
+	for {
+		tok := p.next()
+		if tok.kind != dslIdentifier {
+			return p.errorf(tok, "expected a variable, found %v", tok.describe())
+		}
+		v, ok := lookupVariableFieldName(tok.text)
+		if !ok {
+			return unknownVariableError(tok)
+		}
+		if _, ok := p.widths[v]; ok {
+			return p.errorf(tok, "variable '%v' declared twice", v)
+		}
+		width := 1
+		if p.atSymbol("[") {
+			p.next()
+			var err error
+			if width, err = p.parseInteger(); err != nil {
+				return err
+			}
+			if err := p.expectSymbol("]"); err != nil {
+				return err
+			}
+		}
+		p.variables = append(p.variables, v)
+		p.widths[v] = width
+		if !p.atSymbol(",") {
+			return nil
+		}
+		p.next()
+	}
+}
+
+func (p *ruleParser) parseInteger() (int, error) {
+	tok := p.next()
+	if tok.kind != dslNumber || tok.number != float64(int(tok.number)) || tok.number < 0 {
+		return 0, p.errorf(tok, "expected a non-negative integer, found %v", tok.describe())
+	}
+	return int(tok.number), nil
+}
+
+// parseClause parses one constraint or output row
+func (p *ruleParser) parseClause() error {
+	if p.atKeyword("output") {
+		p.next()
+		expression, err := p.parseExpression()
+		if err != nil {
+			return err
+		}
+		p.rows = append(p.rows, expression)
+		p.auxiliary = append(p.auxiliary, 4)
+		return nil
+	}
+	lhs, err := p.parseExpression()
+	if err != nil {
+		return err
+	}
+	tok := p.next()
+	auxCodes := map[string]float64{"==": 0, ">": 1, ">=": 2, "!=": 3, "<": 1, "<=": 2}
+	aux, ok := auxCodes[tok.text]
+	if tok.kind != dslSymbol || !ok {
+		return p.errorf(tok, "expected a comparison (==, !=, >, >=, <, <=), found %v", tok.describe())
+	}
+	rhs, err := p.parseExpression()
+	if err != nil {
+		return err
+	}
+	row := newLinearExpression()
+	if tok.text == "<" || tok.text == "<=" {
+		row = row.add(rhs, 1).add(lhs, -1)
+	} else {
+		row = row.add(lhs, 1).add(rhs, -1)
+	}
+	p.rows = append(p.rows, row)
+	p.auxiliary = append(p.auxiliary, aux)
+	return nil
+}
+
+func newLinearExpression() linearExpression {
+	return linearExpression{coefficients: map[variableColumn]float64{}}
+}
+
+func (p *ruleParser) parseExpression() (linearExpression, error) {
+	expression := newLinearExpression()
+	sign := 1.0
+	if p.atSymbol("-") || p.atSymbol("+") {
+		if p.next().text == "-" {
+			sign = -1
+		}
+	}
+	for {
+		if err := p.parseTerm(&expression, sign); err != nil {
+			return linearExpression{}, err
+		}
+		if !p.atSymbol("+") && !p.atSymbol("-") {
+			return expression, nil
+		}
+		sign = 1
+		if p.next().text == "-" {
+			sign = -1
+		}
+	}
+}
+
+// parseTerm parses a number, a variable, or a product of the two, and adds it to expression
+func (p *ruleParser) parseTerm(expression *linearExpression, sign float64) error {
+	tok := p.peek()
+	switch {
+	case tok.kind == dslNumber:
+		p.next()
+		if !p.atSymbol("*") {
+			expression.constant += sign * tok.number
+			return nil
+		}
+		p.next()
+		column, err := p.parseVariable()
+		if err != nil {
+			return err
+		}
+		expression.coefficients[column] += sign * tok.number
+	case tok.kind == dslIdentifier && !isRuleKeyword(tok.text):
+		column, err := p.parseVariable()
+		if err != nil {
+			return err
+		}
+		coefficient := 1.0
+		if p.atSymbol("*") {
+			p.next()
+			number := p.next()
+			if number.kind != dslNumber {
+				return p.errorf(number, "expected a number, found %v", number.describe())
+			}
+			coefficient = number.number
+		}
+		expression.coefficients[column] += sign * coefficient
+	default:
+		return p.errorf(tok, "expected a number or variable, found %v", tok.describe())
+	}
+	return nil
+}
+
+// parseVariable parses a possibly indexed variable, registering it with the rule
+func (p *ruleParser) parseVariable() (variableColumn, error) {
+	tok := p.next()
+	if tok.kind != dslIdentifier {
+		return variableColumn{}, p.errorf(tok, "expected a variable, found %v", tok.describe())
+	}
+	v, ok := lookupVariableFieldName(tok.text)
+	if !ok {
+		return variableColumn{}, unknownVariableError(tok)
+	}
+	index := SingleValueVariableEntry
+	if p.atSymbol("[") {
+		p.next()
+		var err error
+		if index, err = p.parseInteger(); err != nil {
+			return variableColumn{}, err
+		}
+		if err := p.expectSymbol("]"); err != nil {
+			return variableColumn{}, err
+		}
+	}
+
+	width, seen := p.widths[v]
+	switch {
+	case p.declared && !seen:
+		return variableColumn{}, p.errorf(tok, "variable '%v' is not declared in the over clause", v)
+	case p.declared && index >= width:
+		return variableColumn{}, &RuleError{
+			Err:       errors.Errorf("%v: variable '%v' is declared with %v values, index %v is out of range", tok.position(), v, width, index),
+			ErrorType: VariableVectDimsDoNotMatchRuleMatrix,
+		}
+	case !seen:
+		p.variables = append(p.variables, v)
+		p.widths[v] = index + 1
+	case index >= width:
+		p.widths[v] = index + 1
+	}
+	return variableColumn{variable: v, index: index}, nil
+}
+
+func (p *ruleParser) parseLink() (RuleLink, error) {
+	tok := p.next()
+	for _, linkType := range linkTypes() {
+		if tok.kind == dslIdentifier && tok.text == linkType.String() {
+			children := []string{}
+			for {
+				child, err := p.parseRuleName()
+				if err != nil {
+					return RuleLink{}, err
+				}
+				children = append(children, child)
+				if !p.atSymbol(",") {
+					break
+				}
+				p.next()
+			}
+			link := RuleLink{Linked: true, LinkType: linkType, LinkedRule: children[0]}
+			if len(children) > 1 {
+				link.AdditionalLinkedRules = children[1:]
+			}
+			return link, nil
+		}
+	}
+	names := []string{}
+	for _, linkType := range linkTypes() {
+		names = append(names, linkType.String())
+	}
+	return RuleLink{}, p.errorf(tok, "expected a link type (one of %v), found %v", strings.Join(names, ", "), tok.describe())
+}
+
+// linkTypes returns the link types a rule can be linked with, i.e. all but NoLink
+func linkTypes() []LinkTypeOption {
+	ret := []LinkTypeOption{}
+	for linkType := ParentFailAutoRulePass; linkType < linkTypeOptionEnd; linkType++ {
+		if linkType != NoLink {
+			ret = append(ret, linkType)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,262 @@
+package rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestParseRule(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want RuleMatrix
+	}{
+		{
+			name: "example from the request",
+			text: "rule tax_paid: IslandTaxContribution - ExpectedTaxContribution >= 0",
+			want: compileRule(t, RawRuleSpecification{
+				Name:    "tax_paid",
+				ReqVar:  []VariableFieldName{IslandTaxContribution, ExpectedTaxContribution},
+				Values:  []float64{1, -1, 0},
+				Aux:     []float64{2},
+				Mutable: true,
+			}),
+		},
+		{
+			name: "less than swaps sides",
+			text: "immutable rule allocation: 2 * IslandAllocation + 1 < ExpectedAllocation * 0.5",
+			want: compileRule(t, RawRuleSpecification{
+				Name:   "allocation",
+				ReqVar: []VariableFieldName{IslandAllocation, ExpectedAllocation},
+				Values: []float64{-2, 0.5, -1},
+				Aux:    []float64{1},
+			}),
+		},
+		{
+			name: "several rows, outputs and links",
+			text: `rule "sanction rule" : # comments are ignored
+				TurnsLeftOnSanction != 0;
+				output -SanctionExpected + 3e2 - SanctionPaid + SanctionExpected * 3
+				link ParentFailAutoRulePass "other rule"`,
+			want: compileRule(t, RawRuleSpecification{
+				Name:       "sanction rule",
+				ReqVar:     []VariableFieldName{TurnsLeftOnSanction, SanctionExpected, SanctionPaid},
+				Values:     []float64{1, 0, 0, 0, 0, 2, -1, 300},
+				Aux:        []float64{3, 4},
+				Mutable:    true,
+				Linked:     true,
+				LinkType:   ParentFailAutoRulePass,
+				LinkedRule: "other rule",
+			}),
+		},
+		{
+			name: "several linked rules",
+			text: "rule r: TermEnded == 1 link XorLink a, \"b c\"",
+			want: compileRule(t, RawRuleSpecification{
+				Name:                  "r",
+				ReqVar:                []VariableFieldName{TermEnded},
+				Values:                []float64{1, -1},
+				Aux:                   []float64{0},
+				Mutable:               true,
+				Linked:                true,
+				LinkType:              XorLink,
+				LinkedRule:            "a",
+				AdditionalLinkedRules: []string{"b c"},
+			}),
+		},
+		{
+			name: "sunset clause",
+			text: "rule r: TermEnded == 1 expires 12",
+			want: RuleMatrix{
+				RuleName:          "r",
+				RequiredVariables: []VariableFieldName{TermEnded},
+				ApplicableMatrix:  *mat.NewDense(1, 2, []float64{1, -1}),
+				AuxiliaryVector:   *mat.NewVecDense(1, []float64{0}),
+				Mutable:           true,
+				Link:              RuleLink{Linked: false},
+				ExpiryTurn:        12,
+			},
+		},
+		{
+			name: "indexed and declared variables",
+			text: "rule alive over TermEnded, IslandsAlive[3]: IslandsAlive[2] - IslandsAlive == 0",
+			want: RuleMatrix{
+				RuleName:          "alive",
+				RequiredVariables: []VariableFieldName{TermEnded, IslandsAlive},
+				ApplicableMatrix:  *mat.NewDense(1, 5, []float64{0, -1, 0, 1, 0}),
+				AuxiliaryVector:   *mat.NewVecDense(1, []float64{0}),
+				Mutable:           true,
+				Link:              RuleLink{Linked: false},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRule(tc.text)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func compileRule(t *testing.T, spec RawRuleSpecification) RuleMatrix {
+	rule, ok := CompileRuleCase(spec)
+	if !ok {
+		t.Fatalf("invalid rule specification %v", spec)
+	}
+	return rule
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	cases := []struct {
+		name      string
+		text      string
+		errorType RuleErrorType
+		message   string
+	}{
+		{name: "unknown variable", text: "rule r: IslandTaxContributions >= 0", errorType: UnknownVariableFieldName, message: "line 1, column 9: unknown variable 'IslandTaxContributions'"},
+		{name: "unknown variable hint", text: "rule r: termended == 1", errorType: UnknownVariableFieldName, message: "did you mean 'TermEnded'?"},
+		{name: "index out of declared range", text: "rule r over IslandsAlive[2]: IslandsAlive[2] > 0", errorType: VariableVectDimsDoNotMatchRuleMatrix, message: "declared with 2 values"},
+		{name: "undeclared variable", text: "rule r over TermEnded: ElectionHeld > 0", errorType: RuleSyntaxError, message: "not declared"},
+		{name: "missing comparison", text: "rule r: TermEnded", errorType: RuleSyntaxError, message: "expected a comparison"},
+		{name: "missing colon", text: "rule r TermEnded > 0", errorType: RuleSyntaxError, message: "expected ':'"},
+		{name: "unknown link type", text: "rule r: TermEnded > 0 link Sometimes other", errorType: RuleSyntaxError, message: "expected a link type"},
+		{name: "NoLink link type", text: "rule r: TermEnded > 0 link NoLink other", errorType: RuleSyntaxError, message: "expected a link type"},
+		{name: "trailing tokens", text: "rule r: TermEnded > 0 0", errorType: RuleSyntaxError, message: "unexpected '0'"},
+		{name: "bad character", text: "rule r: TermEnded / 2 > 0", errorType: RuleSyntaxError, message: "unexpected character '/'"},
+		{name: "negative expiry turn", text: "rule r: TermEnded > 0 expires -1", errorType: RuleSyntaxError, message: "expected a non-negative integer"},
+		{name: "no rule", text: "# nothing here", errorType: RuleSyntaxError, message: "exactly one rule"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseRule(tc.text)
+			ruleErr, ok := err.(*RuleError)
+			if !ok {
+				t.Fatalf("expected a RuleError, got %v", err)
+			}
+			if ruleErr.Type() != tc.errorType || !strings.Contains(ruleErr.Error(), tc.message) {
+				t.Errorf("want %v containing %q, got %v: %v", tc.errorType, tc.message, ruleErr.Type(), ruleErr)
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	got, err := ParseRules(`
+		rule a: TermEnded == 1
+		immutable rule b: ElectionHeld > 0
+	`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].RuleName != "a" || !got[0].Mutable || got[1].RuleName != "b" || got[1].Mutable {
+		t.Errorf("unexpected rules %v", got)
+	}
+}
+
+func TestFormatRule(t *testing.T) {
+	rule := compileRule(t, RawRuleSpecification{
+		Name:                  "Kinda Complicated Rule",
+		ReqVar:                []VariableFieldName{NumberOfIslandsContributingToCommonPool, NumberOfFailedForages, NumberOfBrokenAgreements},
+		Values:                []float64{1, -1, 0, 0, 0, 0, 2.5, -3},
+		Aux:                   []float64{0, 4},
+		Linked:                true,
+		LinkType:              OrLink,
+		LinkedRule:            "rule",
+		AdditionalLinkedRules: []string{"other rule"},
+	})
+	want := `immutable rule "Kinda Complicated Rule":
+    NumberOfIslandsContributingToCommonPool - NumberOfFailedForages == 0;
+    output 2.5 * NumberOfBrokenAgreements - 3
+    link OrLink "rule", "other rule"`
+	got, err := FormatRule(rule)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("want\n%v\ngot\n%v", want, got)
+	}
+}
+
+func TestFormatRuleErrors(t *testing.T) {
+	twoVariables := []VariableFieldName{TermEnded, ElectionHeld}
+	cases := []struct {
+		name      string
+		rule      RuleMatrix
+		errorType RuleErrorType
+	}{
+		{
+			name:      "unknown variable",
+			rule:      RuleMatrix{RequiredVariables: []VariableFieldName{variableFieldNameEnd}, ApplicableMatrix: *mat.NewDense(1, 2, nil), AuxiliaryVector: *mat.NewVecDense(1, nil)},
+			errorType: UnknownVariableFieldName,
+		},
+		{
+			name:      "too few columns",
+			rule:      RuleMatrix{RequiredVariables: twoVariables, ApplicableMatrix: *mat.NewDense(1, 2, nil), AuxiliaryVector: *mat.NewVecDense(1, nil)},
+			errorType: VariableVectDimsDoNotMatchRuleMatrix,
+		},
+		{
+			name:      "aux vector too short",
+			rule:      RuleMatrix{RequiredVariables: twoVariables, ApplicableMatrix: *mat.NewDense(2, 3, nil), AuxiliaryVector: *mat.NewVecDense(1, nil)},
+			errorType: AuxVectorDimensionDontMatchRuleMatrix,
+		},
+		{
+			name:      "aux code out of range",
+			rule:      RuleMatrix{RequiredVariables: twoVariables, ApplicableMatrix: *mat.NewDense(1, 3, nil), AuxiliaryVector: *mat.NewVecDense(1, []float64{5})},
+			errorType: AuxVectorCodeOutOfRange,
+		},
+		{
+			name:      "empty rule",
+			rule:      RuleMatrix{},
+			errorType: VariableVectDimsDoNotMatchRuleMatrix,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := FormatRule(tc.rule)
+			if ruleErr, ok := err.(*RuleError); !ok || ruleErr.Type() != tc.errorType {
+				t.Errorf("want %v, got %v", tc.errorType, err)
+			}
+		})
+	}
+}
+
+// TestFormatRuleRoundTrip checks that every registered rule survives printing and parsing unchanged
+func TestFormatRuleRoundTrip(t *testing.T) {
+	avail, _ := InitialRuleRegistration(false)
+	multiValued := RuleMatrix{
+		RuleName:          "multi valued",
+		RequiredVariables: []VariableFieldName{IslandsAlive, TermEnded},
+		ApplicableMatrix:  *mat.NewDense(1, 5, []float64{0, 1, 0, 0, -1}),
+		AuxiliaryVector:   *mat.NewVecDense(1, []float64{2}),
+		Link:              RuleLink{Linked: false},
+	}
+	avail[multiValued.RuleName] = multiValued
+	expiring := avail["check_sanction_rule"]
+	expiring.RuleName, expiring.ExpiryTurn = "expiring", 7
+	avail[expiring.RuleName] = expiring
+	variables := map[VariableFieldName]VariableValuePair{IslandsAlive: MakeVariableValuePair(IslandsAlive, []float64{0, 1, 2})}
+
+	for name, rule := range avail {
+		text, err := FormatRuleWithVariables(rule, variables)
+		if err != nil {
+			t.Errorf("%v: unexpected error formatting: %v", name, err)
+			continue
+		}
+		got, err := ParseRule(text)
+		if err != nil {
+			t.Errorf("%v: unexpected error parsing %q: %v", name, text, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, rule) {
+			t.Errorf("%v: round trip through %q changed\n%v\ninto\n%v", name, text, rule, got)
+		}
+	}
+}
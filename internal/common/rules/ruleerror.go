@@ -23,6 +23,10 @@ const (
 	VariableVectDimsDoNotMatchRuleMatrix
 	AuxVectorCodeOutOfRange
 	ChildRuleNotFound
+	RuleSyntaxError
+	UnknownVariableFieldName
+	RuleLinkCycle
+	ComplianceInfeasible
 )
 
 func (r RuleErrorType) String() string {
@@ -38,6 +42,10 @@ func (r RuleErrorType) String() string {
 		"VariableVectDimsDoNotMatchRuleMatrix",
 		"AuxVectorCOdeOutOfRange",
 		"ChildRuleNotFound",
+		"RuleSyntaxError",
+		"UnknownVariableFieldName",
+		"RuleLinkCycle",
+		"ComplianceInfeasible",
 	}
 
 	if r >= 0 && int(r) < len(strs) {
@@ -0,0 +1,212 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RuleExplanation explains the result of evaluating a rule: which of its rows do not hold, with the values involved
+// and the change needed to comply
+type RuleExplanation struct {
+	RuleName   string
+	Passes     bool
+	FailedRows []RowExplanation // rows of the rule's own matrix that do not hold
+	Link       RuleLink
+	Children   []RuleExplanation // explanations of the rules linked to the rule, if any
+}
+
+// RowExplanation explains why one row of a rule matrix does not hold. The row holds if its left-hand side, the
+// weighted sum of the variable values plus the constant column, compares to 0 as required.
+type RowExplanation struct {
+	Row          int
+	Constraint   string // the row in the rule language
+	Terms        []RowTerm
+	LeftHandSide float64
+	Comparison   string // "== 0", "> 0", ">= 0" or "!= 0"
+	// MinimumChange is the smallest change of the left-hand side needed to comply. For "> 0" the change must exceed
+	// it, and for "!= 0" (where it is 0) any non-zero change complies.
+	MinimumChange float64
+}
+
+// RowTerm is a variable value a row of a rule matrix depends on
+type RowTerm struct {
+	Variable    VariableFieldName
+	Name        string // the value in the rule language, e.g. "IslandTaxContribution" or "IslandAllocation[1]"
+	Value       float64
+	Coefficient float64
+}
+
+var comparisonsByAuxCode = map[float64]string{0: "== 0", 1: "> 0", 2: ">= 0", 3: "!= 0"}
+
+// ExplainRuleFromCaches evaluates a rule like EvaluateRuleFromCaches and explains the result
+func ExplainRuleFromCaches(ruleName string, rulesCache map[string]RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) (RuleEvaluationReturn, RuleExplanation) {
+	ret := EvaluateRuleFromCaches(ruleName, rulesCache, variableCache)
+	if ret.EvalError != nil {
+		return ret, RuleExplanation{RuleName: ruleName}
+	}
+	explanation, err := explainRule(rulesCache[ruleName], rulesCache, variableCache, map[string]bool{})
+	if err != nil {
+		ret.EvalError = err
+	}
+	return ret, explanation
+}
+
+// ExplainComplianceCheck is ComplianceCheck in explain mode: it also explains which rows of the rule, or of the rules
+// it is linked to, the variables do not comply with
+func ExplainComplianceCheck(rule RuleMatrix, variables map[VariableFieldName]VariableValuePair, inPlayRules map[string]RuleMatrix) (RuleExplanation, error) {
+	if !checkAllVariablesAvailable(rule.RequiredVariables, variables) {
+		_, err := ComplianceCheck(rule, variables, inPlayRules)
+		return RuleExplanation{RuleName: rule.RuleName}, err
+	}
+	ruleCache := map[string]RuleMatrix{}
+	for name, inPlayRule := range inPlayRules {
+		ruleCache[name] = inPlayRule
+	}
+	ruleCache[rule.RuleName] = rule
+	ret, explanation := ExplainRuleFromCaches(rule.RuleName, ruleCache, variables)
+	return explanation, ret.EvalError
+}
+
+func explainRule(rule RuleMatrix, rulesCache map[string]RuleMatrix, variableCache map[VariableFieldName]VariableValuePair, visiting map[string]bool) (RuleExplanation, error) {
+	passes, err := evaluateLinkedRule(rule, rulesCache, variableCache, map[string]bool{})
+	if err != nil {
+		return RuleExplanation{}, err
+	}
+	explanation := RuleExplanation{RuleName: rule.RuleName, Passes: passes, Link: rule.Link}
+	if passes {
+		return explanation, nil
+	}
+	explanation.FailedRows, err = explainRows(rule, variableCache)
+	if err != nil || !rule.Link.Linked {
+		return explanation, err
+	}
+	if visiting[rule.RuleName] {
+		return explanation, &RuleError{
+			ErrorType: RuleLinkCycle,
+			Err:       errors.Errorf("Rule '%v' is linked to itself", rule.RuleName),
+		}
+	}
+	visiting[rule.RuleName] = true
+	defer delete(visiting, rule.RuleName)
+	for _, childName := range rule.Link.Children() {
+		child, err := explainRule(rulesCache[childName], rulesCache, variableCache, visiting)
+		if err != nil {
+			return explanation, err
+		}
+		explanation.Children = append(explanation.Children, child)
+	}
+	return explanation, nil
+}
+
+// explainRows explains the rows of a rule's own matrix that do not hold
+func explainRows(rule RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) ([]RowExplanation, error) {
+	widths, err := ruleColumnWidths(rule, variableCache)
+	if err != nil {
+		return nil, err
+	}
+	variableVect, err := createVarList(rule.RequiredVariables, variableCache)
+	if err != nil {
+		return nil, err
+	}
+	var terms []RowTerm
+	var columnNames []string
+	for i, v := range rule.RequiredVariables {
+		for j := 0; j < widths[i]; j++ {
+			name := v.String()
+			if widths[i] > 1 {
+				name = fmt.Sprintf("%v[%v]", v, j)
+			}
+			terms = append(terms, RowTerm{Variable: v, Name: name, Value: variableVect[len(terms)]})
+			columnNames = append(columnNames, name)
+		}
+	}
+
+	results := ruleMul(variableVect, rule.ApplicableMatrix)
+	var rows []RowExplanation
+	for i := 0; i < results.Len(); i++ {
+		aux := rule.AuxiliaryVector.AtVec(i)
+		comparison, isConstraint := comparisonsByAuxCode[aux]
+		lhs := results.AtVec(i)
+		if !isConstraint || satisfy(lhs, aux) {
+			continue
+		}
+		row := rule.ApplicableMatrix.RawRowView(i)
+		constraint, err := formatRow(row, aux, columnNames)
+		if err != nil {
+			return nil, err
+		}
+		explanation := RowExplanation{Row: i, Constraint: constraint, LeftHandSide: lhs, Comparison: comparison, MinimumChange: -lhs}
+		for j, term := range terms {
+			if row[j] != 0 {
+				term.Coefficient = row[j]
+				explanation.Terms = append(explanation.Terms, term)
+			}
+		}
+		rows = append(rows, explanation)
+	}
+	return rows, nil
+}
+
+// String describes a rule evaluation in a few lines, starting with the rule's result
+func (e RuleExplanation) String() string {
+	return strings.Join(e.lines(""), "\n")
+}
+
+func (e RuleExplanation) lines(indent string) []string {
+	result := "passes"
+	if !e.Passes {
+		result = "fails"
+	}
+	header := fmt.Sprintf("%vrule '%v' %v", indent, e.RuleName, result)
+	if e.Link.Linked {
+		header += fmt.Sprintf(" (%v with %v)", e.Link.LinkType, strings.Join(e.Link.Children(), ", "))
+	}
+	lines := []string{header}
+	for _, row := range e.FailedRows {
+		lines = append(lines, indent+"  "+row.String())
+	}
+	for _, child := range e.Children {
+		lines = append(lines, child.lines(indent+"  ")...)
+	}
+	return lines
+}
+
+// String describes why the row does not hold and how it can be made to
+func (e RowExplanation) String() string {
+	values := make([]string, len(e.Terms))
+	for i, term := range e.Terms {
+		values[i] = fmt.Sprintf("%v = %v", term.Name, formatNumber(term.Value))
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("row %v, %v, does not hold", e.Row, e.Constraint))
+	if len(values) > 0 {
+		sb.WriteString(" with " + strings.Join(values, ", "))
+	}
+	sb.WriteString(fmt.Sprintf(": the difference between its sides is %v but must be %v", formatNumber(e.LeftHandSide), e.Comparison))
+
+	qualifiers := map[string]string{"== 0": "exactly", "> 0": "more than", ">= 0": "at least"}
+	qualifier, bounded := qualifiers[e.Comparison]
+	if !bounded {
+		return sb.String() + ", so it needs to change by any amount"
+	}
+	sb.WriteString(fmt.Sprintf(", so it needs to %v by %v %v", changeVerb(e.MinimumChange, "increase", "decrease"), qualifier, formatNumber(abs(e.MinimumChange))))
+
+	changeable := IsChangeable()
+	for _, term := range e.Terms {
+		if changeable[term.Variable] {
+			valueChange := e.MinimumChange / term.Coefficient
+			sb.WriteString(fmt.Sprintf(" (e.g. by %v %v by %v %v)", changeVerb(valueChange, "raising", "lowering"), term.Name, qualifier, formatNumber(abs(valueChange))))
+			break
+		}
+	}
+	return sb.String()
+}
+
+func changeVerb(change float64, up string, down string) string {
+	if change < 0 {
+		return down
+	}
+	return up
+}
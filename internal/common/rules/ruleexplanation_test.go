@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplainRuleFromCaches(t *testing.T) {
+	ruleSet := parseRuleSet(t, `
+		rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0
+		rule several: IslandTaxContribution == 2; TermEnded > 0; 2 * TermEnded - IslandTaxContribution <= 1
+		rule real_valued: output 2 * IslandTaxContribution
+		rule parent: IslandTaxContribution > 4 link AndLink tax, several`)
+	variables := map[VariableFieldName]VariableValuePair{
+		IslandTaxContribution:   MakeVariableValuePair(IslandTaxContribution, []float64{3}),
+		ExpectedTaxContribution: MakeVariableValuePair(ExpectedTaxContribution, []float64{5}),
+		TermEnded:               MakeVariableValuePair(TermEnded, []float64{0}),
+	}
+	taxTerms := []RowTerm{
+		{Variable: IslandTaxContribution, Name: "IslandTaxContribution", Value: 3, Coefficient: 1},
+		{Variable: ExpectedTaxContribution, Name: "ExpectedTaxContribution", Value: 5, Coefficient: -1},
+	}
+	taxExplanation := RuleExplanation{
+		RuleName: "tax",
+		FailedRows: []RowExplanation{
+			{Row: 0, Constraint: "IslandTaxContribution - ExpectedTaxContribution >= 0", Terms: taxTerms, LeftHandSide: -2, Comparison: ">= 0", MinimumChange: 2},
+		},
+	}
+	cases := []struct {
+		name string
+		rule string
+		want RuleExplanation
+	}{
+		{name: "failing row", rule: "tax", want: taxExplanation},
+		{
+			name: "several failing rows",
+			rule: "several",
+			want: RuleExplanation{
+				RuleName: "several",
+				FailedRows: []RowExplanation{
+					{
+						Row:          0,
+						Constraint:   "IslandTaxContribution == 2",
+						Terms:        []RowTerm{{Variable: IslandTaxContribution, Name: "IslandTaxContribution", Value: 3, Coefficient: 1}},
+						LeftHandSide: 1, Comparison: "== 0", MinimumChange: -1,
+					},
+					{
+						Row:          1,
+						Constraint:   "TermEnded > 0",
+						Terms:        []RowTerm{{Variable: TermEnded, Name: "TermEnded", Value: 0, Coefficient: 1}},
+						LeftHandSide: 0, Comparison: "> 0", MinimumChange: 0,
+					},
+				},
+			},
+		},
+		{name: "real valued rule", rule: "real_valued", want: RuleExplanation{RuleName: "real_valued", Passes: true}},
+		{
+			name: "linked rule",
+			rule: "parent",
+			want: RuleExplanation{
+				RuleName: "parent",
+				Link:     ruleSet["parent"].Link,
+				FailedRows: []RowExplanation{
+					{
+						Row:          0,
+						Constraint:   "IslandTaxContribution > 4",
+						Terms:        []RowTerm{{Variable: IslandTaxContribution, Name: "IslandTaxContribution", Value: 3, Coefficient: 1}},
+						LeftHandSide: -1, Comparison: "> 0", MinimumChange: 1,
+					},
+				},
+				Children: []RuleExplanation{taxExplanation, {RuleName: "several", FailedRows: []RowExplanation{
+					{
+						Row:          0,
+						Constraint:   "IslandTaxContribution == 2",
+						Terms:        []RowTerm{{Variable: IslandTaxContribution, Name: "IslandTaxContribution", Value: 3, Coefficient: 1}},
+						LeftHandSide: 1, Comparison: "== 0", MinimumChange: -1,
+					},
+					{
+						Row:          1,
+						Constraint:   "TermEnded > 0",
+						Terms:        []RowTerm{{Variable: TermEnded, Name: "TermEnded", Value: 0, Coefficient: 1}},
+						LeftHandSide: 0, Comparison: "> 0", MinimumChange: 0,
+					},
+				}}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ret, got := ExplainRuleFromCaches(tc.rule, ruleSet, variables)
+			if ret.EvalError != nil {
+				t.Fatalf("unexpected error: %v", ret.EvalError)
+			}
+			if ret.RulePasses != tc.want.Passes {
+				t.Errorf("evaluation returned %v but the explanation expected %v", ret.RulePasses, tc.want.Passes)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRuleExplanationString(t *testing.T) {
+	ruleSet := parseRuleSet(t, `
+		rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0
+		rule fixed: ExpectedTaxContribution == 2
+		rule not_zero: TermEnded != 0
+		rule allocation over IslandAllocation[2]: IslandAllocation[1] - 2 * IslandAllocation[0] > 0
+		rule parent: TermEnded > 0 link XorLink tax, fixed`)
+	variables := map[VariableFieldName]VariableValuePair{
+		IslandTaxContribution:   MakeVariableValuePair(IslandTaxContribution, []float64{3}),
+		ExpectedTaxContribution: MakeVariableValuePair(ExpectedTaxContribution, []float64{5}),
+		IslandAllocation:        MakeVariableValuePair(IslandAllocation, []float64{4, 1}),
+		TermEnded:               MakeVariableValuePair(TermEnded, []float64{0}),
+	}
+	cases := []struct {
+		rule string
+		want string
+	}{
+		{
+			rule: "tax",
+			want: "rule 'tax' fails\n" +
+				"  row 0, IslandTaxContribution - ExpectedTaxContribution >= 0, does not hold with IslandTaxContribution = 3, ExpectedTaxContribution = 5: " +
+				"the difference between its sides is -2 but must be >= 0, so it needs to increase by at least 2 (e.g. by raising IslandTaxContribution by at least 2)",
+		},
+		{
+			rule: "fixed",
+			want: "rule 'fixed' fails\n" +
+				"  row 0, ExpectedTaxContribution == 2, does not hold with ExpectedTaxContribution = 5: " +
+				"the difference between its sides is 3 but must be == 0, so it needs to decrease by exactly 3",
+		},
+		{
+			rule: "not_zero",
+			want: "rule 'not_zero' fails\n" +
+				"  row 0, TermEnded != 0, does not hold with TermEnded = 0: the difference between its sides is 0 but must be != 0, so it needs to change by any amount",
+		},
+		{
+			rule: "allocation",
+			want: "rule 'allocation' fails\n" +
+				"  row 0, -2 * IslandAllocation[0] + IslandAllocation[1] > 0, does not hold with IslandAllocation[0] = 4, IslandAllocation[1] = 1: " +
+				"the difference between its sides is -7 but must be > 0, so it needs to increase by more than 7 (e.g. by lowering IslandAllocation[0] by more than 3.5)",
+		},
+		{
+			rule: "parent",
+			want: "rule 'parent' fails (XorLink with tax, fixed)\n" +
+				"  row 0, TermEnded > 0, does not hold with TermEnded = 0: the difference between its sides is 0 but must be > 0, so it needs to increase by more than 0\n" +
+				"  rule 'tax' fails\n" +
+				"    row 0, IslandTaxContribution - ExpectedTaxContribution >= 0, does not hold with IslandTaxContribution = 3, ExpectedTaxContribution = 5: " +
+				"the difference between its sides is -2 but must be >= 0, so it needs to increase by at least 2 (e.g. by raising IslandTaxContribution by at least 2)\n" +
+				"  rule 'fixed' fails\n" +
+				"    row 0, ExpectedTaxContribution == 2, does not hold with ExpectedTaxContribution = 5: " +
+				"the difference between its sides is 3 but must be == 0, so it needs to decrease by exactly 3",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.rule, func(t *testing.T) {
+			_, explanation := ExplainRuleFromCaches(tc.rule, ruleSet, variables)
+			if got := explanation.String(); got != tc.want {
+				t.Errorf("want\n%v\ngot\n%v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExplainComplianceCheck(t *testing.T) {
+	inPlay := parseRuleSet(t, "rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0")
+	rule, err := ParseRule("rule candidate: ExpectedTaxContribution > 1 link OrLink tax")
+	if err != nil {
+		t.Fatalf("invalid rule: %v", err)
+	}
+	variables := map[VariableFieldName]VariableValuePair{
+		IslandTaxContribution:   MakeVariableValuePair(IslandTaxContribution, []float64{7}),
+		ExpectedTaxContribution: MakeVariableValuePair(ExpectedTaxContribution, []float64{1}),
+	}
+	got, err := ExplainComplianceCheck(rule, variables, inPlay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Passes || len(got.FailedRows) != 0 {
+		t.Errorf("expected the rule to pass through its link, got %v", got)
+	}
+
+	variables[IslandTaxContribution] = MakeVariableValuePair(IslandTaxContribution, []float64{0})
+	got, err = ExplainComplianceCheck(rule, variables, inPlay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if compliant, _ := ComplianceCheck(rule, variables, inPlay); got.Passes != compliant {
+		t.Errorf("explanation returned %v but ComplianceCheck returned %v", got.Passes, compliant)
+	}
+	if len(got.FailedRows) != 1 || len(got.Children) != 1 || len(got.Children[0].FailedRows) != 1 {
+		t.Errorf("expected a failing row in the rule and in its child, got %+v", got)
+	}
+
+	delete(variables, ExpectedTaxContribution)
+	_, err = ExplainComplianceCheck(rule, variables, inPlay)
+	if ruleErr, ok := err.(*RuleError); !ok || ruleErr.Type() != VariableCacheDidNotHaveAllRequiredVariables {
+		t.Errorf("expected a missing variable error, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"fmt"
+
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -9,18 +11,56 @@ const SingleValueVariableEntry = 0
 // LinkTypeOption gives an enumerated type for the various link types available for rules
 type LinkTypeOption int
 
+// A linked rule passes depending on whether its own matrix (the parent) and each of its linked rules (the children)
+// pass. Children may be linked rules themselves.
 const (
 	// ParentFailAutoRulePass allows for NOT(Parent Passes) || Parent and Child pass
 	// Useful for cases where if a condition isn't met we don't want to evaluate a rule
+	// With several children, the parent implies that all of them pass
 	ParentFailAutoRulePass LinkTypeOption = iota
 	NoLink
+	// AndLink passes if the parent and all children pass
+	AndLink
+	// OrLink passes if the parent or any child passes
+	OrLink
+	// XorLink passes if exactly one of the parent and the children passes
+	XorLink
+
+	// DO NOT TOUCH THIS
+	linkTypeOptionEnd
 )
 
+func (l LinkTypeOption) String() string {
+	strs := [...]string{"ParentFailAutoRulePass", "NoLink", "AndLink", "OrLink", "XorLink"}
+	if l >= 0 && int(l) < len(strs) {
+		return strs[l]
+	}
+	return fmt.Sprintf("UNKNOWN LinkTypeOption '%v'", int(l))
+}
+
+// GoString implements GoStringer
+func (l LinkTypeOption) GoString() string {
+	return l.String()
+}
+
 // RuleLink provides a containerised package for all linked rules
 type RuleLink struct {
-	Linked     bool
-	LinkType   LinkTypeOption
-	LinkedRule string
+	Linked                bool
+	LinkType              LinkTypeOption
+	LinkedRule            string
+	AdditionalLinkedRules []string // children after LinkedRule
+}
+
+// Children returns the names of all rules linked to, in order
+func (l RuleLink) Children() []string {
+	if !l.Linked {
+		return nil
+	}
+	return append([]string{l.LinkedRule}, l.AdditionalLinkedRules...)
+}
+
+func (l RuleLink) isEmpty() bool {
+	return !l.Linked && l.LinkType == ParentFailAutoRulePass && l.LinkedRule == "" && len(l.AdditionalLinkedRules) == 0
 }
 
 // RuleMatrix provides a container for our matrix based rules
@@ -31,6 +71,7 @@ type RuleMatrix struct {
 	AuxiliaryVector   mat.VecDense
 	Mutable           bool
 	Link              RuleLink
+	ExpiryTurn        uint // turn at which the rule is taken out of play (sunset clause). 0 if the rule never expires
 }
 
 // RuleMatrixIsEmpty returns true is the RuleMatrix is uninitialised
@@ -38,7 +79,8 @@ func (r *RuleMatrix) RuleMatrixIsEmpty() bool {
 	if r.RuleName == "" &&
 		len(r.RequiredVariables) == 0 &&
 		!r.Mutable &&
-		r.Link == (RuleLink{}) {
+		r.Link.isEmpty() &&
+		r.ExpiryTurn == 0 {
 		// if r.ApplicableMatrix != nil && r.AuxiliaryVector != nil {
 		r1, c1 := r.ApplicableMatrix.Dims()
 		r2, c2 := r.AuxiliaryVector.Dims()
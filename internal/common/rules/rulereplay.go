@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// HistoricalReport is the set of variables an island reported in one turn, as recorded in the IIGO history
+type HistoricalReport struct {
+	Turn     uint
+	ClientID int
+	Pairs    []VariableValuePair
+}
+
+// RuleBreach records that an island would have broken a rule in a turn
+type RuleBreach struct {
+	Turn     uint
+	ClientID int
+}
+
+// ReplayRule evaluates a candidate rule against historical reports the way the judiciary inspects the history: each
+// report that includes a variable required by the candidate is evaluated with the variables reported so far in its
+// turn, on top of variableCache. Children of a linked candidate are looked up in rulesCache. Returns the breaches in
+// order of turn, then of report.
+func ReplayRule(candidate RuleMatrix, rulesCache map[string]RuleMatrix, history []HistoricalReport, variableCache map[VariableFieldName]VariableValuePair) ([]RuleBreach, error) {
+	ruleCache := map[string]RuleMatrix{}
+	for name, rule := range rulesCache {
+		ruleCache[name] = rule
+	}
+	ruleCache[candidate.RuleName] = candidate
+
+	reports := make([]HistoricalReport, len(history))
+	copy(reports, history)
+	sort.SliceStable(reports, func(i, j int) bool { return reports[i].Turn < reports[j].Turn })
+
+	var breaches []RuleBreach
+	var turnVariables map[VariableFieldName]VariableValuePair
+	for i, report := range reports {
+		if i == 0 || report.Turn != reports[i-1].Turn {
+			turnVariables = CopyVariableMap(variableCache)
+		}
+		affected := false
+		for _, pair := range report.Pairs {
+			turnVariables[pair.VariableName] = pair
+			if _, found := searchForVariableInArray(pair.VariableName, candidate.RequiredVariables); found {
+				affected = true
+			}
+		}
+		if !affected {
+			continue
+		}
+		ret := EvaluateRuleFromCaches(candidate.RuleName, ruleCache, turnVariables)
+		if ret.EvalError != nil {
+			return nil, errors.Errorf("Rule '%v' could not be evaluated for island %v on turn %v: %v", candidate.RuleName, report.ClientID, report.Turn, ret.EvalError)
+		}
+		if !ret.RulePasses {
+			breaches = append(breaches, RuleBreach{Turn: report.Turn, ClientID: report.ClientID})
+		}
+	}
+	return breaches, nil
+}
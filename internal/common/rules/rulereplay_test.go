@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReplayRule(t *testing.T) {
+	report := func(turn uint, clientID int, tax float64, expected float64) HistoricalReport {
+		return HistoricalReport{Turn: turn, ClientID: clientID, Pairs: []VariableValuePair{
+			MakeVariableValuePair(IslandTaxContribution, []float64{tax}),
+			MakeVariableValuePair(ExpectedTaxContribution, []float64{expected}),
+		}}
+	}
+	history := []HistoricalReport{
+		report(2, 1, 5, 10),
+		report(1, 0, 10, 10),
+		report(1, 1, 3, 10),
+		{Turn: 1, ClientID: 2, Pairs: []VariableValuePair{MakeVariableValuePair(TermEnded, []float64{1})}},
+		report(2, 0, 12, 10),
+	}
+	cases := []struct {
+		name      string
+		candidate string
+		want      []RuleBreach
+	}{
+		{name: "breaches in order of turn", candidate: "rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0", want: []RuleBreach{{Turn: 1, ClientID: 1}, {Turn: 2, ClientID: 1}}},
+		{name: "rule never broken", candidate: "rule low: IslandTaxContribution <= 20", want: nil},
+		{name: "reports without the rule's variables are skipped", candidate: "rule turn: TermEnded == 0", want: []RuleBreach{{Turn: 1, ClientID: 2}}},
+		{name: "linked to a registered rule", candidate: "rule tax: IslandTaxContribution > 4 link AndLink check_low", want: []RuleBreach{{Turn: 1, ClientID: 1}, {Turn: 2, ClientID: 0}}},
+	}
+	rulesCache := parseRuleSet(t, "rule check_low: IslandTaxContribution <= 10")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			candidate, err := ParseRule(tc.candidate)
+			if err != nil {
+				t.Fatalf("invalid rule: %v", err)
+			}
+			got, err := ReplayRule(candidate, rulesCache, history, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	candidate, _ := ParseRule("rule sanction: IslandTaxContribution - SanctionExpected >= 0")
+	if _, err := ReplayRule(candidate, rulesCache, history, nil); err == nil {
+		t.Errorf("expected an error replaying a rule over variables that were never reported")
+	}
+}
@@ -1,5 +1,7 @@
 package rules
 
+import "github.com/pkg/errors"
+
 // PickUpRulesByVariable returns a list of rule_id's which are affected by certain variables.
 func PickUpRulesByVariable(variableName VariableFieldName, ruleStore map[string]RuleMatrix, variableMap map[VariableFieldName]VariableValuePair) ([]string, bool) {
 	var Rules []string
@@ -41,3 +43,29 @@ func MakeVariableValuePair(variable VariableFieldName, value []float64) Variable
 		Values:       value,
 	}
 }
+
+// ruleColumnWidths finds the number of matrix columns taken by each variable of a rule, i.e. the number of values the
+// variable holds. These are taken from variableCache. Variables missing from it hold a single value, unless the rule
+// has a lone variable, which takes all the columns but the constant.
+func ruleColumnWidths(rule RuleMatrix, variableCache map[VariableFieldName]VariableValuePair) ([]int, error) {
+	nRows, nCols := rule.ApplicableMatrix.Dims()
+	widths := make([]int, len(rule.RequiredVariables))
+	totalWidth := 0
+	for i, v := range rule.RequiredVariables {
+		widths[i] = 1
+		if pair, ok := variableCache[v]; ok {
+			widths[i] = len(pair.Values)
+		} else if len(widths) == 1 && nCols > 1 {
+			widths[i] = nCols - 1
+		}
+		totalWidth += widths[i]
+	}
+	if nRows == 0 || totalWidth+1 != nCols {
+		return nil, &RuleError{
+			Err: errors.Errorf("Rule '%v' has a %vx%v matrix, but its variables %v need %v columns (one per value plus a constant)",
+				rule.RuleName, nRows, nCols, rule.RequiredVariables, totalWidth+1),
+			ErrorType: VariableVectDimsDoNotMatchRuleMatrix,
+		}
+	}
+	return widths, nil
+}
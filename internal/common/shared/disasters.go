@@ -2,6 +2,8 @@ package shared
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
 	"github.com/pkg/errors"
@@ -67,3 +69,324 @@ func HelpSpatialPDFType() string {
 
 	return help
 }
+
+// AttenuationType is an enum for the function mapping distance from a disaster's epicentre to the
+// magnitude felt by an island
+type AttenuationType int
+
+const (
+	// InverseDistance attenuation: effect = magnitude / distance, capped at magnitude (legacy behaviour)
+	InverseDistance AttenuationType = iota
+	// InverseSquare attenuation: effect = magnitude / distance^2, capped at magnitude
+	InverseSquare
+	// ExponentialDecay attenuation: effect = magnitude * exp(-distance / scale)
+	ExponentialDecay
+	// Gaussian attenuation: effect = magnitude * exp(-distance^2 / (2 * scale^2))
+	Gaussian
+	// HardCutoff attenuation: effect = magnitude within a radius of scale from the epicentre, zero outside
+	HardCutoff
+
+	// DO NOT TOUCH THIS
+	attenuationTypeEnd
+)
+
+func (a AttenuationType) String() string {
+	strings := [...]string{"InverseDistance", "InverseSquare", "ExponentialDecay", "Gaussian", "HardCutoff"}
+	if a >= 0 && int(a) < len(strings) {
+		return strings[a]
+	}
+	return fmt.Sprintf("UNKNOWN AttenuationType '%v'", int(a))
+}
+
+// GoString implements GoStringer
+func (a AttenuationType) GoString() string {
+	return a.String()
+}
+
+// MarshalText implements TextMarshaler
+func (a AttenuationType) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(a.String())
+}
+
+// MarshalJSON implements RawMessage
+func (a AttenuationType) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(a.String())
+}
+
+// ParseAttenuationType gets the AttenuationType based on the number
+func ParseAttenuationType(x int) (AttenuationType, error) {
+	if x >= 0 && AttenuationType(x) < attenuationTypeEnd {
+		return AttenuationType(x), nil
+	}
+	return InverseDistance, errors.Errorf("Unknown AttenuationType specified: '%v'.", x)
+}
+
+// HelpAttenuationType returns a help string for AttenuationType
+func HelpAttenuationType() string {
+	help := "Set function mapping distance from a disaster's epicentre to the magnitude felt by an island\n"
+
+	for i := 0; i < int(attenuationTypeEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, AttenuationType(i))
+	}
+
+	return help
+}
+
+// IslandLayout is an enum for the arrangement of islands within the bounds of the archipelago
+type IslandLayout int
+
+const (
+	// LineLayout places islands on equidistant points from XMin to XMax along the line y = YMin (the x axis with
+	// the default bounds)
+	LineLayout IslandLayout = iota
+	// CircleLayout places islands on equidistant points of the largest circle inscribed in the bounds
+	CircleLayout
+	// ScatterLayout places islands uniformly at random in the bounds, subject to a minimum separation
+	ScatterLayout
+	// GridLayout places islands in the centres of the cells of a regular grid spanning the bounds
+	GridLayout
+	// ExplicitLayout places islands at co-ordinates specified in config
+	ExplicitLayout
+
+	// DO NOT TOUCH THIS
+	islandLayoutEnd
+)
+
+func (l IslandLayout) String() string {
+	strings := [...]string{"LineLayout", "CircleLayout", "ScatterLayout", "GridLayout", "ExplicitLayout"}
+	if l >= 0 && int(l) < len(strings) {
+		return strings[l]
+	}
+	return fmt.Sprintf("UNKNOWN IslandLayout '%v'", int(l))
+}
+
+// GoString implements GoStringer
+func (l IslandLayout) GoString() string {
+	return l.String()
+}
+
+// MarshalText implements TextMarshaler
+func (l IslandLayout) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(l.String())
+}
+
+// MarshalJSON implements RawMessage
+func (l IslandLayout) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(l.String())
+}
+
+// ParseIslandLayout gets the IslandLayout based on the number
+func ParseIslandLayout(x int) (IslandLayout, error) {
+	if x >= 0 && IslandLayout(x) < islandLayoutEnd {
+		return IslandLayout(x), nil
+	}
+	return LineLayout, errors.Errorf("Unknown IslandLayout specified: '%v'.", x)
+}
+
+// HelpIslandLayout returns a help string for IslandLayout
+func HelpIslandLayout() string {
+	help := "Set arrangement of islands within the bounds of the archipelago\n"
+
+	for i := 0; i < int(islandLayoutEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, IslandLayout(i))
+	}
+
+	return help
+}
+
+// Position is a point on the map
+type Position struct {
+	X, Y Coordinate
+}
+
+// ParseIslandPositions parses island co-ordinates of the form `x1:y1;x2:y2;...`, where the i-th pair
+// is the position of TeamIDs[i]. An empty string yields no positions.
+func ParseIslandPositions(s string) (map[ClientID]Position, error) {
+	positions := map[ClientID]Position{}
+	if strings.TrimSpace(s) == "" {
+		return positions, nil
+	}
+	pairs := strings.Split(s, ";")
+	if len(pairs) > len(TeamIDs) {
+		return nil, errors.Errorf("Got %v island positions but there are only %v islands.", len(pairs), len(TeamIDs))
+	}
+	for i, pair := range pairs {
+		xy := strings.Split(pair, ":")
+		if len(xy) != 2 {
+			return nil, errors.Errorf("Invalid island position '%v'. Expected 'x:y'.", pair)
+		}
+		x, err := strconv.ParseFloat(strings.TrimSpace(xy[0]), 64)
+		if err != nil {
+			return nil, errors.Errorf("Invalid x co-ordinate in island position '%v': %v", pair, err)
+		}
+		y, err := strconv.ParseFloat(strings.TrimSpace(xy[1]), 64)
+		if err != nil {
+			return nil, errors.Errorf("Invalid y co-ordinate in island position '%v': %v", pair, err)
+		}
+		positions[TeamIDs[i]] = Position{X: x, Y: y}
+	}
+	return positions, nil
+}
+
+// MitigationCurveType is an enum for the shape of the curve mapping the common pool level to the multiplier
+// applied to disaster damage
+type MitigationCurveType int
+
+const (
+	// ThresholdMitigation halves damage if the common pool is at or above CommonpoolThreshold (legacy behaviour)
+	ThresholdMitigation MitigationCurveType = iota
+	// PiecewiseLinearMitigation linearly interpolates the damage multiplier between (level, multiplier) points
+	PiecewiseLinearMitigation
+	// LogisticMitigation smoothly decreases the damage multiplier from 1 to a min. multiplier around a midpoint
+	LogisticMitigation
+	// StepTableMitigation applies the multiplier of the highest (level, multiplier) point reached by the common pool
+	StepTableMitigation
+
+	// DO NOT TOUCH THIS
+	mitigationCurveTypeEnd
+)
+
+func (m MitigationCurveType) String() string {
+	strings := [...]string{"ThresholdMitigation", "PiecewiseLinearMitigation", "LogisticMitigation", "StepTableMitigation"}
+	if m >= 0 && int(m) < len(strings) {
+		return strings[m]
+	}
+	return fmt.Sprintf("UNKNOWN MitigationCurveType '%v'", int(m))
+}
+
+// GoString implements GoStringer
+func (m MitigationCurveType) GoString() string {
+	return m.String()
+}
+
+// MarshalText implements TextMarshaler
+func (m MitigationCurveType) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(m.String())
+}
+
+// MarshalJSON implements RawMessage
+func (m MitigationCurveType) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(m.String())
+}
+
+// ParseMitigationCurveType gets the MitigationCurveType based on the number
+func ParseMitigationCurveType(x int) (MitigationCurveType, error) {
+	if x >= 0 && MitigationCurveType(x) < mitigationCurveTypeEnd {
+		return MitigationCurveType(x), nil
+	}
+	return ThresholdMitigation, errors.Errorf("Unknown MitigationCurveType specified: '%v'.", x)
+}
+
+// HelpMitigationCurveType returns a help string for MitigationCurveType
+func HelpMitigationCurveType() string {
+	help := "Set curve mapping common pool level to the multiplier applied to disaster damage\n"
+
+	for i := 0; i < int(mitigationCurveTypeEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, MitigationCurveType(i))
+	}
+
+	return help
+}
+
+// MitigationPoint is a point on a mitigation curve: the damage multiplier at a given common pool level
+type MitigationPoint struct {
+	Level      Resources
+	Multiplier float64
+}
+
+// ParseMitigationPoints parses mitigation curve points of the form `level1:multiplier1;level2:multiplier2;...`.
+// Levels must be strictly increasing and multipliers non-negative. An empty string yields no points.
+func ParseMitigationPoints(s string) ([]MitigationPoint, error) {
+	points := []MitigationPoint{}
+	if strings.TrimSpace(s) == "" {
+		return points, nil
+	}
+	for _, pair := range strings.Split(s, ";") {
+		lm := strings.Split(pair, ":")
+		if len(lm) != 2 {
+			return nil, errors.Errorf("Invalid mitigation point '%v'. Expected 'level:multiplier'.", pair)
+		}
+		level, err := strconv.ParseFloat(strings.TrimSpace(lm[0]), 64)
+		if err != nil {
+			return nil, errors.Errorf("Invalid level in mitigation point '%v': %v", pair, err)
+		}
+		multiplier, err := strconv.ParseFloat(strings.TrimSpace(lm[1]), 64)
+		if err != nil {
+			return nil, errors.Errorf("Invalid multiplier in mitigation point '%v': %v", pair, err)
+		}
+		if multiplier < 0 {
+			return nil, errors.Errorf("Negative multiplier in mitigation point '%v'.", pair)
+		}
+		if len(points) > 0 && Resources(level) <= points[len(points)-1].Level {
+			return nil, errors.Errorf("Mitigation point levels should be strictly increasing: got '%v'.", pair)
+		}
+		points = append(points, MitigationPoint{Level: Resources(level), Multiplier: multiplier})
+	}
+	return points, nil
+}
+
+// DisasterProcess is an enum for the temporal process that decides in which turns disasters occur
+type DisasterProcess int
+
+const (
+	// PeriodicProcess uses DisasterConfig.Period, deterministically or as a Bernoulli process (legacy behaviour)
+	PeriodicProcess DisasterProcess = iota
+	// MarkovModulatedProcess switches between a calm and a stormy regime, each with its own disaster period
+	MarkovModulatedProcess
+	// HawkesProcess is self-exciting: the chance of a disaster rises after a disaster, in proportion to its magnitude
+	HawkesProcess
+	// ReplayProcess replays a fixed schedule of disasters
+	ReplayProcess
+
+	// DO NOT TOUCH THIS
+	disasterProcessEnd
+)
+
+func (d DisasterProcess) String() string {
+	strings := [...]string{"PeriodicProcess", "MarkovModulatedProcess", "HawkesProcess", "ReplayProcess"}
+	if d >= 0 && int(d) < len(strings) {
+		return strings[d]
+	}
+	return fmt.Sprintf("UNKNOWN DisasterProcess '%v'", int(d))
+}
+
+// GoString implements GoStringer
+func (d DisasterProcess) GoString() string {
+	return d.String()
+}
+
+// MarshalText implements TextMarshaler
+func (d DisasterProcess) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(d.String())
+}
+
+// MarshalJSON implements RawMessage
+func (d DisasterProcess) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(d.String())
+}
+
+// ParseDisasterProcess gets the DisasterProcess based on the number
+func ParseDisasterProcess(x int) (DisasterProcess, error) {
+	if x >= 0 && DisasterProcess(x) < disasterProcessEnd {
+		return DisasterProcess(x), nil
+	}
+	return PeriodicProcess, errors.Errorf("Unknown DisasterProcess specified: '%v'.", x)
+}
+
+// HelpDisasterProcess returns a help string for DisasterProcess
+func HelpDisasterProcess() string {
+	help := "Set temporal process deciding in which turns disasters occur\n"
+
+	for i := 0; i < int(disasterProcessEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, DisasterProcess(i))
+	}
+
+	return help
+}
+
+// ScheduledDisaster is a disaster to be replayed in a given turn
+type ScheduledDisaster struct {
+	Turn      uint
+	X, Y      Coordinate
+	Magnitude Magnitude
+}
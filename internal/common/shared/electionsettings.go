@@ -15,6 +15,12 @@ const (
 	Runoff
 	InstantRunoff
 	Approval
+	// Schulze elects the candidate whose strongest beatpaths to all other candidates are at least as strong as theirs
+	Schulze
+	// RankedPairs locks in pairwise majorities from strongest to weakest, skipping any that would create a cycle
+	RankedPairs
+	// Copeland elects the candidate winning the most pairwise contests (a tied contest counts as half a win)
+	Copeland
 )
 
 // ElectionSettings allows islands to configure elections for power transfer in IIGO
@@ -30,6 +36,9 @@ func (e ElectionVotingMethod) String() string {
 		"Runoff",
 		"InstantRunoff",
 		"Approval",
+		"Schulze",
+		"RankedPairs",
+		"Copeland",
 	}
 	if e >= 0 && int(e) < len(strs) {
 		return strs[e]
@@ -15,6 +15,8 @@ const (
 	DeerForageType ForageType = iota
 	// FishForageType is another foraging resource also defined in foraging package
 	FishForageType
+	// FarmForageType is crop farming: investments mature over several turns before they are harvested
+	FarmForageType
 
 	// don't change this
 	_endForageType
@@ -47,7 +49,7 @@ func IsValidForageType(ft ForageType) bool {
 }
 
 func (ft ForageType) String() string {
-	strings := [...]string{"DeerForageType", "FishForageType"}
+	strings := [...]string{"DeerForageType", "FishForageType", "FarmForageType"}
 	if ft >= 0 && int(ft) < len(strings) {
 		return strings[ft]
 	}
@@ -69,6 +71,240 @@ func (ft ForageType) MarshalJSON() ([]byte, error) {
 	return miscutils.MarshalJSONForString(ft.String())
 }
 
+// PopulationModelType selects the growth model governing a forage population over time
+type PopulationModelType int
+
+const (
+	// BasicPopulationModel is dP/dt = k(N-P): the population regenerates towards capacity N at a rate prop. to the shortfall
+	BasicPopulationModel PopulationModelType = iota
+	// LogisticPopulationModel is dP/dt = kP(1-P/N)
+	LogisticPopulationModel
+	// AlleePopulationModel is logistic growth with a strong Allee effect: dP/dt = kP(1-P/N)(P/A-1). The population
+	// collapses below the critical density A
+	AlleePopulationModel
+	// GompertzPopulationModel is dP/dt = kP ln(N/P)
+	GompertzPopulationModel
+
+	// DO NOT TOUCH THIS
+	populationModelTypeEnd
+)
+
+func (pm PopulationModelType) String() string {
+	strings := [...]string{"BasicPopulationModel", "LogisticPopulationModel", "AlleePopulationModel", "GompertzPopulationModel"}
+	if pm >= 0 && int(pm) < len(strings) {
+		return strings[pm]
+	}
+	return fmt.Sprintf("UNKNOWN PopulationModelType '%v'", int(pm))
+}
+
+// GoString implements GoStringer
+func (pm PopulationModelType) GoString() string {
+	return pm.String()
+}
+
+// MarshalText implements TextMarshaler
+func (pm PopulationModelType) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(pm.String())
+}
+
+// MarshalJSON implements RawMessage
+func (pm PopulationModelType) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(pm.String())
+}
+
+// ParsePopulationModelType gets the PopulationModelType based on the number
+func ParsePopulationModelType(x int) (PopulationModelType, error) {
+	if x >= 0 && PopulationModelType(x) < populationModelTypeEnd {
+		return PopulationModelType(x), nil
+	}
+	return BasicPopulationModel, errors.Errorf("Unknown PopulationModelType specified: '%v'.", x)
+}
+
+// HelpPopulationModelType returns a help string for PopulationModelType
+func HelpPopulationModelType() string {
+	help := "Set growth model governing the population over time\n"
+
+	for i := 0; i < int(populationModelTypeEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, PopulationModelType(i))
+	}
+
+	return help
+}
+
+// FarmGrowthCurve selects how crops grow over the turns until they mature
+type FarmGrowthCurve int
+
+const (
+	// LinearFarmGrowth grows crops by the same amount each turn
+	LinearFarmGrowth FarmGrowthCurve = iota
+	// LogisticFarmGrowth grows crops slowly at first, fastest halfway to maturity and slowly again near maturity
+	LogisticFarmGrowth
+	// ConcaveFarmGrowth grows crops fastest right after planting
+	ConcaveFarmGrowth
+
+	// DO NOT TOUCH THIS
+	farmGrowthCurveEnd
+)
+
+func (fg FarmGrowthCurve) String() string {
+	strings := [...]string{"LinearFarmGrowth", "LogisticFarmGrowth", "ConcaveFarmGrowth"}
+	if fg >= 0 && int(fg) < len(strings) {
+		return strings[fg]
+	}
+	return fmt.Sprintf("UNKNOWN FarmGrowthCurve '%v'", int(fg))
+}
+
+// GoString implements GoStringer
+func (fg FarmGrowthCurve) GoString() string {
+	return fg.String()
+}
+
+// MarshalText implements TextMarshaler
+func (fg FarmGrowthCurve) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(fg.String())
+}
+
+// MarshalJSON implements RawMessage
+func (fg FarmGrowthCurve) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(fg.String())
+}
+
+// ParseFarmGrowthCurve gets the FarmGrowthCurve based on the number
+func ParseFarmGrowthCurve(x int) (FarmGrowthCurve, error) {
+	if x >= 0 && FarmGrowthCurve(x) < farmGrowthCurveEnd {
+		return FarmGrowthCurve(x), nil
+	}
+	return LinearFarmGrowth, errors.Errorf("Unknown FarmGrowthCurve specified: '%v'.", x)
+}
+
+// HelpFarmGrowthCurve returns a help string for FarmGrowthCurve
+func HelpFarmGrowthCurve() string {
+	help := "Set curve governing how crops grow until they mature\n"
+
+	for i := 0; i < int(farmGrowthCurveEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, FarmGrowthCurve(i))
+	}
+
+	return help
+}
+
+// FishSizeDistribution selects the distribution of the size (return) of each fish caught
+type FishSizeDistribution int
+
+const (
+	// TruncatedNormalFishSize is a normal distribution truncated at zero, so fish never have a negative size
+	TruncatedNormalFishSize FishSizeDistribution = iota
+	// LogNormalFishSize is a log-normal distribution with the configured mean and variance
+	LogNormalFishSize
+	// GammaFishSize is a gamma distribution with the configured mean and variance
+	GammaFishSize
+	// LegacyNormalFishSize is the original normal distribution, which uses the configured variance as its standard
+	// deviation and can produce negative fish sizes
+	LegacyNormalFishSize
+
+	// DO NOT TOUCH THIS
+	fishSizeDistributionEnd
+)
+
+func (fd FishSizeDistribution) String() string {
+	strings := [...]string{"TruncatedNormalFishSize", "LogNormalFishSize", "GammaFishSize", "LegacyNormalFishSize"}
+	if fd >= 0 && int(fd) < len(strings) {
+		return strings[fd]
+	}
+	return fmt.Sprintf("UNKNOWN FishSizeDistribution '%v'", int(fd))
+}
+
+// GoString implements GoStringer
+func (fd FishSizeDistribution) GoString() string {
+	return fd.String()
+}
+
+// MarshalText implements TextMarshaler
+func (fd FishSizeDistribution) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(fd.String())
+}
+
+// MarshalJSON implements RawMessage
+func (fd FishSizeDistribution) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(fd.String())
+}
+
+// ParseFishSizeDistribution gets the FishSizeDistribution based on the number
+func ParseFishSizeDistribution(x int) (FishSizeDistribution, error) {
+	if x >= 0 && FishSizeDistribution(x) < fishSizeDistributionEnd {
+		return FishSizeDistribution(x), nil
+	}
+	return TruncatedNormalFishSize, errors.Errorf("Unknown FishSizeDistribution specified: '%v'.", x)
+}
+
+// HelpFishSizeDistribution returns a help string for FishSizeDistribution
+func HelpFishSizeDistribution() string {
+	help := "Set distribution of the size of each fish caught\n"
+
+	for i := 0; i < int(fishSizeDistributionEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, FishSizeDistribution(i))
+	}
+
+	return help
+}
+
+// StagHuntRule selects how the number of participants in a deer hunt affects the chance of catching deer
+type StagHuntRule int
+
+const (
+	// NoStagHuntRule ignores the number of participants: only the total input matters
+	NoStagHuntRule StagHuntRule = iota
+	// MinimumParticipantsStagHunt catches no deer unless at least MinHuntParticipants islands take part
+	MinimumParticipantsStagHunt
+	// ParticipantScaledStagHunt multiplies the catch probability by min(1, participants/MinHuntParticipants)
+	ParticipantScaledStagHunt
+
+	// DO NOT TOUCH THIS
+	stagHuntRuleEnd
+)
+
+func (sr StagHuntRule) String() string {
+	strings := [...]string{"NoStagHuntRule", "MinimumParticipantsStagHunt", "ParticipantScaledStagHunt"}
+	if sr >= 0 && int(sr) < len(strings) {
+		return strings[sr]
+	}
+	return fmt.Sprintf("UNKNOWN StagHuntRule '%v'", int(sr))
+}
+
+// GoString implements GoStringer
+func (sr StagHuntRule) GoString() string {
+	return sr.String()
+}
+
+// MarshalText implements TextMarshaler
+func (sr StagHuntRule) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(sr.String())
+}
+
+// MarshalJSON implements RawMessage
+func (sr StagHuntRule) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(sr.String())
+}
+
+// ParseStagHuntRule gets the StagHuntRule based on the number
+func ParseStagHuntRule(x int) (StagHuntRule, error) {
+	if x >= 0 && StagHuntRule(x) < stagHuntRuleEnd {
+		return StagHuntRule(x), nil
+	}
+	return NoStagHuntRule, errors.Errorf("Unknown StagHuntRule specified: '%v'.", x)
+}
+
+// HelpStagHuntRule returns a help string for StagHuntRule
+func HelpStagHuntRule() string {
+	help := "Set how the number of deer hunt participants affects the chance of catching deer\n"
+
+	for i := 0; i < int(stagHuntRuleEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, StagHuntRule(i))
+	}
+
+	return help
+}
+
 // ForageDecision is used to represent a foraging decision made by agents
 type ForageDecision struct {
 	Type         ForageType
@@ -95,3 +331,43 @@ type ForagingOfferDict = map[ClientID]ForageShareInfo
 // ForagingReceiptDict is a map of client -> array of information of other clients
 // foraging decisions, their resource obtained, and which clients to sent this information
 type ForagingReceiptDict = map[ClientID][]ForageShareInfo
+
+// HuntingPartyProposal is an island's proposal to form a binding hunting party. Each member of the party (including
+// the proposer) commits to forage in ForageType with a contribution of at least MinimumCommitment. The party forages
+// separately from other islands and its return is split among its members according to SplitStrategy.
+type HuntingPartyProposal struct {
+	ForageType        ForageType
+	Invitees          []ClientID // islands invited to join the party. An empty list means no proposal
+	SplitStrategy     ResourceDistributionStrategy
+	MinimumCommitment Resources
+}
+
+// HuntingPartyProposalDict is a map of proposer -> hunting party proposal
+type HuntingPartyProposalDict = map[ClientID]HuntingPartyProposal
+
+// HuntingParty is a hunting party agreed before foraging. Its terms are enforced by the server.
+type HuntingParty struct {
+	Proposer          ClientID
+	Members           []ClientID // all members of the party, including the proposer
+	ForageType        ForageType
+	SplitStrategy     ResourceDistributionStrategy
+	MinimumCommitment Resources
+}
+
+// IsMember checks whether an island is a member of the hunting party
+func (p HuntingParty) IsMember(id ClientID) bool {
+	for _, member := range p.Members {
+		if member == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy returns a deep copy of the HuntingParty
+func (p HuntingParty) Copy() HuntingParty {
+	ret := p
+	ret.Members = make([]ClientID, len(p.Members))
+	copy(ret.Members, p.Members)
+	return ret
+}
@@ -16,6 +16,7 @@ const (
 	CommunicationBool
 	CommunicationIIGORole
 	CommunicationIIGOValue
+	CommunicationStringList
 )
 
 func (c CommunicationContentType) String() string {
@@ -25,6 +26,7 @@ func (c CommunicationContentType) String() string {
 		"CommunicationBool",
 		"CommunicationIIGORole",
 		"CommunicationIIGOValue",
+		"CommunicationStringList",
 	}
 	if c >= 0 && int(c) < len(strs) {
 		return strs[c]
@@ -63,6 +65,7 @@ type CommunicationContent struct {
 	IIGORoleData   Role
 	IIGOValueData  ValueDecision
 	RuleMatrixData rules.RuleMatrix
+	TextListData   []string
 }
 
 type CommunicationFieldName int
@@ -85,6 +88,8 @@ const (
 	IIGOTaxDecision
 	IIGOAllocationDecision
 	SanctionClientID
+	RuleConflicts
+	SanctionExplanations
 )
 
 func (c CommunicationFieldName) String() string {
@@ -106,6 +111,8 @@ func (c CommunicationFieldName) String() string {
 		"IIGOTaxDecision",
 		"IIGOAllocationDecision",
 		"SanctionClientID",
+		"RuleConflicts",
+		"SanctionExplanations",
 	}
 	if c >= 0 && int(c) < len(strs) {
 		return strs[c]
@@ -16,15 +16,17 @@ const (
 	EqualSplit ResourceDistributionStrategy = iota
 	// InputProportionalSplit splits proportional to input contributions
 	InputProportionalSplit
-	// RankProportionalSplit splits according to some other external rank specification
+	// RankProportionalSplit splits according to some other external rank specification (see RankingBasis)
 	RankProportionalSplit
+	// InputProportionalWithMinimumShareSplit guarantees each party a minimum share and splits the rest proportional to input contributions
+	InputProportionalWithMinimumShareSplit
 
 	// don't modify this
 	_resourceDistrEnd
 )
 
 func (rd ResourceDistributionStrategy) String() string {
-	strings := [...]string{"EqualSplit", "InputProportionalSplit", "RankProportionalSplit"}
+	strings := [...]string{"EqualSplit", "InputProportionalSplit", "RankProportionalSplit", "InputProportionalWithMinimumShareSplit"}
 	if rd >= 0 && int(rd) < len(strings) {
 		return strings[rd]
 	}
@@ -64,3 +66,60 @@ func HelpResourceDistributionStrategy() string {
 
 	return help
 }
+
+// RankingBasis is the external ranking used to split resources in RankProportionalSplit
+type RankingBasis int
+
+const (
+	// ContributionRanking ranks parties by their input contribution
+	ContributionRanking RankingBasis = iota
+	// CumulativeContributionRanking ranks parties by their cumulative input contributions over all past foraging sessions
+	CumulativeContributionRanking
+	// SanctionRanking ranks parties by their outstanding IIGO sanctions. Fewer sanctions => higher rank
+	SanctionRanking
+
+	// don't modify this
+	_rankingBasisEnd
+)
+
+func (rb RankingBasis) String() string {
+	strings := [...]string{"ContributionRanking", "CumulativeContributionRanking", "SanctionRanking"}
+	if rb >= 0 && int(rb) < len(strings) {
+		return strings[rb]
+	}
+	return fmt.Sprintf("UNKNOWN RankingBasis '%v'", int(rb))
+}
+
+// GoString implements GoStringer
+func (rb RankingBasis) GoString() string {
+	return rb.String()
+}
+
+// MarshalText implements TextMarshaler
+func (rb RankingBasis) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(rb.String())
+}
+
+// MarshalJSON implements RawMessage
+func (rb RankingBasis) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(rb.String())
+}
+
+// ParseRankingBasis gets the RankingBasis based on an iota index
+func ParseRankingBasis(x int) (RankingBasis, error) {
+	if x >= 0 && RankingBasis(x) < _rankingBasisEnd {
+		return RankingBasis(x), nil
+	}
+	return ContributionRanking, errors.Errorf("Unknown RankingBasis specified: '%v'.", x)
+}
+
+// HelpRankingBasis returns a help string for RankingBasis
+func HelpRankingBasis() string {
+	help := "Determine ranking of parties used to split group resources in RankProportionalSplit\n"
+
+	for i := 0; i < int(_rankingBasisEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, RankingBasis(i))
+	}
+
+	return help
+}
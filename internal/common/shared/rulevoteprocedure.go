@@ -0,0 +1,172 @@
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+	"github.com/pkg/errors"
+)
+
+// RuleVoteDecisionRule provides enumerated type for the rule deciding whether a rule vote passes
+type RuleVoteDecisionRule int
+
+const (
+	// MajorityRuleVote passes a rule if the weight of the ballots in favour exceeds the weight of those against
+	MajorityRuleVote RuleVoteDecisionRule = iota
+	// SupermajorityRuleVote passes a rule if the ballots in favour carry at least the supermajority fraction of the
+	// weight of all cast (non-abstaining) ballots
+	SupermajorityRuleVote
+	// UnanimousRuleVote passes a rule if at least one ballot is in favour and none are against
+	UnanimousRuleVote
+
+	// DO NOT TOUCH THIS
+	ruleVoteDecisionRuleEnd
+)
+
+func (r RuleVoteDecisionRule) String() string {
+	strs := [...]string{"MajorityRuleVote", "SupermajorityRuleVote", "UnanimousRuleVote"}
+	if r >= 0 && int(r) < len(strs) {
+		return strs[r]
+	}
+	return fmt.Sprintf("UNKNOWN RuleVoteDecisionRule '%v'", int(r))
+}
+
+// GoString implements GoStringer
+func (r RuleVoteDecisionRule) GoString() string {
+	return r.String()
+}
+
+// MarshalText implements TextMarshaler
+func (r RuleVoteDecisionRule) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(r.String())
+}
+
+// MarshalJSON implements RawMessage
+func (r RuleVoteDecisionRule) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(r.String())
+}
+
+// ParseRuleVoteDecisionRule gets the RuleVoteDecisionRule based on the number
+func ParseRuleVoteDecisionRule(x int) (RuleVoteDecisionRule, error) {
+	if x >= 0 && RuleVoteDecisionRule(x) < ruleVoteDecisionRuleEnd {
+		return RuleVoteDecisionRule(x), nil
+	}
+	return MajorityRuleVote, errors.Errorf("Unknown RuleVoteDecisionRule specified: '%v'.", x)
+}
+
+// HelpRuleVoteDecisionRule returns a help string for RuleVoteDecisionRule
+func HelpRuleVoteDecisionRule() string {
+	help := "Set rule deciding whether a rule vote passes\n"
+
+	for i := 0; i < int(ruleVoteDecisionRuleEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, RuleVoteDecisionRule(i))
+	}
+
+	return help
+}
+
+// RuleVoteWeighting provides enumerated type for the weight carried by each island's ballot in a rule vote
+type RuleVoteWeighting int
+
+const (
+	// EqualVoteWeighting gives every ballot the same weight
+	EqualVoteWeighting RuleVoteWeighting = iota
+	// ResourceVoteWeighting weighs each ballot by the private resources of the voting island
+	ResourceVoteWeighting
+	// ContributionVoteWeighting weighs each ballot by the total tax the voting island has paid into the common pool
+	ContributionVoteWeighting
+
+	// DO NOT TOUCH THIS
+	ruleVoteWeightingEnd
+)
+
+func (w RuleVoteWeighting) String() string {
+	strs := [...]string{"EqualVoteWeighting", "ResourceVoteWeighting", "ContributionVoteWeighting"}
+	if w >= 0 && int(w) < len(strs) {
+		return strs[w]
+	}
+	return fmt.Sprintf("UNKNOWN RuleVoteWeighting '%v'", int(w))
+}
+
+// GoString implements GoStringer
+func (w RuleVoteWeighting) GoString() string {
+	return w.String()
+}
+
+// MarshalText implements TextMarshaler
+func (w RuleVoteWeighting) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(w.String())
+}
+
+// MarshalJSON implements RawMessage
+func (w RuleVoteWeighting) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(w.String())
+}
+
+// ParseRuleVoteWeighting gets the RuleVoteWeighting based on the number
+func ParseRuleVoteWeighting(x int) (RuleVoteWeighting, error) {
+	if x >= 0 && RuleVoteWeighting(x) < ruleVoteWeightingEnd {
+		return RuleVoteWeighting(x), nil
+	}
+	return EqualVoteWeighting, errors.Errorf("Unknown RuleVoteWeighting specified: '%v'.", x)
+}
+
+// HelpRuleVoteWeighting returns a help string for RuleVoteWeighting
+func HelpRuleVoteWeighting() string {
+	help := "Set weight carried by each island's ballot in a rule vote\n"
+
+	for i := 0; i < int(ruleVoteWeightingEnd); i++ {
+		help += fmt.Sprintf("%v: %v\n", i, RuleVoteWeighting(i))
+	}
+
+	return help
+}
+
+// RuleVoteProcedure is the procedure by which a rule vote is decided. The zero value is an unweighted simple majority.
+type RuleVoteProcedure struct {
+	DecisionRule RuleVoteDecisionRule
+	Weighting    RuleVoteWeighting
+}
+
+// ParseRuleVoteProcedureOverrides parses per-rule vote procedures from a string of the form
+// `rule1=decisionRule:weighting;rule2=decisionRule:weighting;...`, where decisionRule and weighting are the numbers of
+// a RuleVoteDecisionRule and a RuleVoteWeighting respectively.
+func ParseRuleVoteProcedureOverrides(s string) (map[string]RuleVoteProcedure, error) {
+	overrides := map[string]RuleVoteProcedure{}
+	if strings.TrimSpace(s) == "" {
+		return overrides, nil
+	}
+	for _, entry := range strings.Split(s, ";") {
+		nameProcedure := strings.Split(entry, "=")
+		if len(nameProcedure) != 2 || strings.TrimSpace(nameProcedure[0]) == "" {
+			return nil, errors.Errorf("Invalid rule vote procedure '%v'. Expected 'ruleName=decisionRule:weighting'.", entry)
+		}
+		ruleWeighting := strings.Split(nameProcedure[1], ":")
+		if len(ruleWeighting) != 2 {
+			return nil, errors.Errorf("Invalid rule vote procedure '%v'. Expected 'ruleName=decisionRule:weighting'.", entry)
+		}
+		rule, err := strconv.Atoi(strings.TrimSpace(ruleWeighting[0]))
+		if err != nil {
+			return nil, errors.Errorf("Invalid decision rule in rule vote procedure '%v': %v", entry, err)
+		}
+		parsedRule, err := ParseRuleVoteDecisionRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		weighting, err := strconv.Atoi(strings.TrimSpace(ruleWeighting[1]))
+		if err != nil {
+			return nil, errors.Errorf("Invalid weighting in rule vote procedure '%v': %v", entry, err)
+		}
+		parsedWeighting, err := ParseRuleVoteWeighting(weighting)
+		if err != nil {
+			return nil, err
+		}
+		overrides[strings.TrimSpace(nameProcedure[0])] = RuleVoteProcedure{
+			DecisionRule: parsedRule,
+			Weighting:    parsedWeighting,
+		}
+	}
+	return overrides, nil
+}
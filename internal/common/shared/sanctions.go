@@ -2,6 +2,7 @@ package shared
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
@@ -59,6 +60,83 @@ type EvaluationReturn struct {
 	Evaluations []bool
 }
 
+// DefaultSanctionThresholds provides default thresholds for sanctions
+func DefaultSanctionThresholds() map[IIGOSanctionsTier]IIGOSanctionsScore {
+	return map[IIGOSanctionsTier]IIGOSanctionsScore{
+		SanctionTier1: 1,
+		SanctionTier2: 5,
+		SanctionTier3: 10,
+		SanctionTier4: 20,
+		SanctionTier5: 30,
+	}
+}
+
+// GetSanctionTier if statement based evaluator for which sanction tier a particular score is in
+func GetSanctionTier(islandScore IIGOSanctionsScore, scoreMap map[IIGOSanctionsTier]IIGOSanctionsScore) IIGOSanctionsTier {
+	if islandScore < scoreMap[SanctionTier1] {
+		return NoSanction
+	} else if islandScore < scoreMap[SanctionTier2] {
+		return SanctionTier1
+	} else if islandScore < scoreMap[SanctionTier3] {
+		return SanctionTier2
+	} else if islandScore < scoreMap[SanctionTier4] {
+		return SanctionTier3
+	} else if islandScore < scoreMap[SanctionTier5] {
+		return SanctionTier4
+	} else {
+		return SanctionTier5
+	}
+}
+
+// CounterfactualSanction is the sanction an island would have received in a turn for breaking a candidate rule
+type CounterfactualSanction struct {
+	Turn         uint
+	ClientID     ClientID
+	Breaches     int // number of reports in the turn breaking the rule
+	Score        IIGOSanctionsScore
+	SanctionTier IIGOSanctionsTier
+}
+
+// ReplayRuleSanctions replays a candidate rule against the accountability entries of an IIGO history (see
+// rules.ReplayRule) and returns the sanction each island breaking it would have received in each turn, in order of turn
+// and island, if every breach scored severity under the given sanction thresholds. Clients can use it in VoteForRule
+// with the history they have gathered; rulesCache provides the children of a linked candidate.
+func ReplayRuleSanctions(candidate rules.RuleMatrix, rulesCache map[string]rules.RuleMatrix, iigoHistory map[uint][]Accountability, variableCache map[rules.VariableFieldName]rules.VariableValuePair, severity IIGOSanctionsScore, thresholds map[IIGOSanctionsTier]IIGOSanctionsScore) ([]CounterfactualSanction, error) {
+	var reports []rules.HistoricalReport
+	for turn, entries := range iigoHistory {
+		for _, entry := range entries {
+			reports = append(reports, rules.HistoricalReport{Turn: turn, ClientID: int(entry.ClientID), Pairs: entry.Pairs})
+		}
+	}
+	// keep the order of entries within a turn, ReplayRule orders the turns
+	breaches, err := rules.ReplayRule(candidate, rulesCache, reports, variableCache)
+	if err != nil {
+		return nil, err
+	}
+
+	var sanctions []CounterfactualSanction
+	indices := map[CounterfactualSanction]int{}
+	for _, breach := range breaches {
+		key := CounterfactualSanction{Turn: breach.Turn, ClientID: ClientID(breach.ClientID)}
+		index, ok := indices[key]
+		if !ok {
+			index = len(sanctions)
+			indices[key] = index
+			sanctions = append(sanctions, key)
+		}
+		sanctions[index].Breaches++
+		sanctions[index].Score += severity
+		sanctions[index].SanctionTier = GetSanctionTier(sanctions[index].Score, thresholds)
+	}
+	sort.Slice(sanctions, func(i, j int) bool {
+		if sanctions[i].Turn != sanctions[j].Turn {
+			return sanctions[i].Turn < sanctions[j].Turn
+		}
+		return sanctions[i].ClientID < sanctions[j].ClientID
+	})
+	return sanctions, nil
+}
+
 // Sanction is a data-structure that represents a sanction on an agent, including how long it has left
 type Sanction struct {
 	ClientID     ClientID
@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+)
+
+func TestGetSanctionTier(t *testing.T) {
+	thresholds := DefaultSanctionThresholds()
+	cases := map[IIGOSanctionsScore]IIGOSanctionsTier{0: NoSanction, 1: SanctionTier1, 9: SanctionTier2, 20: SanctionTier4, 100: SanctionTier5}
+	for score, want := range cases {
+		if got := GetSanctionTier(score, thresholds); got != want {
+			t.Errorf("score %v: want %v, got %v", score, want, got)
+		}
+	}
+}
+
+func TestReplayRuleSanctions(t *testing.T) {
+	candidate, err := rules.ParseRule("rule honest: IslandReportedPrivateResources - IslandActualPrivateResources == 0")
+	if err != nil {
+		t.Fatalf("invalid rule: %v", err)
+	}
+	entry := func(clientID ClientID, reported float64, actual float64) Accountability {
+		return Accountability{ClientID: clientID, Pairs: []rules.VariableValuePair{
+			rules.MakeVariableValuePair(rules.IslandReportedPrivateResources, []float64{reported}),
+			rules.MakeVariableValuePair(rules.IslandActualPrivateResources, []float64{actual}),
+		}}
+	}
+	iigoHistory := map[uint][]Accountability{
+		3: {entry(Team2, 10, 20), entry(Team1, 5, 5)},
+		1: {entry(Team3, 10, 20), entry(Team1, 5, 6), entry(Team3, 1, 2)},
+	}
+	want := []CounterfactualSanction{
+		{Turn: 1, ClientID: Team1, Breaches: 1, Score: 3, SanctionTier: SanctionTier1},
+		{Turn: 1, ClientID: Team3, Breaches: 2, Score: 6, SanctionTier: SanctionTier2},
+		{Turn: 3, ClientID: Team2, Breaches: 1, Score: 3, SanctionTier: SanctionTier1},
+	}
+	got, err := ReplayRuleSanctions(candidate, nil, iigoHistory, nil, 3, DefaultSanctionThresholds())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
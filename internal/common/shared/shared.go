@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+	"github.com/pkg/errors"
 )
 
 // ClientID is an enum for client IDs
@@ -54,6 +55,17 @@ func (c ClientID) MarshalJSON() ([]byte, error) {
 	return miscutils.MarshalJSONForString(c.String())
 }
 
+// UnmarshalText implements TextUnmarshaler, so that client IDs written to output.json can be read back
+func (c *ClientID) UnmarshalText(text []byte) error {
+	for _, id := range TeamIDs {
+		if id.String() == string(text) {
+			*c = id
+			return nil
+		}
+	}
+	return errors.Errorf("unknown ClientID '%s'", text)
+}
+
 // Logger type for convenience in other definitions
 type Logger func(format string, a ...interface{})
 
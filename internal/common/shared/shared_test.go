@@ -35,3 +35,172 @@ func TestSortClientByID(t *testing.T) {
 		t.Errorf("want '%v' got '%v'", want, clients)
 	}
 }
+
+func TestParseIslandPositions(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    map[ClientID]Position
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  map[ClientID]Position{},
+		},
+		{
+			name:  "partial",
+			input: "1:2; 3.5:-4",
+			want: map[ClientID]Position{
+				Team1: {X: 1, Y: 2},
+				Team2: {X: 3.5, Y: -4},
+			},
+		},
+		{
+			name:    "missing co-ordinate",
+			input:   "1:2;3",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			input:   "1:a",
+			wantErr: true,
+		},
+		{
+			name:    "too many islands",
+			input:   "0:0;0:0;0:0;0:0;0:0;0:0;0:0",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseIslandPositions(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got '%v'", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseMitigationPoints(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    []MitigationPoint
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  []MitigationPoint{},
+		},
+		{
+			name:  "valid",
+			input: "0:1; 100:0.5;200:0.25",
+			want: []MitigationPoint{
+				{Level: 0, Multiplier: 1},
+				{Level: 100, Multiplier: 0.5},
+				{Level: 200, Multiplier: 0.25},
+			},
+		},
+		{
+			name:    "missing multiplier",
+			input:   "0:1;100",
+			wantErr: true,
+		},
+		{
+			name:    "negative multiplier",
+			input:   "0:-1",
+			wantErr: true,
+		},
+		{
+			name:    "levels not increasing",
+			input:   "100:0.5;100:0.25",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMitigationPoints(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got '%v'", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseRuleVoteProcedureOverrides(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    map[string]RuleVoteProcedure
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  map[string]RuleVoteProcedure{},
+		},
+		{
+			name:  "valid",
+			input: "rule_vote_quorum=2:0; tax_decision = 1:2",
+			want: map[string]RuleVoteProcedure{
+				"rule_vote_quorum": {DecisionRule: UnanimousRuleVote, Weighting: EqualVoteWeighting},
+				"tax_decision":     {DecisionRule: SupermajorityRuleVote, Weighting: ContributionVoteWeighting},
+			},
+		},
+		{
+			name:    "missing weighting",
+			input:   "rule_vote_quorum=2",
+			wantErr: true,
+		},
+		{
+			name:    "missing rule name",
+			input:   "=1:0",
+			wantErr: true,
+		},
+		{
+			name:    "unknown decision rule",
+			input:   "rule_vote_quorum=7:0",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRuleVoteProcedureOverrides(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got '%v'", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
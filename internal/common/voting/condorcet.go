@@ -0,0 +1,260 @@
+package voting
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// pairwisePreferences tallies the ballots pairwise: the result [i][j] is the number of ballots ranking candidate i
+// above candidate j. Ranked candidates are preferred to unranked ones, which are tied with each other.
+func pairwisePreferences(votes [][]shared.ClientID, candidateList []shared.ClientID) [][]float64 {
+	index := map[shared.ClientID]int{}
+	for i, candidate := range candidateList {
+		index[candidate] = i
+	}
+	pairwise := newSquareMatrix(len(candidateList))
+	for _, ballot := range votes {
+		position := make([]int, len(candidateList))
+		for i := range position {
+			position[i] = math.MaxInt32
+		}
+		for pos, candidate := range ballot {
+			if i, ok := index[candidate]; ok && position[i] == math.MaxInt32 {
+				position[i] = pos
+			}
+		}
+		for i := range candidateList {
+			for j := range candidateList {
+				if position[i] < position[j] {
+					pairwise[i][j]++
+				}
+			}
+		}
+	}
+	return pairwise
+}
+
+func newSquareMatrix(n int) [][]float64 {
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+	return matrix
+}
+
+// tieBreakRanking returns the position of each candidate in a random ranking of the candidates drawn from the
+// election's tie-break seed. Lower positions win ties.
+func (e *Election) tieBreakRanking() []int {
+	order := rand.New(rand.NewSource(e.TieBreakSeed)).Perm(len(e.candidateList))
+	ranking := make([]int, len(order))
+	rankedCandidates := make([]shared.ClientID, len(order))
+	for pos, i := range order {
+		ranking[i] = pos
+		rankedCandidates[pos] = e.candidateList[i]
+	}
+	e.rounds = append(e.rounds, gamestate.ElectionRound{
+		Stage:      "TieBreakRanking",
+		Candidates: rankedCandidates,
+	})
+	return ranking
+}
+
+// breakTie returns the candidate index ranked highest by the tie-break ranking. It returns false if there are no
+// tied candidates, e.g. in an election without candidates.
+func breakTie(tied []int, ranking []int) (int, bool) {
+	if len(tied) == 0 {
+		return 0, false
+	}
+	best := tied[0]
+	for _, i := range tied[1:] {
+		if ranking[i] < ranking[best] {
+			best = i
+		}
+	}
+	return best, true
+}
+
+// tieBreakWinner returns the winner among the tied candidates by the tie-break ranking. If there are no tied
+// candidates there is no winner, and like the other counting methods it returns the zero ClientID.
+func (e *Election) tieBreakWinner(tied []int, ranking []int) shared.ClientID {
+	best, ok := breakTie(tied, ranking)
+	if !ok {
+		return shared.ClientID(0)
+	}
+	return e.candidateList[best]
+}
+
+// countPairwise tallies the pairwise preferences of the ballots and records them as a round
+func (e *Election) countPairwise() [][]float64 {
+	pairwise := pairwisePreferences(e.votes, e.candidateList)
+	e.recordPairwiseRound("PairwisePreferences", pairwise)
+	return pairwise
+}
+
+func (e *Election) recordPairwiseRound(stage string, pairwise [][]float64) {
+	e.rounds = append(e.rounds, gamestate.ElectionRound{
+		Stage:      stage,
+		Candidates: copyCandidateList(e.candidateList),
+		Pairwise:   pairwise,
+	})
+}
+
+// schulzeResult elects the Schulze winner: the candidate whose strongest beatpath to every other candidate is at
+// least as strong as the strongest beatpath back. The strength of a beatpath is its weakest pairwise majority.
+func (e *Election) schulzeResult() shared.ClientID {
+	n := len(e.candidateList)
+	pairwise := e.countPairwise()
+
+	strongestPaths := newSquareMatrix(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && pairwise[i][j] > pairwise[j][i] {
+				strongestPaths[i][j] = pairwise[i][j]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				if k != i && k != j {
+					strongestPaths[j][k] = math.Max(strongestPaths[j][k], math.Min(strongestPaths[j][i], strongestPaths[i][k]))
+				}
+			}
+		}
+	}
+	e.recordPairwiseRound("StrongestPaths", strongestPaths)
+
+	winners := []int{}
+	for i := 0; i < n; i++ {
+		isWinner := true
+		for j := 0; j < n; j++ {
+			if i != j && strongestPaths[j][i] > strongestPaths[i][j] {
+				isWinner = false
+				break
+			}
+		}
+		if isWinner {
+			winners = append(winners, i)
+		}
+	}
+	return e.tieBreakWinner(winners, e.tieBreakRanking())
+}
+
+// rankedPairsResult elects the Ranked Pairs (Tideman) winner. Pairwise majorities are locked in from strongest to
+// weakest, skipping any majority that would create a cycle, and the winner is the candidate no locked majority
+// points to. Majorities of equal strength are ordered by the tie-break ranking.
+func (e *Election) rankedPairsResult() shared.ClientID {
+	n := len(e.candidateList)
+	pairwise := e.countPairwise()
+	ranking := e.tieBreakRanking()
+
+	type majority struct{ winner, loser int }
+	majorities := []majority{}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if pairwise[i][j] > pairwise[j][i] {
+				majorities = append(majorities, majority{winner: i, loser: j})
+			}
+		}
+	}
+	sort.SliceStable(majorities, func(a, b int) bool {
+		ma, mb := majorities[a], majorities[b]
+		if pairwise[ma.winner][ma.loser] != pairwise[mb.winner][mb.loser] {
+			return pairwise[ma.winner][ma.loser] > pairwise[mb.winner][mb.loser]
+		}
+		if pairwise[ma.loser][ma.winner] != pairwise[mb.loser][mb.winner] {
+			return pairwise[ma.loser][ma.winner] < pairwise[mb.loser][mb.winner]
+		}
+		if ma.winner != mb.winner {
+			return ranking[ma.winner] < ranking[mb.winner]
+		}
+		return ranking[ma.loser] > ranking[mb.loser]
+	})
+
+	locked := newSquareMatrix(n)
+	for _, m := range majorities {
+		if !lockedPathExists(locked, m.loser, m.winner) {
+			locked[m.winner][m.loser] = 1
+		}
+	}
+	e.recordPairwiseRound("LockedPairs", locked)
+
+	sources := []int{}
+	for j := 0; j < n; j++ {
+		isSource := true
+		for i := 0; i < n; i++ {
+			if locked[i][j] == 1 {
+				isSource = false
+				break
+			}
+		}
+		if isSource {
+			sources = append(sources, j)
+		}
+	}
+	return e.tieBreakWinner(sources, ranking)
+}
+
+// lockedPathExists checks whether the locked pairs lead from candidate from to candidate to
+func lockedPathExists(locked [][]float64, from int, to int) bool {
+	visited := make([]bool, len(locked))
+	stack := []int{from}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == to {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		for next, isLocked := range locked[current] {
+			if isLocked == 1 && !visited[next] {
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
+
+// copelandResult elects the Copeland winner: the candidate winning the most pairwise contests, where a tied contest
+// counts as half a win.
+func (e *Election) copelandResult() shared.ClientID {
+	n := len(e.candidateList)
+	pairwise := e.countPairwise()
+
+	scores := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			if pairwise[i][j] > pairwise[j][i] {
+				scores[i]++
+			} else if pairwise[i][j] == pairwise[j][i] {
+				scores[i] += 0.5
+			}
+		}
+	}
+	e.recordScoresRound("CopelandScores", e.candidateList, scores, nil)
+
+	winners := []int{}
+	maxScore := math.Inf(-1)
+	for i, score := range scores {
+		if score > maxScore {
+			maxScore = score
+			winners = []int{i}
+		} else if score == maxScore {
+			winners = append(winners, i)
+		}
+	}
+	return e.tieBreakWinner(winners, e.tieBreakRanking())
+}
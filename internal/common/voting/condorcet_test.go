@@ -0,0 +1,205 @@
+package voting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// ballots repeats a ranking count times
+func ballots(count int, ranking ...shared.ClientID) [][]shared.ClientID {
+	ret := make([][]shared.ClientID, count)
+	for i := range ret {
+		ret[i] = ranking
+	}
+	return ret
+}
+
+func profile(groups ...[][]shared.ClientID) [][]shared.ClientID {
+	ret := [][]shared.ClientID{}
+	for _, group := range groups {
+		ret = append(ret, group...)
+	}
+	return ret
+}
+
+// Example from the Wikipedia article on the Schulze method: 45 voters, candidates A to E
+const (
+	a = shared.Team1
+	b = shared.Team2
+	c = shared.Team3
+	d = shared.Team4
+	e = shared.Team5
+)
+
+var schulzeExample = profile(
+	ballots(5, a, c, b, e, d),
+	ballots(5, a, d, e, c, b),
+	ballots(8, b, e, d, a, c),
+	ballots(3, c, a, b, e, d),
+	ballots(7, c, a, e, b, d),
+	ballots(2, c, b, a, d, e),
+	ballots(7, d, c, e, b, a),
+	ballots(8, e, b, a, d, c),
+)
+
+// Example from the Wikipedia article on Condorcet methods: choosing the capital of Tennessee
+const (
+	memphis     = shared.Team1
+	nashville   = shared.Team2
+	chattanooga = shared.Team3
+	knoxville   = shared.Team4
+)
+
+var tennesseeExample = profile(
+	ballots(42, memphis, nashville, chattanooga, knoxville),
+	ballots(26, nashville, chattanooga, knoxville, memphis),
+	ballots(15, chattanooga, knoxville, nashville, memphis),
+	ballots(17, knoxville, chattanooga, nashville, memphis),
+)
+
+// Condorcet paradox: every candidate beats one other by 2 to 1
+var cycleExample = profile(
+	ballots(1, a, b, c),
+	ballots(1, b, c, a),
+	ballots(1, c, a, b),
+)
+
+func newCondorcetElection(method shared.ElectionVotingMethod, candidates []shared.ClientID, votes [][]shared.ClientID, seed int64) *Election {
+	return &Election{
+		votingMethod:  method,
+		candidateList: candidates,
+		votes:         votes,
+		TieBreakSeed:  seed,
+	}
+}
+
+func TestPairwisePreferences(t *testing.T) {
+	got := pairwisePreferences(schulzeExample, []shared.ClientID{a, b, c, d, e})
+	want := [][]float64{
+		{0, 20, 26, 30, 22},
+		{25, 0, 16, 33, 18},
+		{19, 29, 0, 17, 24},
+		{15, 12, 28, 0, 14},
+		{23, 27, 21, 31, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// unranked candidates are beaten by ranked ones and tied with each other
+	got = pairwisePreferences([][]shared.ClientID{{b}}, []shared.ClientID{a, b, c})
+	want = [][]float64{{0, 0, 0}, {1, 0, 1}, {0, 0, 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("partial ballot: want %v, got %v", want, got)
+	}
+}
+
+func TestCondorcetMethods(t *testing.T) {
+	schulzeCandidates := []shared.ClientID{a, b, c, d, e}
+	tennesseeCandidates := []shared.ClientID{memphis, nashville, chattanooga, knoxville}
+	cases := []struct {
+		name       string
+		method     shared.ElectionVotingMethod
+		candidates []shared.ClientID
+		votes      [][]shared.ClientID
+		want       shared.ClientID
+	}{
+		{name: "Schulze on Schulze example", method: shared.Schulze, candidates: schulzeCandidates, votes: schulzeExample, want: e},
+		{name: "RankedPairs on Schulze example", method: shared.RankedPairs, candidates: schulzeCandidates, votes: schulzeExample, want: a},
+		{name: "Copeland on Schulze example", method: shared.Copeland, candidates: schulzeCandidates, votes: schulzeExample, want: e},
+		{name: "Schulze on Tennessee", method: shared.Schulze, candidates: tennesseeCandidates, votes: tennesseeExample, want: nashville},
+		{name: "RankedPairs on Tennessee", method: shared.RankedPairs, candidates: tennesseeCandidates, votes: tennesseeExample, want: nashville},
+		{name: "Copeland on Tennessee", method: shared.Copeland, candidates: tennesseeCandidates, votes: tennesseeExample, want: nashville},
+		// without candidates there is no winner, and the zero ClientID is returned
+		{name: "Schulze without candidates", method: shared.Schulze, candidates: []shared.ClientID{}},
+		{name: "RankedPairs without candidates", method: shared.RankedPairs, candidates: []shared.ClientID{}},
+		{name: "Copeland without candidates", method: shared.Copeland, candidates: []shared.ClientID{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the winner of these profiles, if any, is unique, so the tie-break seed must not matter
+			for seed := int64(0); seed < 5; seed++ {
+				election := newCondorcetElection(tc.method, tc.candidates, tc.votes, seed)
+				if got := election.CloseBallot(nil); got != tc.want {
+					t.Errorf("seed %v: want %v, got %v", seed, tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSchulzeRounds(t *testing.T) {
+	election := newCondorcetElection(shared.Schulze, []shared.ClientID{a, b, c, d, e}, schulzeExample, 0)
+	election.CloseBallot(nil)
+	rounds := election.GetVotingInfo().Rounds
+
+	stages := []string{}
+	for _, round := range rounds {
+		stages = append(stages, round.Stage)
+	}
+	wantStages := []string{"PairwisePreferences", "StrongestPaths", "TieBreakRanking"}
+	if !reflect.DeepEqual(stages, wantStages) {
+		t.Fatalf("want stages %v, got %v", wantStages, stages)
+	}
+	wantPaths := [][]float64{
+		{0, 28, 28, 30, 24},
+		{25, 0, 28, 33, 24},
+		{25, 29, 0, 29, 24},
+		{25, 28, 28, 0, 24},
+		{25, 28, 28, 31, 0},
+	}
+	if !reflect.DeepEqual(rounds[1].Pairwise, wantPaths) {
+		t.Errorf("want strongest paths %v, got %v", wantPaths, rounds[1].Pairwise)
+	}
+}
+
+func TestRankedPairsAndCopelandRounds(t *testing.T) {
+	candidates := []shared.ClientID{memphis, nashville, chattanooga, knoxville}
+
+	rankedPairs := newCondorcetElection(shared.RankedPairs, candidates, tennesseeExample, 0)
+	rankedPairs.CloseBallot(nil)
+	// Nashville > Chattanooga > Knoxville > Memphis: every majority is locked in
+	wantLocked := [][]float64{
+		{0, 0, 0, 0},
+		{1, 0, 1, 1},
+		{1, 0, 0, 1},
+		{1, 0, 0, 0},
+	}
+	if got := rankedPairs.rounds[2]; got.Stage != "LockedPairs" || !reflect.DeepEqual(got.Pairwise, wantLocked) {
+		t.Errorf("want locked pairs %v, got %v: %v", wantLocked, got.Stage, got.Pairwise)
+	}
+
+	copeland := newCondorcetElection(shared.Copeland, candidates, tennesseeExample, 0)
+	copeland.CloseBallot(nil)
+	wantScores := []float64{0, 3, 2, 1}
+	if got := copeland.rounds[1]; got.Stage != "CopelandScores" || !reflect.DeepEqual(got.Scores, wantScores) {
+		t.Errorf("want Copeland scores %v, got %v: %v", wantScores, got.Stage, got.Scores)
+	}
+}
+
+func TestCondorcetTieBreak(t *testing.T) {
+	candidates := []shared.ClientID{a, b, c}
+	for _, method := range []shared.ElectionVotingMethod{shared.Schulze, shared.RankedPairs, shared.Copeland} {
+		t.Run(method.String(), func(t *testing.T) {
+			winners := map[shared.ClientID]bool{}
+			for seed := int64(0); seed < 20; seed++ {
+				first := newCondorcetElection(method, candidates, cycleExample, seed).CloseBallot(nil)
+				second := newCondorcetElection(method, candidates, cycleExample, seed).CloseBallot(nil)
+				if first != second {
+					t.Errorf("seed %v: tie-break not deterministic: %v then %v", seed, first, second)
+				}
+				winners[first] = true
+			}
+			if len(winners) < 2 {
+				t.Errorf("expected the tie-break to depend on the seed, always got %v", winners)
+			}
+			for winner := range winners {
+				if winner != a && winner != b && winner != c {
+					t.Errorf("winner %v is not a candidate", winner)
+				}
+			}
+		})
+	}
+}
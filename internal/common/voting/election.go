@@ -16,7 +16,14 @@ type Election struct {
 	candidateList []shared.ClientID
 	voterList     []shared.ClientID
 	votes         [][]shared.ClientID
-	Logger        shared.Logger
+	rounds        []gamestate.ElectionRound
+	//Audit trail of the result
+	held            bool
+	countWinner     shared.ClientID
+	appointedWinner shared.ClientID
+	Logger          shared.Logger
+	// TieBreakSeed seeds the ranking of candidates used to break ties in Schulze, RankedPairs and Copeland
+	TieBreakSeed int64
 }
 
 // Logf is the Election logger
@@ -38,6 +45,7 @@ func (e *Election) OpenBallot(clientIDs []shared.ClientID, allIslands []shared.C
 		return int(allIslands[i]) < int(allIslands[j])
 	})
 	e.candidateList = allIslands
+	e.held = true
 }
 
 // Vote gets votes from eligible islands.
@@ -71,10 +79,32 @@ func (e *Election) CloseBallot(clientMap map[shared.ClientID]baseclient.Client)
 		result = e.instantRunoffResult(clientMap)
 	case shared.Approval:
 		result = e.approvalResult()
+	case shared.Schulze:
+		result = e.schulzeResult()
+	case shared.RankedPairs:
+		result = e.rankedPairsResult()
+	case shared.Copeland:
+		result = e.copelandResult()
 	}
+	e.countWinner = result
 	return result
 }
 
+// AppointWinner records the island appointed to the role, which may differ from the winner of the count.
+func (e *Election) AppointWinner(appointed shared.ClientID) {
+	e.appointedWinner = appointed
+}
+
+// recordScoresRound records a stage of counting which scores candidates
+func (e *Election) recordScoresRound(stage string, candidates []shared.ClientID, scores []float64, eliminated []shared.ClientID) {
+	e.rounds = append(e.rounds, gamestate.ElectionRound{
+		Stage:      stage,
+		Candidates: copyCandidateList(candidates),
+		Scores:     append([]float64{}, scores...),
+		Eliminated: eliminated,
+	})
+}
+
 //func (e *Election) completePreferenceMap()
 
 func scoreCalculator(totalVotes [][]shared.ClientID, candidateList []shared.ClientID) ([]float64, []float64, float64) {
@@ -222,6 +252,7 @@ func (e *Election) bordaCountResult() shared.ClientID {
 	// Implement Borda count winner selection method
 	candidatesNumber := len(e.candidateList)
 	finalScore, variance, _ := scoreCalculator(e.votes, e.candidateList)
+	e.recordScoresRound("BordaScores", e.candidateList, finalScore, nil)
 
 	var maxScore float64 = 0
 	var winnerIndex int
@@ -245,6 +276,7 @@ func (e *Election) runOffResult(clientMap map[shared.ClientID]baseclient.Client)
 	var winner shared.ClientID
 	//Round one
 	scoreList, variance, totalScore := scoreCalculator(e.votes, e.candidateList)
+	e.recordScoresRound("RunoffFirstRound", e.candidateList, scoreList, nil)
 	rOneCandidateList := e.candidateList
 	voterNumber := len(e.voterList)
 
@@ -275,6 +307,13 @@ func (e *Election) runOffResult(clientMap map[shared.ClientID]baseclient.Client)
 				changeNumber++
 			}
 		}
+		var eliminated []shared.ClientID
+		for i, candidate := range rOneCandidateList {
+			if i != maxScoreIndex && i != competitorIndex {
+				eliminated = append(eliminated, candidate)
+			}
+		}
+		e.recordScoresRound("RunoffSecondRound", rTwoCandidateList, []float64{float64(remainNumber), float64(changeNumber)}, eliminated)
 		if changeNumber > remainNumber {
 			winner = rOneCandidateList[competitorIndex]
 		} else {
@@ -287,7 +326,7 @@ func (e *Election) runOffResult(clientMap map[shared.ClientID]baseclient.Client)
 func (e *Election) instantRunoffResult(clientMap map[shared.ClientID]baseclient.Client) shared.ClientID {
 	var winner shared.ClientID
 	candidateNumber := len(e.candidateList)
-	candidateList := e.candidateList
+	candidateList := copyCandidateList(e.candidateList)
 	totalVotes := e.votes
 	var halfTotalScore float64 = 0
 
@@ -300,11 +339,13 @@ func (e *Election) instantRunoffResult(clientMap map[shared.ClientID]baseclient.
 
 		//Eliminate the least popular one until the most popular one has more than half of the total score.
 		if maxScore > halfTotalScore {
+			e.recordScoresRound("InstantRunoffRound", candidateList, scoreList, nil)
 			winner = candidateList[maxScoreIndex]
 			break
 		}
 
 		_, minScoreIndex := findMinScore(scoreList, variance)
+		e.recordScoresRound("InstantRunoffRound", candidateList, scoreList, []shared.ClientID{candidateList[minScoreIndex]})
 
 		//Eliminate the least popular candidate
 		if minScoreIndex == 0 {
@@ -340,6 +381,11 @@ func (e *Election) approvalResult() shared.ClientID {
 			}
 		}
 	}
+	approvals := make([]float64, len(scoreList))
+	for i, score := range scoreList {
+		approvals[i] = float64(score)
+	}
+	e.recordScoresRound("ApprovalScores", candidateList, approvals, nil)
 	maxScore := 0
 	maxScoreIndex := 0
 	for i := 0; i < len(candidateList); i++ {
@@ -380,9 +426,14 @@ func (e *Election) majorityResult() shared.ClientID {
 // GetVotingInfo get a neccesery information to visualise in the form on gamestate.VotingInfo
 func (e *Election) GetVotingInfo() gamestate.VotingInfo {
 	return gamestate.VotingInfo{
-		RoleToElect:  e.roleToElect,
-		VotingMethod: e.votingMethod,
-		VoterList:    e.voterList,
-		Votes:        e.votes,
+		RoleToElect:     e.roleToElect,
+		VotingMethod:    e.votingMethod,
+		VoterList:       e.voterList,
+		Votes:           e.votes,
+		Rounds:          e.rounds,
+		CandidateList:   e.candidateList,
+		ElectionHeld:    e.held,
+		CountWinner:     e.countWinner,
+		AppointedWinner: e.appointedWinner,
 	}
 }
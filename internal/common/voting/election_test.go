@@ -235,3 +235,87 @@ func TestOpenBallot(t *testing.T) {
 		})
 	}
 }
+
+// fixedPreferenceVoter ranks candidates in a fixed order of preference
+type fixedPreferenceVoter struct {
+	baseclient.Client
+	preferences []shared.ClientID
+}
+
+func (v *fixedPreferenceVoter) VoteForElection(roleToElect shared.Role, candidateList []shared.ClientID) []shared.ClientID {
+	ranking := []shared.ClientID{}
+	for _, preferred := range v.preferences {
+		for _, candidate := range candidateList {
+			if candidate == preferred {
+				ranking = append(ranking, candidate)
+			}
+		}
+	}
+	return ranking
+}
+
+func TestElectionAuditTrail(t *testing.T) {
+	voters := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4, shared.Team5}
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &fixedPreferenceVoter{preferences: []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4}},
+		shared.Team2: &fixedPreferenceVoter{preferences: []shared.ClientID{shared.Team1, shared.Team3, shared.Team2, shared.Team4}},
+		shared.Team3: &fixedPreferenceVoter{preferences: []shared.ClientID{shared.Team2, shared.Team1, shared.Team4, shared.Team3}},
+		shared.Team4: &fixedPreferenceVoter{preferences: []shared.ClientID{shared.Team3, shared.Team2, shared.Team1, shared.Team4}},
+		shared.Team5: &fixedPreferenceVoter{preferences: []shared.ClientID{shared.Team4, shared.Team3, shared.Team2, shared.Team1}},
+	}
+	candidates := []shared.ClientID{shared.Team4, shared.Team2, shared.Team3, shared.Team1}
+
+	election := Election{Logger: func(format string, a ...interface{}) {}}
+	election.ProposeElection(shared.Speaker, shared.InstantRunoff)
+	election.OpenBallot(voters, candidates)
+	election.Vote(clientMap)
+	winner := election.CloseBallot(clientMap)
+	election.AppointWinner(shared.Team4)
+	info := election.GetVotingInfo()
+
+	wantCandidates := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4}
+	if !reflect.DeepEqual(info.CandidateList, wantCandidates) {
+		t.Errorf("want candidates %v, got %v", wantCandidates, info.CandidateList)
+	}
+	if !info.ElectionHeld || info.CountWinner != winner || info.AppointedWinner != shared.Team4 {
+		t.Errorf("wrong result recorded: %+v", info)
+	}
+	if info.ResultOverridden() != (winner != shared.Team4) {
+		t.Errorf("want overridden %v, got %v", winner != shared.Team4, info.ResultOverridden())
+	}
+
+	if len(info.Rounds) < 2 {
+		t.Fatalf("want candidates eliminated over several rounds, got rounds %+v", info.Rounds)
+	}
+	eliminated := map[shared.ClientID]bool{}
+	for i, round := range info.Rounds {
+		if round.Stage != "InstantRunoffRound" || len(round.Scores) != len(round.Candidates) {
+			t.Errorf("round %v malformed: %+v", i, round)
+		}
+		for _, candidate := range round.Candidates {
+			if eliminated[candidate] {
+				t.Errorf("round %v counts eliminated candidate %v", i, candidate)
+			}
+		}
+		last := i == len(info.Rounds)-1
+		if last != (len(round.Eliminated) == 0) {
+			t.Errorf("round %v: want one elimination in all but the last round, got %v", i, round.Eliminated)
+		}
+		for _, candidate := range round.Eliminated {
+			eliminated[candidate] = true
+		}
+	}
+	if eliminated[winner] {
+		t.Errorf("winner %v was eliminated", winner)
+	}
+}
+
+func TestElectionNotHeld(t *testing.T) {
+	election := Election{}
+	election.ProposeElection(shared.Judge, shared.BordaCount)
+	election.AppointWinner(shared.Team3)
+	info := election.GetVotingInfo()
+	if info.ElectionHeld || info.ResultOverridden() || info.RoleToElect != shared.Judge || info.AppointedWinner != shared.Team3 {
+		t.Errorf("wrong record of an election not held: %+v", info)
+	}
+}
@@ -14,12 +14,22 @@ type RuleVote struct {
 	voterList  []shared.ClientID
 	//Held by RuleVote
 	ballots []shared.RuleVoteType
+	weights map[shared.ClientID]float64
 	Logger  shared.Logger
 }
 
 type BallotBox struct {
-	VotesInFavour uint
-	VotesAgainst  uint
+	VotesInFavour  uint
+	VotesAgainst   uint
+	WeightInFavour float64
+	WeightAgainst  float64
+}
+
+// DecisionProcedure is the procedure by which a BallotBox decides whether a rule passes
+type DecisionProcedure struct {
+	DecisionRule  shared.RuleVoteDecisionRule
+	Quorum        float64 // fraction of eligible islands that must cast a non-abstaining ballot
+	Supermajority float64 // fraction of the cast ballot weight needed in favour by a SupermajorityRuleVote
 }
 
 // Logf is the rule vote logger
@@ -38,6 +48,12 @@ func (v *RuleVote) SetVotingIslands(clientIDs []shared.ClientID) {
 	v.voterList = clientIDs
 }
 
+// SetVoteWeights is called by baseSpeaker to set the weight of each island's ballot.
+// Islands without a weight, or all islands if weights is nil, have a ballot weight of 1.
+func (v *RuleVote) SetVoteWeights(weights map[shared.ClientID]float64) {
+	v.weights = weights
+}
+
 // GatherBallots is called by baseSpeaker to get votes from clients.
 func (v *RuleVote) GatherBallots(clientMap map[shared.ClientID]baseclient.Client) {
 	//Gather N ballots from islands
@@ -58,15 +74,75 @@ func (v *RuleVote) GetBallotBox() BallotBox {
 	for i := 0; i < len(v.ballots); i++ {
 		if v.ballots[i] == shared.Approve {
 			outcome.VotesInFavour += 1
+			outcome.WeightInFavour += v.getVoteWeight(v.voterList[i])
 		} else if v.ballots[i] == shared.Reject {
 			outcome.VotesAgainst += 1
+			outcome.WeightAgainst += v.getVoteWeight(v.voterList[i])
 		}
 	}
 	return outcome
 }
 
+func (v *RuleVote) getVoteWeight(clientID shared.ClientID) float64 {
+	if weight, ok := v.weights[clientID]; ok {
+		return weight
+	}
+	return 1
+}
+
 //CountVotesMajority is called by baseSpeaker and
 //returns the majority result of the BallotBox
 func (b *BallotBox) CountVotesMajority() bool {
 	return b.VotesInFavour > b.VotesAgainst
 }
+
+// CountVotes returns whether the rule passes under the given decision procedure, where numEligible is the number of
+// islands eligible to vote. A vote that does not reach the quorum fails.
+func (b *BallotBox) CountVotes(procedure DecisionProcedure, numEligible uint) bool {
+	quorumMet, supermajorityMet := b.MeetsThresholds(procedure, numEligible)
+	return b.Decide(procedure.DecisionRule, quorumMet, supermajorityMet)
+}
+
+// MeetsThresholds returns whether the ballots reach the quorum and the supermajority of the given decision procedure,
+// where numEligible is the number of islands eligible to vote
+func (b *BallotBox) MeetsThresholds(procedure DecisionProcedure, numEligible uint) (bool, bool) {
+	quorumMet := float64(b.VotesInFavour+b.VotesAgainst) >= procedure.Quorum*float64(numEligible)
+	supermajorityMet := b.WeightInFavour >= procedure.Supermajority*(b.WeightInFavour+b.WeightAgainst)-supermajorityTolerance
+	return quorumMet, supermajorityMet
+}
+
+// Decide returns whether the rule passes under the given decision rule, given whether the ballots reach the quorum
+// and the supermajority. A vote without any non-abstaining ballot or that does not reach the quorum fails.
+func (b *BallotBox) Decide(decisionRule shared.RuleVoteDecisionRule, quorumMet bool, supermajorityMet bool) bool {
+	if b.VotesInFavour+b.VotesAgainst == 0 || !quorumMet {
+		return false
+	}
+	switch decisionRule {
+	case shared.SupermajorityRuleVote:
+		return b.WeightInFavour > 0 && supermajorityMet
+	case shared.UnanimousRuleVote:
+		return b.VotesInFavour > 0 && b.VotesAgainst == 0
+	default:
+		return b.WeightInFavour > b.WeightAgainst
+	}
+}
+
+// Turnout returns the fraction of the numEligible islands eligible to vote that cast a non-abstaining ballot
+func (b *BallotBox) Turnout(numEligible uint) float64 {
+	if numEligible == 0 {
+		return 0
+	}
+	return float64(b.VotesInFavour+b.VotesAgainst) / float64(numEligible)
+}
+
+// Approval returns the fraction of the cast ballot weight in favour of the rule
+func (b *BallotBox) Approval() float64 {
+	totalWeight := b.WeightInFavour + b.WeightAgainst
+	if totalWeight == 0 {
+		return 0
+	}
+	return b.WeightInFavour / totalWeight
+}
+
+// supermajorityTolerance absorbs floating point error, e.g. so that 2 of 3 ballots reach a 2/3 supermajority
+const supermajorityTolerance = 1e-9
@@ -0,0 +1,152 @@
+package voting
+
+import (
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestGetBallotBoxWeights(t *testing.T) {
+	v := RuleVote{
+		voterList: []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4},
+		ballots:   []shared.RuleVoteType{shared.Approve, shared.Reject, shared.Approve, shared.Abstain},
+	}
+	v.SetVoteWeights(map[shared.ClientID]float64{shared.Team1: 2, shared.Team2: 5, shared.Team4: 10})
+
+	got := v.GetBallotBox()
+	want := BallotBox{VotesInFavour: 2, VotesAgainst: 1, WeightInFavour: 3, WeightAgainst: 5}
+	if got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestCountVotes(t *testing.T) {
+	cases := []struct {
+		name        string
+		box         BallotBox
+		procedure   DecisionProcedure
+		numEligible uint
+		want        bool
+	}{
+		{
+			name:        "majority",
+			box:         BallotBox{VotesInFavour: 2, VotesAgainst: 1, WeightInFavour: 2, WeightAgainst: 1},
+			numEligible: 3,
+			want:        true,
+		},
+		{
+			name:        "majority tie",
+			box:         BallotBox{VotesInFavour: 1, VotesAgainst: 1, WeightInFavour: 1, WeightAgainst: 1},
+			numEligible: 2,
+			want:        false,
+		},
+		{
+			name:        "weighted majority outweighed",
+			box:         BallotBox{VotesInFavour: 2, VotesAgainst: 1, WeightInFavour: 20, WeightAgainst: 50},
+			numEligible: 3,
+			want:        false,
+		},
+		{
+			name:        "no ballots cast",
+			procedure:   DecisionProcedure{DecisionRule: shared.UnanimousRuleVote},
+			numEligible: 6,
+			want:        false,
+		},
+		{
+			name:        "quorum not reached",
+			box:         BallotBox{VotesInFavour: 2, WeightInFavour: 2},
+			procedure:   DecisionProcedure{Quorum: 0.5},
+			numEligible: 6,
+			want:        false,
+		},
+		{
+			name:        "quorum reached",
+			box:         BallotBox{VotesInFavour: 2, VotesAgainst: 1, WeightInFavour: 2, WeightAgainst: 1},
+			procedure:   DecisionProcedure{Quorum: 0.5},
+			numEligible: 6,
+			want:        true,
+		},
+		{
+			name:        "two thirds supermajority reached",
+			box:         BallotBox{VotesInFavour: 2, VotesAgainst: 1, WeightInFavour: 2, WeightAgainst: 1},
+			procedure:   DecisionProcedure{DecisionRule: shared.SupermajorityRuleVote, Supermajority: 2.0 / 3.0},
+			numEligible: 3,
+			want:        true,
+		},
+		{
+			name:        "two thirds supermajority not reached",
+			box:         BallotBox{VotesInFavour: 3, VotesAgainst: 2, WeightInFavour: 3, WeightAgainst: 2},
+			procedure:   DecisionProcedure{DecisionRule: shared.SupermajorityRuleVote, Supermajority: 2.0 / 3.0},
+			numEligible: 5,
+			want:        false,
+		},
+		{
+			name:        "unanimity",
+			box:         BallotBox{VotesInFavour: 4, WeightInFavour: 4},
+			procedure:   DecisionProcedure{DecisionRule: shared.UnanimousRuleVote},
+			numEligible: 6,
+			want:        true,
+		},
+		{
+			name:        "unanimity broken",
+			box:         BallotBox{VotesInFavour: 4, VotesAgainst: 1, WeightInFavour: 4, WeightAgainst: 0},
+			procedure:   DecisionProcedure{DecisionRule: shared.UnanimousRuleVote},
+			numEligible: 6,
+			want:        false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.box.CountVotes(tc.procedure, tc.numEligible)
+			if got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTurnoutAndApproval(t *testing.T) {
+	cases := []struct {
+		name         string
+		box          BallotBox
+		numEligible  uint
+		wantTurnout  float64
+		wantApproval float64
+	}{
+		{name: "no eligible islands", numEligible: 0},
+		{name: "all abstain", numEligible: 4},
+		{
+			name:         "weighted ballots",
+			box:          BallotBox{VotesInFavour: 2, VotesAgainst: 1, WeightInFavour: 3, WeightAgainst: 1},
+			numEligible:  4,
+			wantTurnout:  0.75,
+			wantApproval: 0.75,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.box.Turnout(tc.numEligible); got != tc.wantTurnout {
+				t.Errorf("want turnout %v, got %v", tc.wantTurnout, got)
+			}
+			if got := tc.box.Approval(); got != tc.wantApproval {
+				t.Errorf("want approval %v, got %v", tc.wantApproval, got)
+			}
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	box := BallotBox{VotesInFavour: 1, VotesAgainst: 2, WeightInFavour: 1, WeightAgainst: 2}
+	if box.Decide(shared.SupermajorityRuleVote, false, true) {
+		t.Errorf("want a vote that does not reach the quorum to fail")
+	}
+	if !box.Decide(shared.SupermajorityRuleVote, true, true) {
+		t.Errorf("want a vote that reaches the supermajority to pass")
+	}
+	if box.Decide(shared.MajorityRuleVote, true, true) {
+		t.Errorf("want a majority vote to ignore the supermajority")
+	}
+	if (&BallotBox{}).Decide(shared.MajorityRuleVote, true, true) {
+		t.Errorf("want a vote without ballots to fail")
+	}
+}
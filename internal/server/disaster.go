@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
 
@@ -34,3 +35,39 @@ func (s *SOMASServer) applyDisasterEffects() {
 	s.logf("*** impact: %v, CP: %v, conf: %+v", totalResourceImpact, s.gameState.CommonPool, s.gameConfig.DisasterConfig) //island's resource will be depleted by disaster only when disaster happens and cp cannot fully mitigate
 	s.gameState.CommonPool = shared.Resources(math.Max(float64(s.gameState.CommonPool)-float64(totalResourceImpact), 0))  // deduct disaster damage from CP
 }
+
+// applyDisasterEcologyEffects kills part of the deer and fish stocks and temporarily reduces catch probabilities, in
+// proportion to the magnitude of the disaster felt by the islands (on average)
+func (s *SOMASServer) applyDisasterEcologyEffects() {
+	s.logf("start applyDisasterEcologyEffects")
+	defer s.logf("finish applyDisasterEcologyEffects")
+
+	dConf := s.gameConfig.DisasterConfig
+	felt := meanDisasterEffect(s.gameState.Environment.LastDisasterReport.Effects.Absolute)
+
+	deerLoss := foraging.DisasterStockLoss(dConf.DeerStockSensitivity, felt)
+	if deerLoss > 0 {
+		s.gameState.DeerPopulation = s.gameState.DeerPopulation.Deplete(deerLoss)
+		s.logf("Disaster killed %.0f%% of the deer population", deerLoss*100)
+	}
+	fishLoss := foraging.DisasterStockLoss(dConf.FishStockSensitivity, felt)
+	if fishLoss > 0 && s.gameConfig.ForagingConfig.FishingConfig.MaxFishPopulation > 0 { // unlimited fish stock otherwise
+		s.gameState.FishPopulation = s.gameState.FishPopulation.Deplete(fishLoss)
+		s.logf("Disaster killed %.0f%% of the fish population", fishLoss*100)
+	}
+
+	s.gameState.CatchShock = foraging.NewCatchShock(dConf, felt, s.gameState.Turn)
+	s.logf("Catch probabilities multiplied by %.2f until turn %v", s.gameState.CatchShock.Multiplier, s.gameState.CatchShock.ExpiresTurn)
+}
+
+// meanDisasterEffect returns the mean magnitude of a disaster felt by the islands
+func meanDisasterEffect(effects map[shared.ClientID]shared.Magnitude) shared.Magnitude {
+	if len(effects) == 0 {
+		return 0
+	}
+	total := shared.Magnitude(0)
+	for _, effect := range effects {
+		total += effect
+	}
+	return total / shared.Magnitude(len(effects))
+}
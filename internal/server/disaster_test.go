@@ -1,11 +1,13 @@
 package server
 
 import (
+	"math"
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -32,11 +34,16 @@ func TestDisasterEffects(t *testing.T) {
 		clientInfos[cid] = gamestate.ClientInfo{LifeStatus: shared.Alive, Resources: 100}
 	}
 
+	environment, err := disasters.InitEnvironment(clientIDs, envConf)
+	if err != nil {
+		t.Fatalf("Cannot initialise environment: %v", err)
+	}
+
 	s := SOMASServer{
 		gameState: gamestate.GameState{
 			CommonPool:  initialCP,
 			ClientInfos: clientInfos,
-			Environment: disasters.InitEnvironment(clientIDs, envConf),
+			Environment: environment,
 		},
 		clientMap: clientMap,
 	}
@@ -75,3 +82,45 @@ func (s SOMASServer) getTotalDamage(conf config.DisasterConfig) float64 {
 	return totalDamage
 
 }
+
+func TestApplyDisasterEcologyEffects(t *testing.T) {
+	deerConf := config.DeerHuntConfig{MaxDeerPopulation: 20, DeerGrowthCoefficient: 0.4}
+	fishConf := config.FishingConfig{MaxFishPopulation: 50, FishGrowthRate: 0.4}
+	logger := func(format string, a ...interface{}) {}
+
+	s := SOMASServer{
+		gameConfig: config.Config{
+			ForagingConfig: config.ForagingConfig{DeerHuntConfig: deerConf, FishingConfig: fishConf},
+			DisasterConfig: config.DisasterConfig{
+				DeerStockSensitivity:  0.1,
+				FishStockSensitivity:  0.2,
+				CatchProbabilityShock: 0.25,
+				CatchShockDuration:    3,
+			},
+		},
+		gameState: gamestate.GameState{
+			Turn:           4,
+			DeerPopulation: foraging.CreateDeerPopulationModel(deerConf, logger),
+			FishPopulation: foraging.CreateFishPopulationModel(fishConf, logger),
+			Environment: disasters.Environment{
+				LastDisasterReport: disasters.DisasterReport{
+					Effects: disasters.DisasterEffects{
+						Absolute: map[shared.ClientID]shared.Magnitude{shared.Team1: 1, shared.Team2: 3}, // felt 2 on average
+					},
+				},
+			},
+		},
+	}
+	s.applyDisasterEcologyEffects()
+
+	if got := s.gameState.DeerPopulation.Population; math.Abs(got-16) > 1e-9 {
+		t.Errorf("want deer population 16, got %v", got)
+	}
+	if got := s.gameState.FishPopulation.Population; math.Abs(got-30) > 1e-9 {
+		t.Errorf("want fish population 30, got %v", got)
+	}
+	wantShock := foraging.CatchShock{Multiplier: 0.5, ExpiresTurn: 8}
+	if s.gameState.CatchShock != wantShock {
+		t.Errorf("want catch shock %+v, got %+v", wantShock, s.gameState.CatchShock)
+	}
+}
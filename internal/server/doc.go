@@ -21,6 +21,8 @@ The current structure of the turn is as follows:
 				runIIFOEndOfTurn
 				runIITOEndOfTurn
 			runForage
+			updateForagePopulations
+			growFarmPlots
 			probeDisaster
 			incrementTurnAndSeason
 			deductCostOfLiving
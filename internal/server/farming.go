@@ -0,0 +1,71 @@
+package server
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+)
+
+// plantFarmPlots plants a new farm plot for each island that invested in farming this turn
+func (s *SOMASServer) plantFarmPlots(contributions map[shared.ClientID]shared.Resources) {
+	s.logf("start plantFarmPlots")
+	defer s.logf("finish plantFarmPlots")
+
+	for id, investment := range contributions {
+		s.gameState.FarmPlots = append(s.gameState.FarmPlots, foraging.PlantFarmPlot(id, investment, s.gameState.Turn))
+		s.logf("%v planted a farm plot with %v resources", id, investment)
+	}
+}
+
+// growFarmPlots grows the crops in all farm plots over this turn, and harvests the plots that are mature.
+// The crops of islands that died before harvest are lost.
+func (s *SOMASServer) growFarmPlots() error {
+	s.logf("start growFarmPlots")
+	defer s.logf("finish growFarmPlots")
+
+	fConf := s.gameConfig.ForagingConfig.FarmingConfig
+	growing := make([]foraging.FarmPlot, 0, len(s.gameState.FarmPlots))
+
+	for _, plot := range s.gameState.FarmPlots {
+		plot, mature := plot.Grow(fConf, s.gameState.Turn)
+		if !mature {
+			growing = append(growing, plot)
+			continue
+		}
+		if s.gameState.ClientInfos[plot.Owner].LifeStatus == shared.Dead {
+			s.logf("%v died before its farm plot could be harvested", plot.Owner)
+			continue
+		}
+
+		harvestReport := plot.Harvest(fConf)
+		harvestReport.Turn = s.gameState.Turn // update report's Turn with actual turn value
+		if s.gameState.ForagingHistory[shared.FarmForageType] == nil {
+			return errors.Errorf("Foraging history not initialised properly for %v", shared.FarmForageType)
+		}
+		s.gameState.ForagingHistory[shared.FarmForageType] = append(s.gameState.ForagingHistory[shared.FarmForageType], harvestReport)
+
+		s.distributeForageReturn(harvestReport.ParticipantContributions, harvestReport)
+
+		s.logf("Farming harvest report: %v", harvestReport.Display())
+	}
+
+	s.gameState.FarmPlots = growing
+	return nil
+}
+
+// damageFarmPlots destroys part of the standing crops in each farm plot, in proportion to the disaster magnitude felt
+// by the island that owns it
+func (s *SOMASServer) damageFarmPlots() {
+	s.logf("start damageFarmPlots")
+	defer s.logf("finish damageFarmPlots")
+
+	sensitivity := s.gameConfig.ForagingConfig.FarmingConfig.DisasterSensitivity
+	effects := s.gameState.Environment.LastDisasterReport.Effects.Absolute
+	for i, plot := range s.gameState.FarmPlots {
+		damage := math.Min(sensitivity*effects[plot.Owner], 1)
+		s.gameState.FarmPlots[i] = plot.Damage(damage)
+		s.logf("Disaster destroyed %.0f%% of %v's standing crops", damage*100, plot.Owner)
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestFarmingHarvest(t *testing.T) {
+	client1 := mockClientForage{}
+	client2 := mockClientForage{}
+	s := SOMASServer{
+		gameConfig: config.Config{
+			ForagingConfig: config.ForagingConfig{
+				FarmingConfig: config.FarmingConfig{
+					MaturityTurns:       2,
+					GrowthCurve:         shared.LinearFarmGrowth,
+					YieldMultiplier:     2,
+					DisasterSensitivity: 0.5,
+				},
+			},
+		},
+		gameState: gamestate.GameState{
+			Turn: 1,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive},
+				shared.Team2: {LifeStatus: shared.Alive},
+			},
+			ForagingHistory: map[shared.ForageType][]foraging.ForagingReport{
+				shared.FarmForageType: {},
+			},
+		},
+		clientMap: map[shared.ClientID]baseclient.Client{
+			shared.Team1: &client1,
+			shared.Team2: &client2,
+		},
+	}
+
+	s.plantFarmPlots(map[shared.ClientID]shared.Resources{shared.Team1: 10, shared.Team2: 10})
+	if err := s.growFarmPlots(); err != nil {
+		t.Fatalf("growFarmPlots error: %v", err)
+	}
+	if len(s.gameState.FarmPlots) != 2 || client1.forageUpdateCalled {
+		t.Fatalf("Expected plots to be growing after one turn, got %v", s.gameState.FarmPlots)
+	}
+
+	// disaster hits Team2 only, destroying all of its standing crops
+	s.gameState.Environment.LastDisasterReport.Effects = disasters.DisasterEffects{
+		Absolute: map[shared.ClientID]shared.Magnitude{shared.Team2: 3},
+	}
+	s.damageFarmPlots()
+
+	s.gameState.Turn++
+	if err := s.growFarmPlots(); err != nil {
+		t.Fatalf("growFarmPlots error: %v", err)
+	}
+	if len(s.gameState.FarmPlots) != 0 {
+		t.Errorf("Expected all plots to be harvested, got %v", s.gameState.FarmPlots)
+	}
+	if !client1.forageUpdateCalled || client1.gotForageDecision.Type != shared.FarmForageType {
+		t.Errorf("Expected ForageUpdate to be called on harvest")
+	}
+
+	wantResources := map[shared.ClientID]shared.Resources{shared.Team1: 20, shared.Team2: 10}
+	for id, want := range wantResources {
+		if got := s.gameState.ClientInfos[id].Resources; math.Abs(float64(got-want)) > 1e-9 {
+			t.Errorf("%v: want %v resources, got %v", id, want, got)
+		}
+	}
+	if len(s.gameState.ForagingHistory[shared.FarmForageType]) != 2 {
+		t.Errorf("Expected two harvests in the foraging history, got %v", s.gameState.ForagingHistory[shared.FarmForageType])
+	}
+}
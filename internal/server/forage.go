@@ -11,6 +11,14 @@ func (s *SOMASServer) runForage() error {
 	s.logf("start runForage")
 	defer s.logf("finish runForage")
 
+	parties := s.formHuntingParties()
+	s.huntingParties = parties
+	defer func() { s.huntingParties = nil }()
+	partyContributions := make([]map[shared.ClientID]shared.Resources, len(parties))
+	for i := range partyContributions {
+		partyContributions[i] = make(map[shared.ClientID]shared.Resources)
+	}
+
 	foragingParticipants, err := s.getForagingDecisions()
 	if err != nil {
 		return errors.Errorf("Something went wrong getting the foraging decision:%v", err)
@@ -18,6 +26,7 @@ func (s *SOMASServer) runForage() error {
 
 	deerHunters := make(map[shared.ClientID]shared.Resources)
 	fishers := make(map[shared.ClientID]shared.Resources)
+	farmers := make(map[shared.ClientID]shared.Resources)
 
 	// used to keep track of groups choosing different foraging types
 	// this allows different foraging types to be added in future without having
@@ -31,11 +40,26 @@ func (s *SOMASServer) runForage() error {
 			partyContributions: &fishers,
 			takeResourceReason: "fishing participation",
 		},
+		shared.FarmForageType: {
+			partyContributions: &farmers,
+			takeResourceReason: "farming investment",
+		},
 	}
 
 	for id, decision := range foragingParticipants {
 
-		if !shared.IsValidForageType(decision.Type) {
+		if i, ok := getHuntingParty(parties, id); ok {
+			// party members are bound to the terms of their party and forage separately from other islands
+			decision = enforceHuntingPartyTerms(parties[i], decision)
+			err := s.takeResources(id, decision.Contribution, "hunting party commitment")
+			if err != nil {
+				s.logf("%v did not have enough resources to honour its hunting party commitment", id)
+			} else if decision.Contribution > 0.0 {
+				partyContributions[i][id] = decision.Contribution
+			} else {
+				s.logf("%v did not contribute resources and will not participate in its hunting party.", id)
+			}
+		} else if !shared.IsValidForageType(decision.Type) {
 			s.logf("%v client selected invalid forag type in foraging decision: ", decision.Type)
 		} else {
 			forageGroup := forageGroups[decision.Type]
@@ -54,7 +78,7 @@ func (s *SOMASServer) runForage() error {
 	}
 
 	if len(deerHunters) > 0 {
-		errD := s.runDeerHunt(deerHunters)
+		errD := s.runDeerHunt(deerHunters, nil)
 
 		if errD != nil {
 			return errors.Errorf("Deer hunt returned with an error: %v", errD)
@@ -62,13 +86,33 @@ func (s *SOMASServer) runForage() error {
 	}
 
 	if len(fishers) > 0 {
-		errF := s.runFishingExpedition(fishers)
+		errF := s.runFishingExpedition(fishers, nil)
 
 		if errF != nil {
 			return errors.Errorf("Fishing expedition returned with an error: %v", errF)
 		}
 	}
 
+	if len(farmers) > 0 {
+		s.plantFarmPlots(farmers) // farming returns are only distributed at harvest
+	}
+
+	for i, party := range parties {
+		if len(partyContributions[i]) == 0 {
+			continue
+		}
+		party := party
+		var errP error
+		if party.ForageType == shared.FishForageType {
+			errP = s.runFishingExpedition(partyContributions[i], &party)
+		} else {
+			errP = s.runDeerHunt(partyContributions[i], &party)
+		}
+		if errP != nil {
+			return errors.Errorf("Hunting party of %v returned with an error: %v", party.Proposer, errP)
+		}
+	}
+
 	return nil
 }
 
@@ -93,11 +137,13 @@ func (s *SOMASServer) getForagingDecisions() (shared.ForagingDecisionsDict, erro
 	return participants, nil
 }
 
-func (s *SOMASServer) runDeerHunt(contributions map[shared.ClientID]shared.Resources) error {
+// runDeerHunt runs a deer hunt with the given contributions. party is the hunting party running the hunt, or nil if
+// the hunt is open to all islands.
+func (s *SOMASServer) runDeerHunt(contributions map[shared.ClientID]shared.Resources, party *shared.HuntingParty) error {
 	s.logf("start runDeerHunt")
 	defer s.logf("finish runDeerHunt")
 
-	dhConf := s.gameConfig.ForagingConfig.DeerHuntConfig
+	dhConf := s.gameState.CatchShock.ShockDeerHuntConfig(s.gameConfig.ForagingConfig.DeerHuntConfig, s.gameState.Turn)
 
 	hunt, err := foraging.CreateDeerHunt(
 		contributions,
@@ -108,8 +154,9 @@ func (s *SOMASServer) runDeerHunt(contributions map[shared.ClientID]shared.Resou
 		return errors.Errorf("Error running deer hunt: %v", err)
 	}
 
-	huntReport := hunt.Hunt(dhConf, uint(s.gameState.DeerPopulation.Population))
+	huntReport := hunt.Hunt(dhConf, s.getUncaughtPopulation(shared.DeerForageType, s.gameState.DeerPopulation.Population))
 	huntReport.Turn = s.gameState.Turn // update report's Turn with actual turn value
+	huntReport.Party = party
 	// update foraging history
 	if s.gameState.ForagingHistory[shared.DeerForageType] == nil {
 		return errors.Errorf("Foraging history not initialised properly: %v", err)
@@ -120,21 +167,12 @@ func (s *SOMASServer) runDeerHunt(contributions map[shared.ClientID]shared.Resou
 
 	s.logf("Deer hunt report: %v", huntReport.Display())
 
-	// update deer population // TODO: decide if there is a better place to do this
-	s.logf("Updating deer population after %v deer hunted", huntReport.NumberCaught)
-	s.updateDeerPopulation(huntReport.NumberCaught) // update deer population based on hunt
-
 	return nil
 }
 
 func (s *SOMASServer) distributeForageReturn(contributions map[shared.ClientID]shared.Resources, huntReport foraging.ForagingReport) {
 	// distribute return amongst participants
 
-	totalContributions := shared.Resources(0)
-	for _, c := range huntReport.ParticipantContributions {
-		totalContributions += c
-	}
-
 	if len(huntReport.ParticipantContributions) == 0 {
 		return // to prevent div0 below. Also, no need to evaluate further
 	}
@@ -145,29 +183,32 @@ func (s *SOMASServer) distributeForageReturn(contributions map[shared.ClientID]s
 		resourceReturnReason string
 	}
 
-	for participantID, contribution := range contributions {
-		deerReturnStrat := s.gameConfig.ForagingConfig.DeerHuntConfig.DistributionStrategy
-		fishReturnStrat := s.gameConfig.ForagingConfig.FishingConfig.DistributionStrategy
+	fConf := s.gameConfig.ForagingConfig
+	returnInfoPerType := map[shared.ForageType]auxReturnInfo{
+		shared.DeerForageType: {distrStrategy: s.getForageSplitStrategy(shared.DeerForageType), resourceReturnReason: "Deer hunt return"},
+		shared.FishForageType: {distrStrategy: s.getForageSplitStrategy(shared.FishForageType), resourceReturnReason: "Fishing return"},
+		shared.FarmForageType: {distrStrategy: s.getForageSplitStrategy(shared.FarmForageType), resourceReturnReason: "Farming harvest"},
+	}
 
-		returnInfoPerType := map[shared.ForageType]auxReturnInfo{
-			shared.DeerForageType: {distrStrategy: deerReturnStrat, resourceReturnReason: "Deer hunt return"},
-			shared.FishForageType: {distrStrategy: fishReturnStrat, resourceReturnReason: "Fishing return"},
-		}
-		participantReturn := shared.Resources(0.0)      // default to zero return
-		retReason := "Unspecified foraging return type" // default if f. type not found
-		// check if the foraging type has been specified above
-		if r, ok := returnInfoPerType[huntReport.ForageType]; ok {
-			retReason = r.resourceReturnReason
-
-			if totalContributions > 0.0 {
-				switch r.distrStrategy {
-				case shared.InputProportionalSplit:
-					participantReturn = (contribution / totalContributions) * huntReport.TotalUtility
-				case shared.EqualSplit, shared.RankProportionalSplit: // RankProportional is just same as equal split for now
-					participantReturn = huntReport.TotalUtility / shared.Resources(len(huntReport.ParticipantContributions)) // this casting is a bit lazy
-				}
-			}
+	participantReturns := map[shared.ClientID]shared.Resources{} // default to zero return
+	retReason := "Unspecified foraging return type"              // default if f. type not found
+	// check if the foraging type has been specified above
+	if r, ok := returnInfoPerType[huntReport.ForageType]; ok {
+		if huntReport.Party != nil { // hunting parties split their return as agreed
+			r = auxReturnInfo{distrStrategy: huntReport.Party.SplitStrategy, resourceReturnReason: "Hunting party return"}
 		}
+		retReason = r.resourceReturnReason
+		participantReturns = foraging.SplitForageReturn(
+			huntReport.TotalUtility,
+			huntReport.ParticipantContributions,
+			r.distrStrategy,
+			s.getForageRankScores(huntReport),
+			fConf.MinimumShare,
+		)
+	}
+
+	for participantID, contribution := range contributions {
+		participantReturn := participantReturns[participantID]
 
 		err := s.giveResources(participantID, participantReturn, retReason)
 		if err != nil {
@@ -180,19 +221,56 @@ func (s *SOMASServer) distributeForageReturn(contributions map[shared.ClientID]s
 	}
 }
 
-func (s *SOMASServer) runFishingExpedition(contributions map[shared.ClientID]shared.Resources) error {
+// getForageSplitStrategy returns the strategy by which the return of an open foraging session of the given type is split
+func (s *SOMASServer) getForageSplitStrategy(forageType shared.ForageType) shared.ResourceDistributionStrategy {
+	switch forageType {
+	case shared.DeerForageType:
+		return s.gameConfig.ForagingConfig.DeerHuntConfig.DistributionStrategy
+	case shared.FishForageType:
+		return s.gameConfig.ForagingConfig.FishingConfig.DistributionStrategy
+	default:
+		return shared.InputProportionalSplit // farm plots have a single owner
+	}
+}
+
+// getForageRankScores returns the scores by which participants are ranked in RankProportionalSplit, according to the
+// configured RankingBasis. Higher score => higher rank.
+func (s *SOMASServer) getForageRankScores(huntReport foraging.ForagingReport) map[shared.ClientID]float64 {
+	scores := map[shared.ClientID]float64{}
+	switch s.gameConfig.ForagingConfig.RankingBasis {
+	case shared.CumulativeContributionRanking:
+		cumulative := foraging.CumulativeContributions(s.gameState.ForagingHistory)
+		for id := range huntReport.ParticipantContributions {
+			scores[id] = float64(cumulative[id])
+		}
+	case shared.SanctionRanking:
+		for id := range huntReport.ParticipantContributions {
+			scores[id] = -float64(s.gameState.IIGOSanctionMap[id]) // fewer sanctions => higher rank
+		}
+	default: // shared.ContributionRanking
+		for id, c := range huntReport.ParticipantContributions {
+			scores[id] = float64(c)
+		}
+	}
+	return scores
+}
+
+// runFishingExpedition runs a fishing expedition with the given contributions. party is the hunting party running the
+// expedition, or nil if the expedition is open to all islands.
+func (s *SOMASServer) runFishingExpedition(contributions map[shared.ClientID]shared.Resources, party *shared.HuntingParty) error {
 	s.logf("start runFishHunt")
 	defer s.logf("finish runFishHunt")
 
-	fConf := s.gameConfig.ForagingConfig.FishingConfig
+	fConf := s.gameState.CatchShock.ShockFishingConfig(s.gameConfig.ForagingConfig.FishingConfig, s.gameState.Turn)
 
 	huntF, err := foraging.CreateFishingExpedition(contributions, fConf, s.logf)
 	if err != nil {
 		return errors.Errorf("Error running fish hunt: %v", err)
 	}
-	fishingReport := huntF.Fish(fConf)
+	fishingReport := huntF.Fish(fConf, s.getUncaughtPopulation(shared.FishForageType, s.gameState.FishPopulation.Population))
 
 	fishingReport.Turn = s.gameState.Turn // update report's Turn with actual turn value
+	fishingReport.Party = party
 	if s.gameState.ForagingHistory[shared.DeerForageType] == nil {
 		return errors.Errorf("Foraging history not initialised properly: %v", err)
 	}
@@ -206,8 +284,69 @@ func (s *SOMASServer) runFishingExpedition(contributions map[shared.ClientID]sha
 	return nil
 }
 
+// updateForagePopulations steps the population of every forage type by one turn. The animals caught in this turn's
+// foraging sessions are removed before regeneration (zero consumption if nobody foraged). The resulting populations are
+// recorded in the population history.
+func (s *SOMASServer) updateForagePopulations() {
+	s.logf("start updateForagePopulations")
+	defer s.logf("finish updateForagePopulations")
+
+	consumption := s.getTurnConsumption()
+
+	s.logf("Updating deer population after %v deer hunted", consumption[shared.DeerForageType])
+	s.updateDeerPopulation(consumption[shared.DeerForageType])
+	s.recordPopulation(shared.DeerForageType, s.gameState.DeerPopulation.Population, consumption[shared.DeerForageType])
+
+	if s.gameConfig.ForagingConfig.FishingConfig.MaxFishPopulation > 0 { // unlimited fish stock otherwise
+		s.logf("Updating fish population after %v fish caught", consumption[shared.FishForageType])
+		s.updateFishPopulation(consumption[shared.FishForageType])
+		s.recordPopulation(shared.FishForageType, s.gameState.FishPopulation.Population, consumption[shared.FishForageType])
+	}
+}
+
+// getUncaughtPopulation returns the population of a forage type less the animals already caught in this turn. This
+// keeps several foraging sessions of the same type in one turn (e.g. hunting parties) from catching the same animals.
+func (s *SOMASServer) getUncaughtPopulation(forageType shared.ForageType, population float64) uint {
+	uncaught := population - float64(s.getTurnConsumption()[forageType])
+	if uncaught < 0 {
+		return 0
+	}
+	return uint(uncaught)
+}
+
+// getTurnConsumption returns the number of animals caught in this turn for each forage type
+func (s *SOMASServer) getTurnConsumption() map[shared.ForageType]uint {
+	consumption := map[shared.ForageType]uint{}
+	for forageType, reports := range s.gameState.ForagingHistory {
+		for _, report := range reports {
+			if report.Turn == s.gameState.Turn {
+				consumption[forageType] += report.NumberCaught
+			}
+		}
+	}
+	return consumption
+}
+
+// recordPopulation appends the end of turn population of a forage type to the population history
+func (s *SOMASServer) recordPopulation(forageType shared.ForageType, population float64, consumption uint) {
+	if s.gameState.PopulationHistory == nil {
+		s.gameState.PopulationHistory = map[shared.ForageType][]foraging.PopulationRecord{}
+	}
+	s.gameState.PopulationHistory[forageType] = append(s.gameState.PopulationHistory[forageType], foraging.PopulationRecord{
+		Turn:        s.gameState.Turn,
+		Population:  population,
+		Consumption: consumption,
+	})
+}
+
 // updateDeerPopulation adjusts deer pop. based on consumption of deer after hunt
 func (s *SOMASServer) updateDeerPopulation(consumption uint) {
 	updatedModel := s.gameState.DeerPopulation.Simulate([]int{int(consumption)}) // updates pop. according to DE definition
 	s.gameState.DeerPopulation = updatedModel
 }
+
+// updateFishPopulation adjusts fish pop. based on consumption of fish after fishing expedition
+func (s *SOMASServer) updateFishPopulation(consumption uint) {
+	updatedModel := s.gameState.FishPopulation.Simulate([]int{int(consumption)}) // updates pop. according to DE definition
+	s.gameState.FishPopulation = updatedModel
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
@@ -17,6 +18,23 @@ type mockClientForage struct {
 	forageDecision     shared.ForageDecision
 	forageUpdateCalled bool
 	gotForageDecision  shared.ForageDecision
+
+	partyProposal shared.HuntingPartyProposal
+	joinParty     shared.ClientID
+	acceptsParty  bool
+	gotParties    []shared.HuntingParty
+}
+
+func (c mockClientForage) ProposeHuntingParty() shared.HuntingPartyProposal {
+	return c.partyProposal
+}
+
+func (c mockClientForage) JoinHuntingParty(invitations shared.HuntingPartyProposalDict) (shared.ClientID, bool) {
+	return c.joinParty, c.acceptsParty
+}
+
+func (c *mockClientForage) HuntingPartyUpdate(parties []shared.HuntingParty) {
+	c.gotParties = parties
 }
 
 func (c mockClientForage) DecideForage() (shared.ForageDecision, error) {
@@ -76,6 +94,11 @@ func TestForagingCallsForageUpdate(t *testing.T) {
 					t.Logf("[FORAGING]: %v", fmt.Sprintf(format, a...))
 				}
 
+				environment, err := disasters.InitEnvironment(clientIDs, envConf)
+				if err != nil {
+					t.Fatalf("Cannot initialise environment: %v", err)
+				}
+
 				s := SOMASServer{
 					gameState: gamestate.GameState{
 						ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
@@ -89,11 +112,11 @@ func TestForagingCallsForageUpdate(t *testing.T) {
 							shared.FishForageType: make([]foraging.ForagingReport, 0),
 						},
 						DeerPopulation: foraging.CreateDeerPopulationModel(deerConf, dummyLogger),
-						Environment:    disasters.InitEnvironment(clientIDs, envConf),
+						Environment:    environment,
 					},
 					clientMap: clientMap,
 				}
-				err := s.runForage()
+				err = s.runForage()
 
 				if err != nil {
 					t.Errorf("runForage error: %v", err)
@@ -111,3 +134,104 @@ func TestForagingCallsForageUpdate(t *testing.T) {
 
 	}
 }
+
+func TestUpdateForagePopulations(t *testing.T) {
+	deerConf := config.DeerHuntConfig{
+		MaxDeerPopulation:     20,
+		DeerGrowthCoefficient: 0.4,
+	}
+	fishConf := config.FishingConfig{
+		MaxFishPopulation: 50,
+		FishGrowthRate:    0.4,
+	}
+	dummyLogger := func(format string, a ...interface{}) {
+		t.Logf("[FORAGING]: %v", fmt.Sprintf(format, a...))
+	}
+
+	s := SOMASServer{
+		gameConfig: config.Config{
+			ForagingConfig: config.ForagingConfig{DeerHuntConfig: deerConf, FishingConfig: fishConf},
+		},
+		gameState: gamestate.GameState{
+			Turn: 1,
+			ForagingHistory: map[shared.ForageType][]foraging.ForagingReport{
+				shared.DeerForageType: {},
+				shared.FishForageType: {{ForageType: shared.FishForageType, NumberCaught: 10, Turn: 1}},
+			},
+			DeerPopulation: foraging.CreateDeerPopulationModel(deerConf, dummyLogger),
+			FishPopulation: foraging.CreateFishPopulationModel(fishConf, dummyLogger),
+		},
+	}
+
+	for turn := uint(1); turn <= 3; turn++ {
+		s.gameState.Turn = turn
+		s.updateForagePopulations()
+	}
+
+	// populations are stepped every turn, even if nobody foraged
+	if s.gameState.DeerPopulation.T != 3 || s.gameState.FishPopulation.T != 3 {
+		t.Errorf("Expected populations to be stepped once per turn. Got deer T=%v, fish T=%v", s.gameState.DeerPopulation.T, s.gameState.FishPopulation.T)
+	}
+
+	fishHistory := s.gameState.PopulationHistory[shared.FishForageType]
+	if len(fishHistory) != 3 || len(s.gameState.PopulationHistory[shared.DeerForageType]) != 3 {
+		t.Fatalf("Expected one population record per turn, got %v", s.gameState.PopulationHistory)
+	}
+	if fishHistory[0].Consumption != 10 || fishHistory[1].Consumption != 0 {
+		t.Errorf("Expected fish consumption of 10 in turn 1 only, got %v", fishHistory)
+	}
+	if fishHistory[0].Population >= 50 || fishHistory[2].Population <= fishHistory[0].Population {
+		t.Errorf("Expected fish population to be depleted and then regenerate, got %v", fishHistory)
+	}
+	for i, record := range fishHistory {
+		if record.Turn != uint(i+1) {
+			t.Errorf("Expected record %v to be for turn %v, got %v", i, i+1, record.Turn)
+		}
+	}
+}
+
+func TestGetForageRankScores(t *testing.T) {
+	report := foraging.ForagingReport{
+		ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team1: 5, shared.Team2: 10},
+	}
+	cases := []struct {
+		name  string
+		basis shared.RankingBasis
+		want  map[shared.ClientID]float64
+	}{
+		{
+			name:  "contribution",
+			basis: shared.ContributionRanking,
+			want:  map[shared.ClientID]float64{shared.Team1: 5, shared.Team2: 10},
+		},
+		{
+			name:  "cumulative contribution",
+			basis: shared.CumulativeContributionRanking,
+			want:  map[shared.ClientID]float64{shared.Team1: 25, shared.Team2: 10},
+		},
+		{
+			name:  "sanctions",
+			basis: shared.SanctionRanking,
+			want:  map[shared.ClientID]float64{shared.Team1: 0, shared.Team2: -3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := SOMASServer{
+				gameConfig: config.Config{ForagingConfig: config.ForagingConfig{RankingBasis: tc.basis}},
+				gameState: gamestate.GameState{
+					ForagingHistory: map[shared.ForageType][]foraging.ForagingReport{
+						shared.DeerForageType: {{ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team1: 20}}},
+						shared.FishForageType: {report},
+					},
+					IIGOSanctionMap: map[shared.ClientID]shared.Resources{shared.Team2: 3},
+				},
+			}
+			got := s.getForageRankScores(report)
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+)
+
+// estimateForageReturn estimates the distribution of the return to an island contributing contribution to a foraging
+// session of forageType, given the known contributions of other islands and the population not yet caught in this turn.
+// If the island is in a hunting party of forageType, the party's terms apply: its split strategy is used and members
+// whose contributions are not known are assumed to contribute the party's minimum commitment.
+func (s *SOMASServer) estimateForageReturn(
+	id shared.ClientID,
+	forageType shared.ForageType,
+	contribution shared.Resources,
+	otherContributions map[shared.ClientID]shared.Resources,
+) (foraging.ReturnDistribution, error) {
+	fConf := s.gameConfig.ForagingConfig
+	if !fConf.ReturnOracleVisible {
+		return foraging.ReturnDistribution{}, errors.Errorf("Foraging return oracle is not visible to clients")
+	}
+	fConf.DeerHuntConfig = s.gameState.CatchShock.ShockDeerHuntConfig(fConf.DeerHuntConfig, s.gameState.Turn)
+	fConf.FishingConfig = s.gameState.CatchShock.ShockFishingConfig(fConf.FishingConfig, s.gameState.Turn)
+
+	contributions := map[shared.ClientID]shared.Resources{}
+	for other, c := range otherContributions {
+		if other != id {
+			contributions[other] = c
+		}
+	}
+	contributions[id] = contribution
+	strategy := s.getForageSplitStrategy(forageType)
+
+	if i, ok := getHuntingParty(s.huntingParties, id); ok && s.huntingParties[i].ForageType == forageType {
+		party := s.huntingParties[i]
+		strategy = party.SplitStrategy
+		for _, member := range party.Members {
+			if _, known := contributions[member]; !known {
+				contributions[member] = party.MinimumCommitment
+			}
+		}
+		contributions[id] = shared.Resources(math.Max(float64(contribution), float64(party.MinimumCommitment)))
+	}
+
+	var population uint
+	switch forageType {
+	case shared.DeerForageType:
+		population = s.getUncaughtPopulation(forageType, s.gameState.DeerPopulation.Population)
+	case shared.FishForageType:
+		population = s.getUncaughtPopulation(forageType, s.gameState.FishPopulation.Population)
+	}
+
+	return foraging.EstimateForageReturn(
+		foraging.ForageReturnQuery{
+			ForageType:    forageType,
+			Participant:   id,
+			Contributions: contributions,
+			Population:    population,
+			SplitStrategy: strategy,
+			RankScores:    s.getForageRankScores(foraging.ForagingReport{ParticipantContributions: contributions}),
+		},
+		fConf,
+		fConf.ReturnOracleSamples,
+	)
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestEstimateForageReturnVisibility(t *testing.T) {
+	s := SOMASServer{
+		gameConfig: config.Config{ForagingConfig: config.ForagingConfig{ReturnOracleSamples: 10}},
+	}
+	if _, err := s.estimateForageReturn(shared.Team1, shared.FarmForageType, 10, nil); err == nil {
+		t.Errorf("expected an error when the oracle is not visible")
+	}
+	s.gameConfig.ForagingConfig.ReturnOracleVisible = true
+	if _, err := s.estimateForageReturn(shared.Team1, shared.FarmForageType, 10, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEstimateForageReturnHuntingParty(t *testing.T) {
+	s := SOMASServer{
+		gameConfig: config.Config{
+			ForagingConfig: config.ForagingConfig{
+				FishingConfig: config.FishingConfig{
+					MaxFishPerHunt:        6,
+					IncrementalInputDecay: 0.8,
+					Mean:                  1,
+					Variance:              0.1,
+					InputScaler:           1,
+					OutputScaler:          1,
+					DistributionStrategy:  shared.InputProportionalSplit,
+				},
+				ReturnOracleVisible: true,
+				ReturnOracleSamples: 200,
+			},
+		},
+		huntingParties: []shared.HuntingParty{{
+			Proposer:          shared.Team1,
+			Members:           []shared.ClientID{shared.Team1, shared.Team2},
+			ForageType:        shared.FishForageType,
+			SplitStrategy:     shared.EqualSplit,
+			MinimumCommitment: 4,
+		}},
+	}
+
+	// Team1 is committed to at least 4 and Team2 is assumed to contribute 4. The return is split equally.
+	party, err := s.estimateForageReturn(shared.Team1, shared.FishForageType, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if party.Mean <= 0 {
+		t.Errorf("expected a positive return from the party's commitments, got %+v", party)
+	}
+
+	// outside the party, Team3 contributing nothing gets nothing
+	open, err := s.estimateForageReturn(shared.Team3, shared.FishForageType, 0, map[shared.ClientID]shared.Resources{shared.Team4: 8})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if open.Mean != 0 {
+		t.Errorf("expected no return without a contribution, got %+v", open)
+	}
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"math"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// formHuntingParties runs the hunting party session before foraging. Islands propose parties, invited islands accept
+// at most one invitation, and every proposal with at least one accepted invitation forms a binding party. The formed
+// parties are announced to all alive islands.
+func (s *SOMASServer) formHuntingParties() []shared.HuntingParty {
+	s.logf("start formHuntingParties")
+	defer s.logf("finish formHuntingParties")
+
+	proposals := s.getHuntingPartyProposals()
+	acceptances := s.getHuntingPartyAcceptances(proposals)
+
+	proposers := make([]shared.ClientID, 0, len(acceptances))
+	for proposer := range acceptances {
+		proposers = append(proposers, proposer)
+	}
+	sort.Sort(shared.SortClientByID(proposers))
+
+	parties := []shared.HuntingParty{}
+	for _, proposer := range proposers {
+		members := append([]shared.ClientID{proposer}, acceptances[proposer]...)
+		sort.Sort(shared.SortClientByID(members))
+		proposal := proposals[proposer]
+		party := shared.HuntingParty{
+			Proposer:          proposer,
+			Members:           members,
+			ForageType:        proposal.ForageType,
+			SplitStrategy:     proposal.SplitStrategy,
+			MinimumCommitment: proposal.MinimumCommitment,
+		}
+		s.logf("Hunting party formed: %+v", party)
+		parties = append(parties, party)
+	}
+
+	for _, id := range getNonDeadClientIDs(s.gameState.ClientInfos) {
+		partiesCopy := make([]shared.HuntingParty, len(parties))
+		for i, party := range parties {
+			partiesCopy[i] = party.Copy()
+		}
+		s.clientMap[id].HuntingPartyUpdate(partiesCopy)
+	}
+
+	return parties
+}
+
+// getHuntingPartyProposals collects the hunting party proposals of all alive islands. Invalid proposals and proposals
+// without valid invitees are dropped.
+func (s *SOMASServer) getHuntingPartyProposals() shared.HuntingPartyProposalDict {
+	proposals := shared.HuntingPartyProposalDict{}
+	for _, id := range getNonDeadClientIDs(s.gameState.ClientInfos) {
+		proposal := s.sanitiseHuntingPartyProposal(s.clientMap[id].ProposeHuntingParty(), id)
+		if len(proposal.Invitees) > 0 {
+			proposals[id] = proposal
+		}
+	}
+	return proposals
+}
+
+// sanitiseHuntingPartyProposal removes dead, duplicate and self invitees from a proposal. Proposals with invalid terms
+// (farming, an unknown forage type or split strategy, or an invalid commitment) have all their invitees removed.
+func (s *SOMASServer) sanitiseHuntingPartyProposal(proposal shared.HuntingPartyProposal, proposer shared.ClientID) shared.HuntingPartyProposal {
+	_, strategyErr := shared.ParseResourceDistributionStrategy(int(proposal.SplitStrategy))
+	commitment := float64(proposal.MinimumCommitment)
+	if !shared.IsValidForageType(proposal.ForageType) || proposal.ForageType == shared.FarmForageType ||
+		strategyErr != nil || math.IsNaN(commitment) || math.IsInf(commitment, 0) || commitment < 0 {
+		s.logf("%v proposed a hunting party with invalid terms: %+v", proposer, proposal)
+		proposal.Invitees = []shared.ClientID{}
+		return proposal
+	}
+
+	seen := map[shared.ClientID]bool{}
+	invitees := []shared.ClientID{}
+	for _, invitee := range proposal.Invitees {
+		info, ok := s.gameState.ClientInfos[invitee]
+		if !ok || info.LifeStatus == shared.Dead || invitee == proposer || seen[invitee] {
+			continue
+		}
+		seen[invitee] = true
+		invitees = append(invitees, invitee)
+	}
+	proposal.Invitees = invitees
+	return proposal
+}
+
+// getHuntingPartyAcceptances passes each invited island the proposals inviting it and returns the islands that
+// accepted each proposal, keyed by proposer. Only proposals with at least one acceptance are returned.
+// Islands that proposed a party are committed to their own party and are therefore not invited to others.
+func (s *SOMASServer) getHuntingPartyAcceptances(proposals shared.HuntingPartyProposalDict) map[shared.ClientID][]shared.ClientID {
+	invitations := map[shared.ClientID]shared.HuntingPartyProposalDict{}
+	for proposer, proposal := range proposals {
+		for _, invitee := range proposal.Invitees {
+			if _, isProposer := proposals[invitee]; isProposer {
+				continue
+			}
+			if invitations[invitee] == nil {
+				invitations[invitee] = shared.HuntingPartyProposalDict{}
+			}
+			invitations[invitee][proposer] = proposal
+		}
+	}
+
+	acceptances := map[shared.ClientID][]shared.ClientID{}
+	for invitee, received := range invitations {
+		proposer, accepted := s.clientMap[invitee].JoinHuntingParty(received)
+		if !accepted {
+			continue
+		}
+		if _, ok := received[proposer]; !ok {
+			s.logf("%v tried to join a hunting party it was not invited to (proposer %v)", invitee, proposer)
+			continue
+		}
+		acceptances[proposer] = append(acceptances[proposer], invitee)
+	}
+	return acceptances
+}
+
+// getHuntingParty returns the index of the hunting party an island is a member of
+func getHuntingParty(parties []shared.HuntingParty, id shared.ClientID) (int, bool) {
+	for i, party := range parties {
+		if party.IsMember(id) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// enforceHuntingPartyTerms binds a party member's foraging decision to the terms of its party: the member forages in
+// the party's forage type with at least the party's minimum commitment.
+func enforceHuntingPartyTerms(party shared.HuntingParty, decision shared.ForageDecision) shared.ForageDecision {
+	return shared.ForageDecision{
+		Type:         party.ForageType,
+		Contribution: shared.Resources(math.Max(float64(decision.Contribution), float64(party.MinimumCommitment))),
+	}
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestFormHuntingParties(t *testing.T) {
+	deerProposal := shared.HuntingPartyProposal{
+		ForageType:        shared.DeerForageType,
+		Invitees:          []shared.ClientID{shared.Team2, shared.Team3, shared.Team4},
+		SplitStrategy:     shared.EqualSplit,
+		MinimumCommitment: 5,
+	}
+	clients := map[shared.ClientID]*mockClientForage{
+		shared.Team1: {partyProposal: deerProposal},
+		shared.Team2: { // proposer, so not invited to Team1's party
+			partyProposal: shared.HuntingPartyProposal{ForageType: shared.FishForageType, Invitees: []shared.ClientID{shared.Team3}},
+			joinParty:     shared.Team1,
+			acceptsParty:  true,
+		},
+		shared.Team3: {joinParty: shared.Team1, acceptsParty: true},
+		shared.Team4: {joinParty: shared.Team1, acceptsParty: false},
+	}
+	clientMap := map[shared.ClientID]baseclient.Client{}
+	clientInfos := map[shared.ClientID]gamestate.ClientInfo{}
+	for id, c := range clients {
+		clientMap[id] = c
+		clientInfos[id] = gamestate.ClientInfo{LifeStatus: shared.Alive}
+	}
+	s := SOMASServer{
+		gameState: gamestate.GameState{ClientInfos: clientInfos},
+		clientMap: clientMap,
+	}
+
+	got := s.formHuntingParties()
+	want := []shared.HuntingParty{{
+		Proposer:          shared.Team1,
+		Members:           []shared.ClientID{shared.Team1, shared.Team3},
+		ForageType:        shared.DeerForageType,
+		SplitStrategy:     shared.EqualSplit,
+		MinimumCommitment: 5,
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	for id, c := range clients {
+		if !reflect.DeepEqual(c.gotParties, want) {
+			t.Errorf("%v was told of parties %v, want %v", id, c.gotParties, want)
+		}
+	}
+}
+
+func TestSanitiseHuntingPartyProposal(t *testing.T) {
+	s := SOMASServer{
+		gameState: gamestate.GameState{
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive},
+				shared.Team2: {LifeStatus: shared.Critical},
+				shared.Team3: {LifeStatus: shared.Dead},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		proposal shared.HuntingPartyProposal
+		want     []shared.ClientID
+	}{
+		{
+			name:     "dead, self and duplicate invitees removed",
+			proposal: shared.HuntingPartyProposal{Invitees: []shared.ClientID{shared.Team1, shared.Team2, shared.Team2, shared.Team3, shared.Team4}},
+			want:     []shared.ClientID{shared.Team2},
+		},
+		{
+			name:     "farming",
+			proposal: shared.HuntingPartyProposal{ForageType: shared.FarmForageType, Invitees: []shared.ClientID{shared.Team2}},
+			want:     []shared.ClientID{},
+		},
+		{
+			name:     "invalid split strategy",
+			proposal: shared.HuntingPartyProposal{SplitStrategy: -1, Invitees: []shared.ClientID{shared.Team2}},
+			want:     []shared.ClientID{},
+		},
+		{
+			name:     "negative commitment",
+			proposal: shared.HuntingPartyProposal{MinimumCommitment: -1, Invitees: []shared.ClientID{shared.Team2}},
+			want:     []shared.ClientID{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := s.sanitiseHuntingPartyProposal(tc.proposal, shared.Team1)
+			if !reflect.DeepEqual(got.Invitees, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got.Invitees)
+			}
+		})
+	}
+}
+
+func TestRunForageEnforcesHuntingParty(t *testing.T) {
+	party := shared.HuntingPartyProposal{
+		ForageType:        shared.DeerForageType,
+		Invitees:          []shared.ClientID{shared.Team2},
+		SplitStrategy:     shared.EqualSplit,
+		MinimumCommitment: 5,
+	}
+	clients := map[shared.ClientID]*mockClientForage{
+		shared.Team1: {partyProposal: party, forageDecision: shared.ForageDecision{Type: shared.FishForageType, Contribution: 1}},
+		shared.Team2: {joinParty: shared.Team1, acceptsParty: true, forageDecision: shared.ForageDecision{Type: shared.DeerForageType, Contribution: 8}},
+		shared.Team3: {forageDecision: shared.ForageDecision{Type: shared.DeerForageType, Contribution: 3}},
+	}
+	clientMap := map[shared.ClientID]baseclient.Client{}
+	clientInfos := map[shared.ClientID]gamestate.ClientInfo{}
+	for id, c := range clients {
+		clientMap[id] = c
+		clientInfos[id] = gamestate.ClientInfo{LifeStatus: shared.Alive, Resources: 100}
+	}
+	deerConf := config.DeerHuntConfig{
+		MaxDeerPerHunt:    4,
+		MaxDeerPopulation: 20,
+		BernoulliProb:     0.95,
+		ExponentialRate:   1.0,
+		InputScaler:       1,
+		OutputScaler:      1,
+	}
+	dummyLogger := func(format string, a ...interface{}) {
+		t.Logf("[FORAGING]: %v", fmt.Sprintf(format, a...))
+	}
+	s := SOMASServer{
+		gameConfig: config.Config{ForagingConfig: config.ForagingConfig{DeerHuntConfig: deerConf}},
+		gameState: gamestate.GameState{
+			ClientInfos: clientInfos,
+			ForagingHistory: map[shared.ForageType][]foraging.ForagingReport{
+				shared.DeerForageType: {},
+				shared.FishForageType: {},
+			},
+			DeerPopulation: foraging.CreateDeerPopulationModel(deerConf, dummyLogger),
+		},
+		clientMap: clientMap,
+	}
+
+	if err := s.runForage(); err != nil {
+		t.Fatalf("runForage error: %v", err)
+	}
+
+	reports := s.gameState.ForagingHistory[shared.DeerForageType]
+	if len(reports) != 2 {
+		t.Fatalf("want an open hunt and a party hunt, got %v reports", len(reports))
+	}
+	var partyReport foraging.ForagingReport
+	for _, r := range reports {
+		if r.Party != nil {
+			partyReport = r
+		} else if !reflect.DeepEqual(r.ParticipantContributions, map[shared.ClientID]shared.Resources{shared.Team3: 3}) {
+			t.Errorf("open hunt has wrong participants: %v", r.ParticipantContributions)
+		}
+	}
+	if partyReport.Party == nil || partyReport.Party.Proposer != shared.Team1 {
+		t.Fatalf("party hunt not recorded in foraging history: %v", reports)
+	}
+	wantContributions := map[shared.ClientID]shared.Resources{shared.Team1: 5, shared.Team2: 8}
+	if !reflect.DeepEqual(partyReport.ParticipantContributions, wantContributions) {
+		t.Errorf("want party contributions %v, got %v", wantContributions, partyReport.ParticipantContributions)
+	}
+	wantDecision := shared.ForageDecision{Type: shared.DeerForageType, Contribution: 5}
+	if clients[shared.Team1].gotForageDecision != wantDecision {
+		t.Errorf("want enforced decision %v, got %v", wantDecision, clients[shared.Team1].gotForageDecision)
+	}
+	// equal split: both members are left with the same resources relative to their contributions
+	team1 := s.gameState.ClientInfos[shared.Team1].Resources + 5
+	team2 := s.gameState.ClientInfos[shared.Team2].Resources + 8
+	if team1 != team2 {
+		t.Errorf("party return was not split equally: %v vs %v", team1, team2)
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"math"
 
+	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
 
@@ -32,6 +33,7 @@ func (s *SOMASServer) runPredictionSession() {
 	defer s.logf("finish runPredictionSession")
 	islandPredictionDict := s.getPredictions()
 
+	s.recordPredictions(islandPredictionDict)
 	s.distributePredictions(islandPredictionDict)
 }
 
@@ -85,6 +87,57 @@ func (s *SOMASServer) distributePredictions(islandPredictionDict shared.Disaster
 	}
 }
 
+// recordPredictions stores the predictions made this turn so that they can be scored once their predicted turn comes
+func (s *SOMASServer) recordPredictions(islandPredictionDict shared.DisasterPredictionInfoDict) {
+	if s.gameState.IIFOPendingPredictions == nil {
+		s.gameState.IIFOPendingPredictions = map[shared.ClientID][]disasters.PendingPrediction{}
+	}
+	for id, info := range islandPredictionDict {
+		s.gameState.IIFOPendingPredictions[id] = append(
+			s.gameState.IIFOPendingPredictions[id],
+			disasters.PendingPrediction{TurnMade: s.gameState.Turn, Prediction: info.PredictionMade},
+		)
+	}
+}
+
+// scorePredictions scores the pending predictions that are resolved this turn and updates each island's forecasting
+// accuracy record. If a disaster happened, it resolves the predictions whose predicted turn is within the time
+// tolerance of this turn. Predictions whose window closes this turn without a disaster are scored as false alarms.
+// Scored predictions are no longer pending.
+func (s *SOMASServer) scorePredictions(disasterHappened bool) {
+	s.logf("start scorePredictions")
+	defer s.logf("finish scorePredictions")
+
+	if s.gameState.IIFOForecastingAccuracy == nil {
+		s.gameState.IIFOForecastingAccuracy = map[shared.ClientID]disasters.ForecastingAccuracy{}
+	}
+	report := s.gameState.Environment.LastDisasterReport
+	turn := s.gameState.Turn
+	timeTolerance := s.gameConfig.IIFOConfig.PredictionTimeTolerance
+	for id, predictions := range s.gameState.IIFOPendingPredictions {
+		scores := []disasters.PredictionScore{}
+		pending := []disasters.PendingPrediction{}
+		for _, p := range predictions {
+			switch {
+			case disasterHappened && p.InWindow(turn, timeTolerance):
+				scores = append(scores, disasters.ScorePrediction(p, report, turn, s.gameConfig.IIFOConfig))
+			case p.Expired(turn, timeTolerance):
+				scores = append(scores, disasters.ScoreExpiredPrediction(p))
+			default:
+				pending = append(pending, p)
+			}
+		}
+		s.gameState.IIFOPendingPredictions[id] = pending
+		if len(scores) == 0 {
+			continue
+		}
+		accuracy := s.gameState.IIFOForecastingAccuracy[id].Update(scores)
+		s.gameState.IIFOForecastingAccuracy[id] = accuracy
+		s.logf("%v forecasting accuracy: %v/%v accurate predictions (%v false alarms), Brier score %.3f",
+			id, accuracy.NumAccurate, accuracy.NumScored, accuracy.NumExpired, accuracy.BrierScore)
+	}
+}
+
 // getForageSharing will ping each nonDeadClient and will save what their ForagingDecision,
 // their ResourceObtained from that decision, and which ClientID they want share this info
 // with.
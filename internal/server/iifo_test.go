@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -235,3 +237,68 @@ func TestDistributePredictions(t *testing.T) {
 	}
 
 }
+
+func TestScorePredictions(t *testing.T) {
+	prediction := shared.DisasterPrediction{CoordinateX: 1, CoordinateY: 1, Magnitude: 1, TimeLeft: 2, Confidence: 100}
+	server := &SOMASServer{
+		gameConfig: config.Config{
+			IIFOConfig: config.IIFOConfig{
+				PredictionSpatialTolerance:   1,
+				PredictionMagnitudeTolerance: 1,
+				PredictionTimeTolerance:      0,
+			},
+		},
+		gameState: gamestate.GameState{Turn: 3},
+	}
+
+	server.recordPredictions(shared.DisasterPredictionInfoDict{
+		shared.Team1: makeDisasterPrediction(prediction, []shared.ClientID{}),
+	})
+	server.gameState.Turn = 5
+	server.recordPredictions(shared.DisasterPredictionInfoDict{
+		shared.Team1: makeDisasterPrediction(prediction, []shared.ClientID{}),
+		shared.Team2: makeDisasterPrediction(prediction, []shared.ClientID{}),
+	})
+	if n := len(server.gameState.IIFOPendingPredictions[shared.Team1]); n != 2 {
+		t.Fatalf("Expected 2 pending predictions for Team1, got %v", n)
+	}
+
+	server.gameState.Environment.LastDisasterReport = disasters.DisasterReport{Magnitude: 1, X: 1, Y: 1}
+	server.scorePredictions(true)
+
+	// only the prediction made in turn 3 pointed at turn 5
+	team1 := server.gameState.IIFOForecastingAccuracy[shared.Team1]
+	if team1.NumScored != 1 || team1.NumAccurate != 1 {
+		t.Errorf("Expected Team1 to have 1/1 accurate predictions, got %v/%v", team1.NumAccurate, team1.NumScored)
+	}
+	if _, ok := server.gameState.IIFOForecastingAccuracy[shared.Team2]; ok {
+		t.Errorf("Expected no scored predictions for Team2, got %+v", server.gameState.IIFOForecastingAccuracy[shared.Team2])
+	}
+	for _, id := range []shared.ClientID{shared.Team1, shared.Team2} {
+		if n := len(server.gameState.IIFOPendingPredictions[id]); n != 1 {
+			t.Errorf("Expected 1 pending prediction for %v, got %v", id, n)
+		}
+	}
+
+	// the predictions made in turn 5 expire in turn 7 without a disaster
+	server.gameState.Turn = 6
+	server.scorePredictions(false)
+	if n := len(server.gameState.IIFOPendingPredictions[shared.Team2]); n != 1 {
+		t.Errorf("Expected the Team2 prediction to be pending before its predicted turn, got %v pending", n)
+	}
+	server.gameState.Turn = 7
+	server.scorePredictions(false)
+	for _, id := range []shared.ClientID{shared.Team1, shared.Team2} {
+		if n := len(server.gameState.IIFOPendingPredictions[id]); n != 0 {
+			t.Errorf("Expected no pending predictions for %v, got %v", id, n)
+		}
+	}
+	team1 = server.gameState.IIFOForecastingAccuracy[shared.Team1]
+	if team1.NumScored != 2 || team1.NumAccurate != 1 || team1.NumExpired != 1 {
+		t.Errorf("Expected Team1 to have 1/2 accurate predictions and 1 false alarm, got %+v", team1)
+	}
+	team2 := server.gameState.IIFOForecastingAccuracy[shared.Team2]
+	if team2.NumScored != 1 || team2.NumAccurate != 0 || team2.NumExpired != 1 || team2.BrierScore != 1 {
+		t.Errorf("Expected Team2 to have a single confident false alarm, got %+v", team2)
+	}
+}
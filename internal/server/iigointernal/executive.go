@@ -20,6 +20,7 @@ type executive struct {
 	PresidentID      shared.ClientID
 	clientPresident  roles.President
 	RulesProposals   []rules.RuleMatrix
+	RuleProposers    []shared.ClientID // island that proposed each rule in RulesProposals
 	ResourceRequests map[shared.ClientID]shared.Resources
 	iigoClients      map[shared.ClientID]baseclient.Client
 	monitoring       *monitor
@@ -181,7 +182,8 @@ func (e *executive) replyAllocationRequest(commonPool shared.Resources) (bool, e
 // appointNextSpeaker returns the island ID of the island appointed to be Speaker in the next turn
 func (e *executive) appointNextSpeaker(monitoring shared.MonitorResult, currentSpeaker shared.ClientID, allIslands []shared.ClientID) (shared.ClientID, error) {
 	var election = voting.Election{
-		Logger: e.logger,
+		Logger:       e.logger,
+		TieBreakSeed: getElectionTieBreakSeed(e.gameConf, e.gameState, shared.Speaker),
 	}
 	var appointedSpeaker shared.ClientID
 	allIslandsCopy1 := copyClientList(allIslands)
@@ -193,11 +195,11 @@ func (e *executive) appointNextSpeaker(monitoring shared.MonitorResult, currentS
 	valuesToCache := [][]float64{{boolToFloat(termCondition)}, {boolToFloat(electionSettings.HoldElection)}}
 	e.monitoring.addToCache(e.PresidentID, variablesToCache, valuesToCache)
 
+	election.ProposeElection(shared.Speaker, electionSettings.VotingMethod)
 	if electionSettings.HoldElection {
 		if !e.incurServiceCharge(e.gameConf.AppointNextSpeakerActionCost) {
 			return e.gameState.SpeakerID, errors.Errorf("Insufficient Budget in common Pool: appointNextSpeaker")
 		}
-		election.ProposeElection(shared.Speaker, electionSettings.VotingMethod)
 		allIslandsCopy2 := copyClientList(allIslands)
 		election.OpenBallot(electionSettings.IslandsToVote, allIslandsCopy2)
 		election.Vote(e.iigoClients)
@@ -211,9 +213,13 @@ func (e *executive) appointNextSpeaker(monitoring shared.MonitorResult, currentS
 		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}}
 		e.monitoring.addToCache(e.PresidentID, variablesToCache, valuesToCache)
 		e.Logf("Result of election for new Speaker: %v", appointedSpeaker)
+		if !appointmentMatchesVote {
+			e.Logf("Election result overridden: %v won the election for Speaker but %v was appointed", electedSpeaker, appointedSpeaker)
+		}
 	} else {
 		appointedSpeaker = currentSpeaker
 	}
+	election.AppointWinner(appointedSpeaker)
 	e.gameState.IIGOElection = append(e.gameState.IIGOElection, election.GetVotingInfo())
 	return appointedSpeaker, nil
 }
@@ -255,17 +261,30 @@ func (e *executive) requestRuleProposal() error { //TODO: add checks for if immu
 	}
 
 	var ruleProposals []rules.RuleMatrix
+	var ruleProposers []shared.ClientID
 	for _, island := range e.getIslandAlive() {
 		proposedRuleMatrix := e.iigoClients[shared.ClientID(int(island))].RuleProposal()
 		if checkRuleIsValid(proposedRuleMatrix.RuleName, e.gameState.RulesInfo.AvailableRules) {
 			ruleProposals = append(ruleProposals, proposedRuleMatrix)
+			ruleProposers = append(ruleProposers, shared.ClientID(int(island)))
 		}
 	}
 
 	e.setRuleProposals(ruleProposals)
+	e.RuleProposers = ruleProposers
 	return nil
 }
 
+// getRuleProposer returns the island that proposed a rule, or the President if the rule is not one of the proposals
+func (e *executive) getRuleProposer(ruleMatrix rules.RuleMatrix) shared.ClientID {
+	for i, proposal := range e.RulesProposals {
+		if i < len(e.RuleProposers) && reflect.DeepEqual(proposal, ruleMatrix) {
+			return e.RuleProposers[i]
+		}
+	}
+	return e.PresidentID
+}
+
 func checkRuleIsValid(ruleName string, rulesCache map[string]rules.RuleMatrix) bool {
 	_, valid := rulesCache[ruleName]
 	return valid
@@ -25,6 +25,8 @@ type judiciary struct {
 	iigoClients           map[shared.ClientID]baseclient.Client
 	monitoring            *monitor
 	logger                shared.Logger
+	// explanations of why islands broke rules, by island and rule name
+	transgressionExplanations map[shared.ClientID]map[string]rules.RuleExplanation
 }
 
 func (j *judiciary) Logf(format string, a ...interface{}) {
@@ -119,6 +121,7 @@ func (j *judiciary) inspectHistory(iigoHistory []shared.Accountability) (map[sha
 			entryForHistoryCache := cullCheckedRules(iigoHistory, finalResults, rulesInPlay, j.gameState.RulesInfo.VariableMap)
 			j.cycleHistoryCache(entryForHistoryCache, int(j.gameConf.HistoryCacheDepth))
 			j.evaluationResults = finalResults
+			j.transgressionExplanations = explainTransgressions(iigoHistory, finalResults, rulesInPlay, j.gameState.RulesInfo.VariableMap)
 			return j.evaluationResults, actionTakenByClient
 		}
 		return nil, false
@@ -153,6 +156,7 @@ func (j *judiciary) inspectHistory(iigoHistory []shared.Accountability) (map[sha
 	entryForHistoryCache := cullCheckedRules(iigoHistory, finalResults, rulesInPlay, j.gameState.RulesInfo.VariableMap)
 	j.cycleHistoryCache(entryForHistoryCache, int(j.gameConf.HistoryCacheDepth))
 	j.evaluationResults = finalResults
+	j.transgressionExplanations = explainTransgressions(iigoHistory, finalResults, rulesInPlay, j.gameState.RulesInfo.VariableMap)
 	return j.evaluationResults, actionTakenByClient
 }
 
@@ -169,7 +173,8 @@ func searchForRule(ruleName string, listOfRuleMatrices []rules.RuleMatrix) (int,
 // appointNextPresident returns the island ID of the island appointed to be President in the next turn
 func (j *judiciary) appointNextPresident(monitoring shared.MonitorResult, currentPresident shared.ClientID, allIslands []shared.ClientID) (shared.ClientID, error) {
 	var election = voting.Election{
-		Logger: j.logger,
+		Logger:       j.logger,
+		TieBreakSeed: getElectionTieBreakSeed(j.gameConf, j.gameState, shared.President),
 	}
 	var appointedPresident shared.ClientID
 	allIslandsCopy1 := copyClientList(allIslands)
@@ -181,11 +186,11 @@ func (j *judiciary) appointNextPresident(monitoring shared.MonitorResult, curren
 	valuesToCache := [][]float64{{boolToFloat(termCondition)}, {boolToFloat(electionSettings.HoldElection)}}
 	j.monitoring.addToCache(j.JudgeID, variablesToCache, valuesToCache)
 
+	election.ProposeElection(shared.President, electionSettings.VotingMethod)
 	if electionSettings.HoldElection {
 		if !j.incurServiceCharge(j.gameConf.InspectHistoryActionCost) {
 			return j.gameState.PresidentID, errors.Errorf("Insufficient Budget in common Pool: appointNextPresident")
 		}
-		election.ProposeElection(shared.President, electionSettings.VotingMethod)
 		allIslandsCopy2 := copyClientList(allIslands)
 		election.OpenBallot(electionSettings.IslandsToVote, allIslandsCopy2)
 		election.Vote(j.iigoClients)
@@ -199,9 +204,13 @@ func (j *judiciary) appointNextPresident(monitoring shared.MonitorResult, curren
 		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}}
 		j.monitoring.addToCache(j.JudgeID, variablesToCache, valuesToCache)
 		j.Logf("Result of election for new President: %v", appointedPresident)
+		if !appointmentMatchesVote {
+			j.Logf("Election result overridden: %v won the election for President but %v was appointed", electedPresident, appointedPresident)
+		}
 	} else {
 		appointedPresident = currentPresident
 	}
+	election.AppointWinner(appointedPresident)
 	j.gameState.IIGOElection = append(j.gameState.IIGOElection, election.GetVotingInfo())
 	return appointedPresident, nil
 }
@@ -241,7 +250,11 @@ func (j *judiciary) scoreIslandTransgressions(transgressions map[shared.ClientID
 			} else {
 				totalIslandTurnScore += j.gameConf.DefaultSanctionScore
 			}
-			j.Logf("Rule: %v, broken by: %v", ruleBroken, islandID)
+			if explanation, ok := j.transgressionExplanations[islandID][ruleBroken]; ok {
+				j.Logf("Rule: %v, broken by: %v\n%v", ruleBroken, islandID, explanation)
+			} else {
+				j.Logf("Rule: %v, broken by: %v", ruleBroken, islandID)
+			}
 		}
 		j.sanctionRecord[islandID] += totalIslandTurnScore
 	}
@@ -252,18 +265,29 @@ func (j *judiciary) applySanctions() {
 	j.cycleSanctionCache(int(j.gameConf.SanctionCacheDepth))
 	var currentSanctions []shared.Sanction
 	for islandID, sanctionScore := range j.sanctionRecord {
-		islandSanctionTier := getIslandSanctionTier(sanctionScore, j.sanctionThresholds)
+		islandSanctionTier := shared.GetSanctionTier(sanctionScore, j.sanctionThresholds)
 		sanctionEntry := shared.Sanction{
 			ClientID:     islandID,
 			SanctionTier: islandSanctionTier,
 			TurnsLeft:    int(j.gameConf.SanctionLength),
 		}
 		currentSanctions = append(currentSanctions, sanctionEntry)
-		broadcastToAllIslands(j.iigoClients, j.JudgeID, createBroadcastForSanction(islandID, islandSanctionTier), *j.gameState)
+		broadcastToAllIslands(j.iigoClients, j.JudgeID, createBroadcastForSanction(islandID, islandSanctionTier, j.explainIslandTransgressions(islandID)), *j.gameState)
 	}
 	j.gameState.IIGOSanctionCache[0] = currentSanctions
 }
 
+// explainIslandTransgressions describes why an island broke each of the rules it is sanctioned for, where this is known
+func (j *judiciary) explainIslandTransgressions(islandID shared.ClientID) []string {
+	var explanations []string
+	for _, ruleBroken := range unpackSingleIslandTransgressions(j.evaluationResults[islandID]) {
+		if explanation, ok := j.transgressionExplanations[islandID][ruleBroken]; ok {
+			explanations = append(explanations, explanation.String())
+		}
+	}
+	return explanations
+}
+
 // sanctionEvaluate allows the clients to effectively pardon islands, levy and communicate sanctions
 func (j *judiciary) sanctionEvaluate(reportedIslandResources map[shared.ClientID]shared.ResourcesReport) {
 	pardons := j.clientJudge.GetPardonedIslands(j.gameState.IIGOSanctionCache)
@@ -317,8 +341,8 @@ func broadcastGeneric(clients map[shared.ClientID]baseclient.Client, judgeID sha
 	}
 }
 
-func createBroadcastForSanction(clientID shared.ClientID, sanctionTier shared.IIGOSanctionsTier) map[shared.CommunicationFieldName]shared.CommunicationContent {
-	return map[shared.CommunicationFieldName]shared.CommunicationContent{
+func createBroadcastForSanction(clientID shared.ClientID, sanctionTier shared.IIGOSanctionsTier, explanations []string) map[shared.CommunicationFieldName]shared.CommunicationContent {
+	broadcast := map[shared.CommunicationFieldName]shared.CommunicationContent{
 		shared.SanctionClientID: {
 			T:           shared.CommunicationInt,
 			IntegerData: int(clientID),
@@ -328,6 +352,13 @@ func createBroadcastForSanction(clientID shared.ClientID, sanctionTier shared.II
 			IntegerData: int(sanctionTier),
 		},
 	}
+	if len(explanations) > 0 {
+		broadcast[shared.SanctionExplanations] = shared.CommunicationContent{
+			T:            shared.CommunicationStringList,
+			TextListData: explanations,
+		}
+	}
+	return broadcast
 }
 
 func createBroadcastsForSanctionThresholds(thresholds map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore) []map[shared.CommunicationFieldName]shared.CommunicationContent {
@@ -413,20 +444,9 @@ func decrementSanctionTime(sanctions map[int][]shared.Sanction) (updatedSanction
 	return sanctions
 }
 
-// getDefaultSanctionThresholds provides default thresholds for sanctions
-func getDefaultSanctionThresholds() map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore {
-	return map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore{
-		shared.SanctionTier1: 1,
-		shared.SanctionTier2: 5,
-		shared.SanctionTier3: 10,
-		shared.SanctionTier4: 20,
-		shared.SanctionTier5: 30,
-	}
-}
-
 // softMergeSanctionThresholds merges the default sanction thresholds with a (preferred) client version
 func softMergeSanctionThresholds(clientSanctionMap map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore) map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore {
-	outputMap := getDefaultSanctionThresholds()
+	outputMap := shared.DefaultSanctionThresholds()
 	for k := range outputMap {
 		if clientVal, ok := clientSanctionMap[k]; ok {
 			outputMap[k] = clientVal
@@ -435,7 +455,7 @@ func softMergeSanctionThresholds(clientSanctionMap map[shared.IIGOSanctionsTier]
 	if checkMonotonicityOfSanctionThresholds(outputMap) {
 		return outputMap
 	} else {
-		return getDefaultSanctionThresholds()
+		return shared.DefaultSanctionThresholds()
 	}
 }
 
@@ -468,23 +488,6 @@ func unpackSingleIslandTransgressions(evaluationReturn shared.EvaluationReturn)
 	return transgressions
 }
 
-// getIslandSanctionTier if statement based evaluator for which sanction tier a particular score is in
-func getIslandSanctionTier(islandScore shared.IIGOSanctionsScore, scoreMap map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore) shared.IIGOSanctionsTier {
-	if islandScore < scoreMap[shared.SanctionTier1] {
-		return shared.NoSanction
-	} else if islandScore < scoreMap[shared.SanctionTier2] {
-		return shared.SanctionTier1
-	} else if islandScore < scoreMap[shared.SanctionTier3] {
-		return shared.SanctionTier2
-	} else if islandScore < scoreMap[shared.SanctionTier4] {
-		return shared.SanctionTier3
-	} else if islandScore < scoreMap[shared.SanctionTier5] {
-		return shared.SanctionTier4
-	} else {
-		return shared.SanctionTier5
-	}
-}
-
 // getTierSanctionMap basic mapping between sanction tier and rule that governs it
 func getTierSanctionMap() map[shared.IIGOSanctionsTier]string {
 	return map[shared.IIGOSanctionsTier]string{
@@ -614,6 +617,45 @@ func cullCheckedRules(iigoHistory []shared.Accountability, evalResults map[share
 	return reducedAccountability
 }
 
+// explainTransgressions explains why islands broke the rules in evalResults, by evaluating each broken rule when an
+// island reports a variable it requires, in the order the judge inspects the history. Rules broken in earlier turns
+// (through historical retribution) that hold for this turn's reports are not explained.
+func explainTransgressions(iigoHistory []shared.Accountability, evalResults map[shared.ClientID]shared.EvaluationReturn, rulesCache map[string]rules.RuleMatrix, variableCache map[rules.VariableFieldName]rules.VariableValuePair) map[shared.ClientID]map[string]rules.RuleExplanation {
+	explanations := map[shared.ClientID]map[string]rules.RuleExplanation{}
+	variables := rules.CopyVariableMap(variableCache)
+	for _, entry := range iigoHistory {
+		for _, pair := range entry.Pairs {
+			variables[pair.VariableName] = pair
+		}
+		for _, ruleBroken := range unpackSingleIslandTransgressions(evalResults[entry.ClientID]) {
+			if _, explained := explanations[entry.ClientID][ruleBroken]; explained || !requiresAnyVariable(rulesCache[ruleBroken], entry.Pairs) {
+				continue
+			}
+			ret, explanation := rules.ExplainRuleFromCaches(ruleBroken, rulesCache, variables)
+			if ret.EvalError != nil || ret.RulePasses {
+				continue
+			}
+			if explanations[entry.ClientID] == nil {
+				explanations[entry.ClientID] = map[string]rules.RuleExplanation{}
+			}
+			explanations[entry.ClientID][ruleBroken] = explanation
+		}
+	}
+	return explanations
+}
+
+// requiresAnyVariable checks whether a rule requires any of the variables in pairs
+func requiresAnyVariable(rule rules.RuleMatrix, pairs []rules.VariableValuePair) bool {
+	for _, pair := range pairs {
+		for _, required := range rule.RequiredVariables {
+			if pair.VariableName == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // streamlineRulesAffected removes duplicate rules
 func streamlineRulesAffected(input []string) []string {
 	streamlineMap := map[string]bool{}
@@ -428,7 +428,7 @@ func TestApplySanctions(t *testing.T) {
 			sanctionRecord: map[shared.ClientID]shared.IIGOSanctionsScore{
 				shared.Team1: 4,
 			},
-			sanctionThresholds: getDefaultSanctionThresholds(),
+			sanctionThresholds: shared.DefaultSanctionThresholds(),
 			sanctionLength:     2,
 			expectedSanctions: []shared.Sanction{
 				{
@@ -445,7 +445,7 @@ func TestApplySanctions(t *testing.T) {
 				shared.Team2: 5,
 				shared.Team3: 10,
 			},
-			sanctionThresholds: getDefaultSanctionThresholds(),
+			sanctionThresholds: shared.DefaultSanctionThresholds(),
 			sanctionLength:     2,
 			expectedSanctions: []shared.Sanction{
 				{
@@ -520,6 +520,7 @@ func TestCreateBroadcastForSanction(t *testing.T) {
 		name          string
 		clientID      shared.ClientID
 		tier          shared.IIGOSanctionsTier
+		explanations  []string
 		expectedComms map[shared.CommunicationFieldName]shared.CommunicationContent
 	}{
 		{
@@ -537,10 +538,30 @@ func TestCreateBroadcastForSanction(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "Broadcast with explanations",
+			clientID:     shared.Team2,
+			tier:         shared.SanctionTier1,
+			explanations: []string{"rule 'a' fails"},
+			expectedComms: map[shared.CommunicationFieldName]shared.CommunicationContent{
+				shared.SanctionClientID: {
+					T:           shared.CommunicationInt,
+					IntegerData: int(shared.Team2),
+				},
+				shared.IIGOSanctionTier: {
+					T:           shared.CommunicationInt,
+					IntegerData: int(shared.SanctionTier1),
+				},
+				shared.SanctionExplanations: {
+					T:            shared.CommunicationStringList,
+					TextListData: []string{"rule 'a' fails"},
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			res := createBroadcastForSanction(tc.clientID, tc.tier)
+			res := createBroadcastForSanction(tc.clientID, tc.tier, tc.explanations)
 			if !reflect.DeepEqual(tc.expectedComms, res) {
 				t.Errorf("Expected %v got %v", tc.expectedComms, res)
 			}
@@ -1245,6 +1266,50 @@ func TestCullCheckedRules(t *testing.T) {
 	}
 }
 
+func TestExplainTransgressions(t *testing.T) {
+	rulesCache := map[string]rules.RuleMatrix{}
+	for _, text := range []string{"rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0", "rule salary: JudgeSalary <= 10"} {
+		rule, err := rules.ParseRule(text)
+		if err != nil {
+			t.Fatalf("invalid rule: %v", err)
+		}
+		rulesCache[rule.RuleName] = rule
+	}
+	history := []shared.Accountability{
+		{
+			ClientID: shared.Team1,
+			Pairs: []rules.VariableValuePair{
+				rules.MakeVariableValuePair(rules.IslandTaxContribution, []float64{3}),
+				rules.MakeVariableValuePair(rules.ExpectedTaxContribution, []float64{5}),
+			},
+		},
+		{
+			ClientID: shared.Team2,
+			Pairs: []rules.VariableValuePair{
+				rules.MakeVariableValuePair(rules.IslandTaxContribution, []float64{7}),
+				rules.MakeVariableValuePair(rules.ExpectedTaxContribution, []float64{5}),
+			},
+		},
+	}
+	evalRes := map[shared.ClientID]shared.EvaluationReturn{
+		shared.Team1: {Rules: []rules.RuleMatrix{rulesCache["tax"]}, Evaluations: []bool{false}},
+		// broken in an earlier turn
+		shared.Team2: {Rules: []rules.RuleMatrix{rulesCache["tax"], rulesCache["salary"]}, Evaluations: []bool{true, false}},
+	}
+	variableCache := map[rules.VariableFieldName]rules.VariableValuePair{
+		rules.JudgeSalary: rules.MakeVariableValuePair(rules.JudgeSalary, []float64{50}),
+	}
+
+	res := explainTransgressions(history, evalRes, rulesCache, variableCache)
+	if len(res) != 1 || len(res[shared.Team1]) != 1 {
+		t.Fatalf("Expected an explanation of Team1 breaking 'tax' only, got %v", res)
+	}
+	explanation := res[shared.Team1]["tax"]
+	if explanation.Passes || len(explanation.FailedRows) != 1 || explanation.FailedRows[0].MinimumChange != 2 {
+		t.Errorf("Expected 'tax' to fail by 2, got %+v", explanation)
+	}
+}
+
 // TestPickUpRulesByVariable checks whether the pickUpRulesByVariable is able to correctly identify what rules
 // a particular variable has affected
 func TestPickUpRulesByVariable(t *testing.T) {
@@ -2,6 +2,7 @@ package iigointernal
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/voting"
 	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
 )
 
 type legislature struct {
@@ -20,8 +22,10 @@ type legislature struct {
 	gameConf      *config.IIGOConfig
 	SpeakerID     shared.ClientID
 	ruleToVote    rules.RuleMatrix
+	ruleProposer  shared.ClientID
 	ballotBox     voting.BallotBox
 	votingResult  bool
+	ruleConflicts []string
 	clientSpeaker roles.Speaker
 	iigoClients   map[shared.ClientID]baseclient.Client
 	monitoring    *monitor
@@ -111,7 +115,10 @@ func (l *legislature) setVotingResult(clientIDs []shared.ClientID) (bool, error)
 		}
 		l.ballotBox = l.RunVote(returnVote.RuleMatrix, returnVote.ParticipatingIslands)
 
-		l.votingResult = l.ballotBox.CountVotesMajority()
+		procedure := l.getRuleVoteProcedure(returnVote.RuleMatrix.RuleName)
+		quorumMet, supermajorityMet := l.checkRuleVoteThresholds(l.ballotBox, uint(len(clientIDs)))
+		l.votingResult = l.ballotBox.Decide(procedure.DecisionRule, quorumMet, supermajorityMet)
+		l.Logf("Rule vote decided by %v (quorum met %v, supermajority met %v) with ballots %+v: %v", procedure.DecisionRule, quorumMet, supermajorityMet, l.ballotBox, l.votingResult)
 		voteCalled = true
 	}
 	return voteCalled, nil
@@ -135,6 +142,7 @@ func (l *legislature) RunVote(ruleMatrix rules.RuleMatrix, clientIDs []shared.Cl
 	//TODO: intersection of islands alive and islands chosen to vote in case of client error
 	//TODO: check if remaining slice is >0, otherwise return empty ballot, raise error?
 	ruleVote.SetVotingIslands(clientIDs)
+	ruleVote.SetVoteWeights(l.getRuleVoteWeights(l.getRuleVoteProcedure(ruleMatrix.RuleName).Weighting, clientIDs))
 
 	ruleVote.GatherBallots(l.iigoClients)
 	//TODO: log of vote occurring with ruleMatrix, clientIDs
@@ -154,6 +162,110 @@ func (l *legislature) RunVote(ruleMatrix rules.RuleMatrix, clientIDs []shared.Cl
 	return ruleVote.GetBallotBox()
 }
 
+// getRuleVoteProcedure returns the procedure of a vote on a rule: the rule's configured override if there is one,
+// otherwise the procedure of votes on immutable or mutable rules.
+func (l *legislature) getRuleVoteProcedure(ruleName string) shared.RuleVoteProcedure {
+	if procedure, ok := l.gameConf.RuleVoteProcedureOverrides[ruleName]; ok {
+		return procedure
+	}
+	if rule, ok := l.gameState.RulesInfo.AvailableRules[ruleName]; ok && !rule.Mutable {
+		return l.gameConf.ImmutableRuleVoteProcedure
+	}
+	return l.gameConf.RuleVoteProcedure
+}
+
+// checkRuleVoteThresholds records the turnout and approval of a rule vote in the RuleVoteTurnout and RuleVoteApproval
+// variables and returns whether the vote reached the quorum and the supermajority. While the rules "rule_vote_quorum"
+// and "rule_vote_supermajority" are in play the thresholds are met if the rules pass, so that the legislature can
+// amend its own procedure. Otherwise, the thresholds from config are used.
+func (l *legislature) checkRuleVoteThresholds(ballotBox voting.BallotBox, numEligible uint) (bool, bool) {
+	l.gameState.UpdateVariable(rules.RuleVoteTurnout, rules.MakeVariableValuePair(rules.RuleVoteTurnout, []float64{ballotBox.Turnout(numEligible)}))
+	l.gameState.UpdateVariable(rules.RuleVoteApproval, rules.MakeVariableValuePair(rules.RuleVoteApproval, []float64{ballotBox.Approval()}))
+
+	quorumMet, supermajorityMet := ballotBox.MeetsThresholds(voting.DecisionProcedure{
+		Quorum:        l.gameConf.RuleVoteQuorum,
+		Supermajority: l.gameConf.RuleVoteSupermajority,
+	}, numEligible)
+	quorumMet = l.evaluateRuleVoteThreshold("rule_vote_quorum", quorumMet)
+	supermajorityMet = l.evaluateRuleVoteThreshold("rule_vote_supermajority", supermajorityMet)
+	return quorumMet, supermajorityMet
+}
+
+// evaluateRuleVoteThreshold evaluates a rule holding a rule vote threshold if it is in play, otherwise it returns
+// metByConfig
+func (l *legislature) evaluateRuleVoteThreshold(ruleName string, metByConfig bool) bool {
+	if _, ok := l.gameState.RulesInfo.CurrentRulesInPlay[ruleName]; !ok {
+		return metByConfig
+	}
+	ret := rules.EvaluateRuleFromCaches(ruleName, l.gameState.RulesInfo.CurrentRulesInPlay, l.gameState.RulesInfo.VariableMap)
+	if ret.EvalError != nil {
+		l.Logf("Cannot evaluate rule '%v', using the threshold from config: %v", ruleName, ret.EvalError)
+		return metByConfig
+	}
+	return ret.RulePasses
+}
+
+// getRuleVoteWeights returns the ballot weight of each voter under the given weighting. Returns nil (equal weights)
+// if the weighting is equal or no voter carries any weight.
+func (l *legislature) getRuleVoteWeights(weighting shared.RuleVoteWeighting, clientIDs []shared.ClientID) map[shared.ClientID]float64 {
+	weights := map[shared.ClientID]float64{}
+	switch weighting {
+	case shared.ResourceVoteWeighting:
+		for _, clientID := range clientIDs {
+			weights[clientID] = math.Max(0, float64(l.gameState.ClientInfos[clientID].Resources))
+		}
+	case shared.ContributionVoteWeighting:
+		contributions := getTotalTaxContributions(l.gameState.IIGOHistory)
+		for _, clientID := range clientIDs {
+			weights[clientID] = math.Max(0, float64(contributions[clientID]))
+		}
+	default:
+		return nil
+	}
+
+	for _, weight := range weights {
+		if weight > 0 {
+			return weights
+		}
+	}
+	return nil
+}
+
+// getTotalTaxContributions sums the tax paid by each island over the IIGO history
+func getTotalTaxContributions(iigoHistory map[uint][]shared.Accountability) map[shared.ClientID]shared.Resources {
+	contributions := map[shared.ClientID]shared.Resources{}
+	for _, entries := range iigoHistory {
+		for _, entry := range entries {
+			for _, pair := range entry.Pairs {
+				if pair.VariableName == rules.IslandTaxContribution && len(pair.Values) > 0 {
+					contributions[entry.ClientID] += shared.Resources(pair.Values[0])
+				}
+			}
+		}
+	}
+	return contributions
+}
+
+// SetRuleVoteThresholds sets the rules holding the quorum and supermajority of rule votes to their configured values
+func SetRuleVoteThresholds(g *gamestate.GameState, iigoConf config.IIGOConfig) error {
+	thresholdRules := []struct {
+		ruleName  string
+		threshold float64
+	}{
+		{ruleName: "rule_vote_quorum", threshold: iigoConf.RuleVoteQuorum},
+		{ruleName: "rule_vote_supermajority", threshold: iigoConf.RuleVoteSupermajority},
+	}
+	for _, thresholdRule := range thresholdRules {
+		// threshold rules read variable - threshold >= 0
+		newMatrix := mat.NewDense(1, 2, []float64{1, -thresholdRule.threshold})
+		newAux := mat.NewVecDense(1, []float64{2})
+		if err := g.ModifyRule(thresholdRule.ruleName, *newMatrix, *newAux); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 //Speaker declares a result of a vote (see spec to see conditions on what this means for a rule-abiding speaker)
 //Called by orchestration
 func (l *legislature) announceVotingResult() (bool, error) {
@@ -171,7 +283,7 @@ func (l *legislature) announceVotingResult() (bool, error) {
 		}
 
 		//Perform announcement
-		broadcastToAllIslands(l.iigoClients, shared.TeamIDs[l.SpeakerID], generateVotingResultMessage(returnAnnouncement.RuleMatrix, returnAnnouncement.VotingResult), *l.gameState)
+		broadcastToAllIslands(l.iigoClients, shared.TeamIDs[l.SpeakerID], generateVotingResultMessage(returnAnnouncement.RuleMatrix, returnAnnouncement.VotingResult, l.ruleConflicts), *l.gameState)
 		resultAnnounced = true
 
 		//log rule "must announce what was called"
@@ -186,7 +298,7 @@ func (l *legislature) announceVotingResult() (bool, error) {
 	return resultAnnounced, nil
 }
 
-func generateVotingResultMessage(ruleMatrix rules.RuleMatrix, result bool) map[shared.CommunicationFieldName]shared.CommunicationContent {
+func generateVotingResultMessage(ruleMatrix rules.RuleMatrix, result bool, conflicts []string) map[shared.CommunicationFieldName]shared.CommunicationContent {
 	returnMap := map[shared.CommunicationFieldName]shared.CommunicationContent{}
 
 	returnMap[shared.RuleName] = shared.CommunicationContent{
@@ -197,25 +309,84 @@ func generateVotingResultMessage(ruleMatrix rules.RuleMatrix, result bool) map[s
 		T:           shared.CommunicationBool,
 		BooleanData: result,
 	}
+	if len(conflicts) > 0 {
+		returnMap[shared.RuleConflicts] = shared.CommunicationContent{
+			T:            shared.CommunicationStringList,
+			TextListData: conflicts,
+		}
+	}
 
 	return returnMap
 }
 
+// checkRuleConflicts finds the rules in play that cannot be satisfied together with a rule about to be pulled into
+// play, or to replace a rule in play, as a result of a vote. They are reported in the announcement of the vote.
+func (l *legislature) checkRuleConflicts(ruleMatrix rules.RuleMatrix, ruleIsVotedIn bool) {
+	l.ruleConflicts = nil
+	_, available := l.gameState.RulesInfo.AvailableRules[ruleMatrix.RuleName]
+	_, inPlay := l.gameState.RulesInfo.CurrentRulesInPlay[ruleMatrix.RuleName]
+	if !ruleIsVotedIn || !available || (inPlay == l.putsRuleInOrOutOfPlay(ruleMatrix)) {
+		return
+	}
+	conflicts, err := rules.FindConflictingRules(ruleMatrix, l.gameState.RulesInfo.CurrentRulesInPlay, l.gameState.RulesInfo.VariableMap)
+	if err != nil {
+		l.Logf("Could not check rule '%v' for conflicts with rules in play: %v", ruleMatrix.RuleName, err)
+		return
+	}
+	if len(conflicts) > 0 {
+		l.Logf("Rule '%v' cannot be satisfied together with rules in play: %v", ruleMatrix.RuleName, conflicts)
+	}
+	l.ruleConflicts = conflicts
+}
+
+// putsRuleInOrOutOfPlay checks whether a proposal is for putting a rule in/out of play rather than modifying it. This
+// is the case if the proposal has the same content as the rule with the same name in AvailableRules, or if the rule is
+// out of play and the proposal only differs in its sunset clause, so that the rule enters play with the sunset clause.
+func (l *legislature) putsRuleInOrOutOfPlay(ruleMatrix rules.RuleMatrix) bool {
+	availableRule, ok := l.gameState.RulesInfo.AvailableRules[ruleMatrix.RuleName]
+	if !ok || reflect.DeepEqual(ruleMatrix, availableRule) {
+		return true
+	}
+	_, inPlay := l.gameState.RulesInfo.CurrentRulesInPlay[ruleMatrix.RuleName]
+	withAvailableExpiry := ruleMatrix
+	withAvailableExpiry.ExpiryTurn = availableRule.ExpiryTurn
+	return !inPlay && reflect.DeepEqual(withAvailableExpiry, availableRule)
+}
+
 // updateRules updates the rules in play according to the result of a vote.
 func (l *legislature) updateRules(ruleMatrix rules.RuleMatrix, ruleIsVotedIn bool) error {
 	if !l.incurServiceCharge(l.gameConf.UpdateRulesActionCost) {
 		return errors.Errorf("Insufficient Budget in common Pool: updateRules")
 	}
+	previousRules := gamestate.RulesContext{
+		AvailableRules:     rules.CopyRulesMap(l.gameState.RulesInfo.AvailableRules),
+		CurrentRulesInPlay: rules.CopyRulesMap(l.gameState.RulesInfo.CurrentRulesInPlay),
+	}
+	defer l.gameState.RecordRuleVote(previousRules, l.ruleProposer, l.ballotBox.VotesInFavour, l.ballotBox.VotesAgainst)
 	//TODO: might want to log the errors as logging messages too?
 	//notInRulesCache := errors.Errorf("Rule '%v' is not available in rules cache", ruleMatrix)
-	if _, ok := l.gameState.RulesInfo.AvailableRules[ruleMatrix.RuleName]; !ok || reflect.DeepEqual(ruleMatrix, l.gameState.RulesInfo.AvailableRules[ruleMatrix.RuleName]) { //if the proposed ruleMatrix has the same content as the rule with the same name in AvailableRules, the proposal is for putting a rule in/out of play.
+	if l.putsRuleInOrOutOfPlay(ruleMatrix) { //if the proposed ruleMatrix has the same content as the rule with the same name in AvailableRules, apart from a sunset clause for a rule out of play, the proposal is for putting a rule in/out of play.
 		if ruleIsVotedIn {
+			if l.gameConf.RejectConflictingRules && len(l.ruleConflicts) > 0 {
+				return errors.Errorf("Rule '%v' not pulled into play: conflicts with %v", ruleMatrix.RuleName, l.ruleConflicts)
+			}
+			// an immutable rule cannot get a sunset clause, so the proposal is rejected before anything changes
+			if availableRule, ok := l.gameState.RulesInfo.AvailableRules[ruleMatrix.RuleName]; ok && !availableRule.Mutable && ruleMatrix.ExpiryTurn != 0 {
+				return &rules.RuleError{
+					ErrorType: rules.RuleRequestedForModificationWasImmutable,
+					Err:       errors.Errorf("Rule '%v' not pulled into play: immutable rules cannot have a sunset clause", ruleMatrix.RuleName),
+				}
+			}
 			err := l.gameState.PullRuleIntoPlay(ruleMatrix.RuleName)
 			if ruleErr, ok := err.(*rules.RuleError); ok {
 				if ruleErr.Type() == rules.RuleNotInAvailableRulesCache {
 					return ruleErr
 				}
 			}
+			// the rule enters play with the proposed sunset clause
+			if inPlayRule, ok := l.gameState.RulesInfo.CurrentRulesInPlay[ruleMatrix.RuleName]; ok && ruleMatrix.ExpiryTurn != inPlayRule.ExpiryTurn {
+				return l.gameState.SetRuleExpiry(ruleMatrix.RuleName, ruleMatrix.ExpiryTurn)
+			}
 		} else {
 			err := l.gameState.PullRuleOutOfPlay(ruleMatrix.RuleName)
 			if ruleErr, ok := err.(*rules.RuleError); ok {
@@ -227,7 +398,13 @@ func (l *legislature) updateRules(ruleMatrix rules.RuleMatrix, ruleIsVotedIn boo
 		}
 	} else { //if the proposed ruleMatrix has different content to the rule with the same name in AvailableRules, the proposal is for modifying the rule in the rule caches. It doesn't put a rule in/out of play.
 		if ruleIsVotedIn {
+			if l.gameConf.RejectConflictingRules && len(l.ruleConflicts) > 0 {
+				return errors.Errorf("Rule '%v' not modified: conflicts with %v", ruleMatrix.RuleName, l.ruleConflicts)
+			}
 			err := l.gameState.ModifyRule(ruleMatrix.RuleName, ruleMatrix.ApplicableMatrix, ruleMatrix.AuxiliaryVector)
+			if err == nil && ruleMatrix.ExpiryTurn != l.gameState.RulesInfo.AvailableRules[ruleMatrix.RuleName].ExpiryTurn {
+				err = l.gameState.SetRuleExpiry(ruleMatrix.RuleName, ruleMatrix.ExpiryTurn)
+			}
 			return err
 		}
 	}
@@ -239,7 +416,8 @@ func (l *legislature) updateRules(ruleMatrix rules.RuleMatrix, ruleIsVotedIn boo
 // appointNextJudge returns the island ID of the island appointed to be Judge in the next turn
 func (l *legislature) appointNextJudge(monitoring shared.MonitorResult, currentJudge shared.ClientID, allIslands []shared.ClientID) (shared.ClientID, error) {
 	var election = voting.Election{
-		Logger: l.logger,
+		Logger:       l.logger,
+		TieBreakSeed: getElectionTieBreakSeed(l.gameConf, l.gameState, shared.Judge),
 	}
 	var appointedJudge shared.ClientID
 	allIslandsCopy1 := copyClientList(allIslands)
@@ -251,11 +429,11 @@ func (l *legislature) appointNextJudge(monitoring shared.MonitorResult, currentJ
 	valuesToCache := [][]float64{{boolToFloat(termCondition)}, {boolToFloat(electionSettings.HoldElection)}}
 	l.monitoring.addToCache(l.SpeakerID, variablesToCache, valuesToCache)
 
+	election.ProposeElection(shared.Judge, electionSettings.VotingMethod)
 	if electionSettings.HoldElection {
 		if !l.incurServiceCharge(l.gameConf.AppointNextJudgeActionCost) {
 			return l.gameState.JudgeID, errors.Errorf("Insufficient Budget in common Pool: appointNextJudge")
 		}
-		election.ProposeElection(shared.Judge, electionSettings.VotingMethod)
 		allIslandsCopy2 := copyClientList(allIslands)
 		election.OpenBallot(electionSettings.IslandsToVote, allIslandsCopy2)
 		election.Vote(l.iigoClients)
@@ -269,9 +447,13 @@ func (l *legislature) appointNextJudge(monitoring shared.MonitorResult, currentJ
 		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}}
 		l.monitoring.addToCache(l.SpeakerID, variablesToCache, valuesToCache)
 		l.Logf("Result of election for new Judge: %v", appointedJudge)
+		if !appointmentMatchesVote {
+			l.Logf("Election result overridden: %v won the election for Judge but %v was appointed", electedJudge, appointedJudge)
+		}
 	} else {
 		appointedJudge = currentJudge
 	}
+	election.AppointWinner(appointedJudge)
 	l.gameState.IIGOElection = append(l.gameState.IIGOElection, election.GetVotingInfo())
 	return appointedJudge, nil
 }
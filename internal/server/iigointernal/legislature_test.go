@@ -8,6 +8,7 @@ import (
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/voting"
 	"github.com/SOMAS2020/SOMAS2020/pkg/testutils"
 	"gonum.org/v1/gonum/mat"
 )
@@ -415,3 +416,322 @@ func TestSpeakerIncureServiceCharge(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRuleVoteProcedure(t *testing.T) {
+	avail, inPlay := generateRulesTestStores()
+	immutableRule := genRuleMatrixExample1("Immutable Rule")
+	immutableRule.Mutable = false
+	avail["Immutable Rule"] = immutableRule
+	overridden := shared.RuleVoteProcedure{DecisionRule: shared.UnanimousRuleVote, Weighting: shared.ResourceVoteWeighting}
+	immutable := shared.RuleVoteProcedure{DecisionRule: shared.SupermajorityRuleVote}
+	l := legislature{
+		gameState: &gamestate.GameState{
+			RulesInfo: gamestate.RulesContext{AvailableRules: avail, CurrentRulesInPlay: inPlay},
+		},
+		gameConf: &config.IIGOConfig{
+			ImmutableRuleVoteProcedure: immutable,
+			RuleVoteProcedureOverrides: map[string]shared.RuleVoteProcedure{"TestingRule1": overridden},
+		},
+	}
+	cases := []struct {
+		ruleName string
+		want     shared.RuleVoteProcedure
+	}{
+		{ruleName: "Kinda Test Rule", want: shared.RuleVoteProcedure{}},
+		{ruleName: "Immutable Rule", want: immutable},
+		{ruleName: "TestingRule1", want: overridden},
+		{ruleName: "Unknown Rule", want: shared.RuleVoteProcedure{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.ruleName, func(t *testing.T) {
+			if got := l.getRuleVoteProcedure(tc.ruleName); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckRuleVoteThresholds(t *testing.T) {
+	avail, inPlay := rules.InitialRuleRegistration(false)
+	fakeGameState := gamestate.GameState{
+		RulesInfo: gamestate.RulesContext{
+			AvailableRules:     avail,
+			CurrentRulesInPlay: inPlay,
+			VariableMap:        rules.InitialVarRegistration(),
+		},
+	}
+	conf := config.IIGOConfig{RuleVoteQuorum: 0.5, RuleVoteSupermajority: 0.75}
+	l := legislature{gameState: &fakeGameState, gameConf: &conf, logger: func(string, ...interface{}) {}}
+	// 3 of 6 islands vote, 2/3 of the ballot weight in favour
+	ballotBox := voting.BallotBox{VotesInFavour: 2, VotesAgainst: 1, WeightInFavour: 2, WeightAgainst: 1}
+
+	// thresholds are taken from the config while the threshold rules are out of play
+	if quorumMet, supermajorityMet := l.checkRuleVoteThresholds(ballotBox, 6); !quorumMet || supermajorityMet {
+		t.Errorf("want config quorum met and supermajority not met, got %v and %v", quorumMet, supermajorityMet)
+	}
+	turnout := fakeGameState.RulesInfo.VariableMap[rules.RuleVoteTurnout].Values
+	approval := fakeGameState.RulesInfo.VariableMap[rules.RuleVoteApproval].Values
+	if !reflect.DeepEqual(turnout, []float64{0.5}) || !reflect.DeepEqual(approval, []float64{2.0 / 3.0}) {
+		t.Errorf("want turnout 0.5 and approval 2/3 recorded, got %v and %v", turnout, approval)
+	}
+
+	if err := SetRuleVoteThresholds(&fakeGameState, config.IIGOConfig{RuleVoteQuorum: 0.6, RuleVoteSupermajority: 0.6}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ruleName := range []string{"rule_vote_quorum", "rule_vote_supermajority"} {
+		if err := fakeGameState.PullRuleIntoPlay(ruleName); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if quorumMet, supermajorityMet := l.checkRuleVoteThresholds(ballotBox, 6); quorumMet || !supermajorityMet {
+		t.Errorf("want rule quorum not met and supermajority met, got %v and %v", quorumMet, supermajorityMet)
+	}
+}
+
+func TestGetRuleVoteWeights(t *testing.T) {
+	clientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+	l := legislature{
+		gameState: &gamestate.GameState{
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 30},
+				shared.Team2: {Resources: -5},
+				shared.Team3: {Resources: 10},
+			},
+			IIGOHistory: map[uint][]shared.Accountability{
+				1: {
+					{ClientID: shared.Team1, Pairs: []rules.VariableValuePair{{VariableName: rules.IslandTaxContribution, Values: []float64{4}}}},
+					{ClientID: shared.Team2, Pairs: []rules.VariableValuePair{{VariableName: rules.SanctionPaid, Values: []float64{9}}}},
+				},
+				2: {
+					{ClientID: shared.Team1, Pairs: []rules.VariableValuePair{{VariableName: rules.IslandTaxContribution, Values: []float64{6}}}},
+					{ClientID: shared.Team3, Pairs: []rules.VariableValuePair{{VariableName: rules.IslandTaxContribution, Values: []float64{1}}}},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		name      string
+		weighting shared.RuleVoteWeighting
+		want      map[shared.ClientID]float64
+	}{
+		{
+			name:      "equal",
+			weighting: shared.EqualVoteWeighting,
+			want:      nil,
+		},
+		{
+			name:      "resources",
+			weighting: shared.ResourceVoteWeighting,
+			want:      map[shared.ClientID]float64{shared.Team1: 30, shared.Team2: 0, shared.Team3: 10},
+		},
+		{
+			name:      "contributions",
+			weighting: shared.ContributionVoteWeighting,
+			want:      map[shared.ClientID]float64{shared.Team1: 10, shared.Team2: 0, shared.Team3: 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := l.getRuleVoteWeights(tc.weighting, clientIDs)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	// no island has contributed yet => equal weights
+	l.gameState.IIGOHistory = map[uint][]shared.Accountability{}
+	if got := l.getRuleVoteWeights(shared.ContributionVoteWeighting, clientIDs); got != nil {
+		t.Errorf("want equal weights when no island has contributed, got %v", got)
+	}
+}
+
+func TestCheckRuleConflicts(t *testing.T) {
+	parse := func(text string) rules.RuleMatrix {
+		rule, err := rules.ParseRule(text)
+		if err != nil {
+			t.Fatalf("invalid rule: %v", err)
+		}
+		return rule
+	}
+	tax := parse("rule tax: IslandTaxContribution - ExpectedTaxContribution >= 0")
+	expected := parse("rule expected: ExpectedTaxContribution == 10")
+	cap := parse("rule cap: IslandTaxContribution < 10")
+	looseCap := parse("rule loose_cap: IslandTaxContribution < 20")
+	avail := map[string]rules.RuleMatrix{tax.RuleName: tax, expected.RuleName: expected, cap.RuleName: cap, looseCap.RuleName: looseCap}
+	inPlay := map[string]rules.RuleMatrix{tax.RuleName: tax, expected.RuleName: expected}
+	modifiedExpected := parse("rule expected: ExpectedTaxContribution == 20")
+
+	cases := []struct {
+		name       string
+		rule       rules.RuleMatrix
+		votedIn    bool
+		reject     bool
+		want       []string
+		wantInPlay bool
+	}{
+		{name: "compatible rule", rule: looseCap, votedIn: true, want: nil, wantInPlay: true},
+		{name: "conflicting rule is reported", rule: cap, votedIn: true, want: []string{"expected", "tax"}, wantInPlay: true},
+		{name: "conflicting rule is rejected", rule: cap, votedIn: true, reject: true, want: []string{"expected", "tax"}, wantInPlay: false},
+		{name: "rule voted out", rule: cap, votedIn: false, want: nil, wantInPlay: false},
+		{name: "modified rule in play", rule: modifiedExpected, votedIn: true, want: nil, wantInPlay: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := legislature{
+				gameState: &gamestate.GameState{
+					CommonPool:      400,
+					IIGORolesBudget: map[shared.Role]shared.Resources{shared.Speaker: 10},
+					RulesInfo: gamestate.RulesContext{
+						AvailableRules:     rules.CopyRulesMap(avail),
+						CurrentRulesInPlay: rules.CopyRulesMap(inPlay),
+					},
+				},
+				gameConf: &config.IIGOConfig{RejectConflictingRules: tc.reject},
+				logger:   func(format string, a ...interface{}) {},
+			}
+			l.checkRuleConflicts(tc.rule, tc.votedIn)
+			if !reflect.DeepEqual(l.ruleConflicts, tc.want) {
+				t.Errorf("want conflicts %v, got %v", tc.want, l.ruleConflicts)
+			}
+			err := l.updateRules(tc.rule, tc.votedIn)
+			if _, inPlay := l.gameState.RulesInfo.CurrentRulesInPlay[tc.rule.RuleName]; inPlay != tc.wantInPlay {
+				t.Errorf("want rule in play %v, got %v (error %v)", tc.wantInPlay, inPlay, err)
+			}
+
+			message := generateVotingResultMessage(tc.rule, tc.votedIn, l.ruleConflicts)
+			if content, ok := message[shared.RuleConflicts]; ok != (tc.want != nil) || !reflect.DeepEqual(content.TextListData, tc.want) {
+				t.Errorf("want conflicts %v announced, got %v", tc.want, message)
+			}
+		})
+	}
+}
+
+func TestUpdateRulesWithSunsetClause(t *testing.T) {
+	parsed, err := rules.ParseRules(`
+		rule out_of_play: IslandTaxContribution >= 0
+		rule in_play: ExpectedTaxContribution >= 0`)
+	if err != nil {
+		t.Fatalf("invalid rules: %v", err)
+	}
+	avail := map[string]rules.RuleMatrix{}
+	for _, rule := range parsed {
+		avail[rule.RuleName] = rule
+	}
+	l := legislature{
+		gameState: &gamestate.GameState{
+			Turn:            4,
+			CommonPool:      400,
+			IIGORolesBudget: map[shared.Role]shared.Resources{shared.Speaker: 10},
+			RulesInfo: gamestate.RulesContext{
+				AvailableRules:     avail,
+				CurrentRulesInPlay: map[string]rules.RuleMatrix{"in_play": avail["in_play"]},
+			},
+		},
+		gameConf: &config.IIGOConfig{},
+	}
+
+	// a rule out of play enters play with its sunset clause in a single vote
+	withSunset := rules.CopyRuleMatrix(avail["out_of_play"])
+	withSunset.ExpiryTurn = 8
+	if err := l.updateRules(withSunset, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, cache := range []map[string]rules.RuleMatrix{l.gameState.RulesInfo.AvailableRules, l.gameState.RulesInfo.CurrentRulesInPlay} {
+		if got := cache["out_of_play"]; !reflect.DeepEqual(got, withSunset) {
+			t.Errorf("want rule %v with sunset clause, got %v", withSunset, got)
+		}
+	}
+	if versions := l.gameState.RulesInfo.RuleHistory["out_of_play"]; len(versions) != 1 || versions[0].Change != gamestate.RulePulledIntoPlay {
+		t.Errorf("want the rule recorded as pulled into play, got %+v", versions)
+	}
+
+	// an immutable rule proposed with a sunset clause is neither pulled into play nor changed
+	immutable := rules.CopyRuleMatrix(avail["out_of_play"])
+	immutable.RuleName = "immutable"
+	immutable.Mutable = false
+	l.gameState.RulesInfo.AvailableRules["immutable"] = rules.CopyRuleMatrix(immutable)
+	withSunset = rules.CopyRuleMatrix(immutable)
+	withSunset.ExpiryTurn = 8
+	err = l.updateRules(withSunset, true)
+	if ruleErr, ok := err.(*rules.RuleError); !ok || ruleErr.Type() != rules.RuleRequestedForModificationWasImmutable {
+		t.Errorf("want an immutable rule error, got %v", err)
+	}
+	if _, ok := l.gameState.RulesInfo.CurrentRulesInPlay["immutable"]; ok {
+		t.Errorf("want the immutable rule left out of play")
+	}
+	if got := l.gameState.RulesInfo.AvailableRules["immutable"]; !reflect.DeepEqual(got, immutable) {
+		t.Errorf("want immutable rule %v unchanged, got %v", immutable, got)
+	}
+	if versions := l.gameState.RulesInfo.RuleHistory["immutable"]; len(versions) != 0 {
+		t.Errorf("want no history for the immutable rule, got %+v", versions)
+	}
+
+	// rejecting a sunset clause for a rule in play leaves the rule in play
+	withSunset = rules.CopyRuleMatrix(avail["in_play"])
+	withSunset.ExpiryTurn = 8
+	if err := l.updateRules(withSunset, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := l.gameState.RulesInfo.CurrentRulesInPlay["in_play"]; !ok || got.ExpiryTurn != 0 {
+		t.Errorf("want rule left in play without a sunset clause, got %v (in play: %v)", got, ok)
+	}
+}
+
+func TestUpdateRulesRecordsHistory(t *testing.T) {
+	parsed, err := rules.ParseRules(`
+		rule parent: IslandTaxContribution >= 0 link AndLink child
+		rule child: ExpectedTaxContribution >= 0`)
+	if err != nil {
+		t.Fatalf("invalid rules: %v", err)
+	}
+	avail := map[string]rules.RuleMatrix{}
+	for _, rule := range parsed {
+		avail[rule.RuleName] = rule
+	}
+	original := rules.CopyRuleMatrix(avail["parent"])
+	l := legislature{
+		gameState: &gamestate.GameState{
+			Turn:            4,
+			CommonPool:      400,
+			IIGORolesBudget: map[shared.Role]shared.Resources{shared.Speaker: 10},
+			RulesInfo: gamestate.RulesContext{
+				AvailableRules:     avail,
+				CurrentRulesInPlay: map[string]rules.RuleMatrix{},
+			},
+		},
+		gameConf:     &config.IIGOConfig{},
+		ruleProposer: shared.Team2,
+		ballotBox:    voting.BallotBox{VotesInFavour: 3, VotesAgainst: 1},
+	}
+
+	// pulling the parent into play brings the linked child with it
+	if err := l.updateRules(avail["parent"], true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	history := l.gameState.RulesInfo.RuleHistory
+	for _, name := range []string{"parent", "child"} {
+		if len(history[name]) != 1 {
+			t.Fatalf("want one version of %v, got %v", name, history[name])
+		}
+		version := history[name][0]
+		if version.Change != gamestate.RulePulledIntoPlay || version.Turn != 4 || !version.ByVote || version.Proposer != shared.Team2 || version.VotesInFavour != 3 || version.VotesAgainst != 1 {
+			t.Errorf("unexpected version of %v: %+v", name, version)
+		}
+	}
+
+	// a modification can add a sunset clause, and keeps the link
+	modified := rules.CopyRuleMatrix(original)
+	modified.ApplicableMatrix.Set(0, 1, -5)
+	modified.ExpiryTurn = 6
+	if err := l.updateRules(modified, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := l.gameState.RulesInfo.CurrentRulesInPlay["parent"]; !reflect.DeepEqual(got, modified) {
+		t.Errorf("want modified rule %v in play, got %v", modified, got)
+	}
+	versions := history["parent"]
+	if len(versions) != 2 || versions[1].Change != gamestate.RuleModified || !reflect.DeepEqual(versions[1].PreviousRule, original) || !reflect.DeepEqual(versions[1].Rule, modified) {
+		t.Errorf("unexpected history %+v", versions)
+	}
+}
@@ -59,6 +59,11 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 		logger:           logger,
 	}
 
+	// Takes rules whose sunset clause has been reached out of play
+	if expired := g.ExpireRules(); len(expired) > 0 {
+		logger("Rules reached their sunset clause and left play: %v", expired)
+	}
+
 	// Increments the budget according to increment_budget_role rules
 	PresidentIncRule, ok := g.RulesInfo.CurrentRulesInPlay["increment_budget_president"]
 	if ok {
@@ -193,6 +198,7 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 
 	//TODO:- shouldn't updateRules be called somewhere?
 	insufficientBudget = legislativeBranch.setRuleToVote(ruleToVoteReturn.ProposedRuleMatrix)
+	legislativeBranch.ruleProposer = executiveBranch.getRuleProposer(ruleToVoteReturn.ProposedRuleMatrix)
 
 	if insufficientBudget != nil {
 		return false, "Common pool resources insufficient for legislativeBranch setRuleToVote"
@@ -201,6 +207,7 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 	if insufficientBudget != nil {
 		return false, "Common pool resources insufficient for legislativeBranch setVotingResult"
 	}
+	legislativeBranch.checkRuleConflicts(legislativeBranch.ruleToVote, legislativeBranch.votingResult)
 	err := legislativeBranch.updateRules(legislativeBranch.ruleToVote, legislativeBranch.votingResult)
 	if err != nil {
 		logger("Error updating rules with result: %v", err)
@@ -4,6 +4,7 @@ import (
 	"math/rand"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
@@ -73,6 +74,12 @@ func copyClientList(in []shared.ClientID) []shared.ClientID {
 	return ret
 }
 
+// getElectionTieBreakSeed returns the tie-break seed of an election. Elections are reproducible for a given configured
+// seed, but each turn and role gets its own tie-breaking ranking.
+func getElectionTieBreakSeed(iigoConf *config.IIGOConfig, g *gamestate.GameState, role shared.Role) int64 {
+	return iigoConf.ElectionTieBreakSeed + int64(g.Turn)<<2 + int64(role) // there are fewer than 4 roles
+}
+
 // if an IIGO role is dead, it is replaced with a random living island
 func removeDeadBodiesFromOffice(g *gamestate.GameState) {
 	aliveClientIds := []shared.ClientID{}
@@ -34,6 +34,9 @@ type SOMASServer struct {
 	// not contain pointers to other clients!
 	clientMap map[shared.ClientID]baseclient.Client
 
+	// huntingParties are the hunting parties formed for the foraging session in progress
+	huntingParties []shared.HuntingParty
+
 	// prevent the same instance from being run twice
 	ran bool
 }
@@ -66,8 +69,10 @@ func createSOMASServer(
 	}
 
 	forageHistory := map[shared.ForageType][]foraging.ForagingReport{}
+	populationHistory := map[shared.ForageType][]foraging.PopulationRecord{}
 	for _, t := range shared.AllForageTypes() {
 		forageHistory[t] = make([]foraging.ForagingReport, 0)
+		populationHistory[t] = make([]foraging.PopulationRecord, 0)
 	}
 
 	availableRules, rulesInPlay := rules.InitialRuleRegistration(gameConfig.IIGOConfig.StartWithRulesInPlay)
@@ -75,6 +80,10 @@ func createSOMASServer(
 	if err != nil {
 		return nil, errors.Errorf("Cannot initialise IIGO roles: %v", err)
 	}
+	environment, err := disasters.InitEnvironment(clientIDs, gameConfig.DisasterConfig)
+	if err != nil {
+		return nil, errors.Errorf("Cannot initialise environment: %v", err)
+	}
 
 	server := &SOMASServer{
 		clientMap:  clientMap,
@@ -83,12 +92,15 @@ func createSOMASServer(
 			Season:                  1,
 			Turn:                    1,
 			ClientInfos:             clientInfos,
-			Environment:             disasters.InitEnvironment(clientIDs, gameConfig.DisasterConfig),
+			Environment:             environment,
 			ForagingHistory:         forageHistory,
+			PopulationHistory:       populationHistory,
 			IIGOHistory:             map[uint][]shared.Accountability{},
 			IIGOSanctionCache:       iigointernal.DefaultInitLocalSanctionCache(3),
 			IIGOHistoryCache:        iigointernal.DefaultInitLocalHistoryCache(3),
 			IIGORoleMonitoringCache: []shared.Accountability{},
+			IIFOPendingPredictions:  map[shared.ClientID][]disasters.PendingPrediction{},
+			IIFOForecastingAccuracy: map[shared.ClientID]disasters.ForecastingAccuracy{},
 			IIGORolesBudget: map[shared.Role]shared.Resources{
 				shared.President: 0,
 				shared.Judge:     0,
@@ -107,12 +119,18 @@ func createSOMASServer(
 				AvailableRules:     availableRules,
 				CurrentRulesInPlay: rulesInPlay,
 				VariableMap:        rules.InitialVarRegistration(),
+				RuleHistory:        map[string][]gamestate.RuleVersion{},
 			},
 		},
 		ran: false,
 	}
 
+	if err := iigointernal.SetRuleVoteThresholds(&server.gameState, gameConfig.IIGOConfig); err != nil {
+		return nil, errors.Errorf("Cannot initialise rule vote thresholds: %v", err)
+	}
+
 	server.gameState.DeerPopulation = foraging.CreateDeerPopulationModel(gameConfig.ForagingConfig.DeerHuntConfig, server.logf)
+	server.gameState.FishPopulation = foraging.CreateFishPopulationModel(gameConfig.ForagingConfig.FishingConfig, server.logf)
 
 	for _, client := range clientMap {
 		client.Initialise(ServerForClient{
@@ -178,6 +196,16 @@ func (s ServerForClient) GetGameConfig() config.ClientConfig {
 	return s.server.gameConfig.GetClientConfig()
 }
 
+// GetForageReturnEstimate estimates the distribution of the resources the client would get back from contributing
+// contribution to a foraging session of forageType, alongside the known contributions of other islands.
+func (s ServerForClient) GetForageReturnEstimate(
+	forageType shared.ForageType,
+	contribution shared.Resources,
+	otherContributions map[shared.ClientID]shared.Resources,
+) (foraging.ReturnDistribution, error) {
+	return s.server.estimateForageReturn(s.clientID, forageType, contribution, otherContributions)
+}
+
 func getNRandClientIDsUniqueIfPossible(input []shared.ClientID, n int) ([]shared.ClientID, error) {
 	if len(input) == 0 {
 		return nil, errors.Errorf("empty list")
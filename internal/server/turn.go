@@ -70,6 +70,12 @@ func (s *SOMASServer) endOfTurn() error {
 		return errors.Errorf("Failed to run hunt at end of turn: %v", err)
 	}
 
+	s.updateForagePopulations() // regenerate forage stocks, whether or not anyone foraged
+
+	if err := s.growFarmPlots(); err != nil {
+		return errors.Errorf("Failed to grow farm plots at end of turn: %v", err)
+	}
+
 	if err := s.runIIFOEndOfTurn(); err != nil {
 		return errors.Errorf("IIFO EndOfTurn error: %v", err)
 	}
@@ -93,9 +99,12 @@ func (s *SOMASServer) endOfTurn() error {
 	disasterHappened := updatedEnv.LastDisasterReport.Magnitude > 0
 
 	if disasterHappened {
-		s.applyDisasterEffects()    // compute effects taking into account CP and deduct resources accordingly
-		s.notifyClientsOfDisaster() // sends disaster report and effects to all non-dead clients
+		s.applyDisasterEffects()        // compute effects taking into account CP and deduct resources accordingly
+		s.damageFarmPlots()             // destroys part of the crops that are yet to be harvested
+		s.applyDisasterEcologyEffects() // depletes deer and fish stocks and temporarily reduces catch probabilities
+		s.notifyClientsOfDisaster()     // sends disaster report and effects to all non-dead clients
 	}
+	s.scorePredictions(disasterHappened) // scores IIFO predictions due this turn against the disaster, if any
 	s.incrementTurnAndSeason(disasterHappened)
 
 	// deduct cost of living
@@ -47,6 +47,13 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == whatIfCommand {
+		if err := runWhatIf(os.Args[2:], os.Stdout); err != nil && err != flag.ErrHelp {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
 	timeStart := time.Now()
 	rand.Seed(timeStart.UTC().UnixNano())
 
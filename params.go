@@ -2,8 +2,10 @@ package main
 
 import (
 	"flag"
+	"os"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 	"github.com/pkg/errors"
 )
@@ -105,6 +107,26 @@ var (
 		0.4,
 		"Scaling parameter used in the population model. Larger coeff => deer pop. regenerates faster.",
 	)
+	foragingDeerPopulationModel = flag.Int(
+		"foragingDeerPopulationModel",
+		int(shared.BasicPopulationModel),
+		shared.HelpPopulationModelType(),
+	)
+	foragingDeerAlleeThreshold = flag.Float64(
+		"foragingDeerAlleeThreshold",
+		4,
+		"Critical deer population below which deer collapse when using the AlleePopulationModel.",
+	)
+	foragingDeerStagHuntRule = flag.Int(
+		"foragingDeerStagHuntRule",
+		int(shared.NoStagHuntRule),
+		shared.HelpStagHuntRule(),
+	)
+	foragingDeerMinHuntParticipants = flag.Uint(
+		"foragingDeerMinHuntParticipants",
+		2,
+		"Number of deer hunt participants needed for a full chance of catching deer when using a stag hunt rule.",
+	)
 
 	// config.ForagingConfig.FishingConfig
 	foragingFishMaxPerHunt = flag.Uint(
@@ -120,12 +142,17 @@ var (
 	foragingFishingMean = flag.Float64(
 		"foragingFishingMean",
 		1.45,
-		"Determines mean of normal distribution of fishing return (see foraging README)",
+		"Determines mean of fish size distribution (see foraging README)",
 	)
 	foragingFishingVariance = flag.Float64(
 		"foragingFishingVariance",
 		0.1,
-		"Determines variance of normal distribution of fishing return (see foraging README)",
+		"Determines variance of fish size distribution (see foraging README)",
+	)
+	foragingFishSizeDistribution = flag.Int(
+		"foragingFishSizeDistribution",
+		int(shared.TruncatedNormalFishSize),
+		shared.HelpFishSizeDistribution(),
 	)
 	foragingFishingInputScaler = flag.Float64(
 		"foragingFishingInputScaler",
@@ -142,6 +169,70 @@ var (
 		int(shared.EqualSplit),
 		shared.HelpResourceDistributionStrategy(),
 	)
+	foragingFishThetaCritical = flag.Float64(
+		"foragingFishThetaCritical",
+		0.97,
+		"Bernoulli prob of catching a fish when population ratio = running population/max fish per hunt = 1",
+	)
+	foragingFishThetaMax = flag.Float64(
+		"foragingFishThetaMax",
+		0.99,
+		"Bernoulli prob of catching a fish when population is at carrying capacity (max population)",
+	)
+	foragingFishMaxPopulation = flag.Uint(
+		"foragingFishMaxPopulation",
+		60,
+		"Max possible fish population. 0 => unlimited fish stock. ** Otherwise, should be strictly greater than max fish per hunt.",
+	)
+	foragingFishGrowthRate = flag.Float64(
+		"foragingFishGrowthRate",
+		0.4,
+		"Growth rate used in the logistic fish population model. Larger rate => fish pop. regenerates faster.",
+	)
+
+	// config.FarmingConfig
+	foragingFarmMaturityTurns = flag.Uint(
+		"foragingFarmMaturityTurns",
+		3,
+		"Number of turns crops grow for before they are harvested (including the turn they are planted in).",
+	)
+	foragingFarmGrowthCurve = flag.Int(
+		"foragingFarmGrowthCurve",
+		int(shared.LogisticFarmGrowth),
+		shared.HelpFarmGrowthCurve(),
+	)
+	foragingFarmYieldMultiplier = flag.Float64(
+		"foragingFarmYieldMultiplier",
+		1.5,
+		"Return per resource invested in farming if crops grow undamaged until maturity.",
+	)
+	foragingFarmDisasterSensitivity = flag.Float64(
+		"foragingFarmDisasterSensitivity",
+		0.5,
+		"Fraction of standing crops destroyed per unit of disaster magnitude felt by the island that owns them.",
+	)
+
+	// config.ForagingConfig
+	foragingRankingBasis = flag.Int(
+		"foragingRankingBasis",
+		int(shared.ContributionRanking),
+		shared.HelpRankingBasis(),
+	)
+	foragingMinimumShare = flag.Float64(
+		"foragingMinimumShare",
+		0.1,
+		"Fraction of total foraging return guaranteed to each participant when using InputProportionalWithMinimumShareSplit",
+	)
+	foragingReturnOracleVisible = flag.Bool(
+		"foragingReturnOracleVisible",
+		true,
+		"Whether clients can query the expected return of a hypothetical foraging contribution",
+	)
+	foragingReturnOracleSamples = flag.Uint(
+		"foragingReturnOracleSamples",
+		500,
+		"Number of foraging sessions sampled to estimate the return distribution of a hypothetical foraging contribution",
+	)
 
 	// config.DisasterConfig
 	disasterXMin = flag.Float64(
@@ -164,6 +255,21 @@ var (
 		10,
 		"Max y bound of archipelago (bounds for possible disaster).",
 	)
+	disasterIslandLayout = flag.Int(
+		"disasterIslandLayout",
+		0,
+		shared.HelpIslandLayout(),
+	)
+	disasterIslandMinSeparation = flag.Float64(
+		"disasterIslandMinSeparation",
+		1,
+		"Min distance between any two islands when islands are scattered randomly (ScatterLayout).",
+	)
+	disasterIslandPositions = flag.String(
+		"disasterIslandPositions",
+		"",
+		"Island co-ordinates used in ExplicitLayout, in team order. Must be within the disaster bounds. Format: `x1:y1;x2:y2;...`",
+	)
 	disasterPeriod = flag.Uint(
 		"disasterPeriod",
 		5,
@@ -179,6 +285,16 @@ var (
 		1,
 		"Exponential rate param for disaster magnitude",
 	)
+	disasterAttenuationType = flag.Int(
+		"disasterAttenuationType",
+		0,
+		shared.HelpAttenuationType(),
+	)
+	disasterAttenuationScale = flag.Float64(
+		"disasterAttenuationScale",
+		2,
+		"Length scale for ExponentialDecay and Gaussian disaster attenuation, radius for HardCutoff attenuation",
+	)
 	disasterMagnitudeResourceMultiplier = flag.Float64(
 		"disasterMagnitudeResourceMultiplier",
 		85,
@@ -189,11 +305,101 @@ var (
 		200,
 		"Common pool threshold value for disaster to be mitigated",
 	)
+	disasterMitigationCurveType = flag.Int(
+		"disasterMitigationCurveType",
+		0,
+		shared.HelpMitigationCurveType(),
+	)
+	disasterMitigationCurvePoints = flag.String(
+		"disasterMitigationCurvePoints",
+		"",
+		"(CP level, damage multiplier) points used by PiecewiseLinear and StepTable mitigation curves. Format: `level1:multiplier1;level2:multiplier2;...`",
+	)
+	disasterMitigationLogisticMidpoint = flag.Float64(
+		"disasterMitigationLogisticMidpoint",
+		200,
+		"CP level at which the logistic mitigation multiplier is halfway between 1 and disasterMitigationMinMultiplier",
+	)
+	disasterMitigationLogisticSteepness = flag.Float64(
+		"disasterMitigationLogisticSteepness",
+		0.02,
+		"Steepness of the logistic mitigation curve around its midpoint",
+	)
+	disasterMitigationMinMultiplier = flag.Float64(
+		"disasterMitigationMinMultiplier",
+		0.5,
+		"Lower asymptote (max. mitigation) of the logistic mitigation curve",
+	)
 	disasterStochasticPeriod = flag.Bool(
 		"disasterStochasticPeriod",
 		false,
 		"If true, period between disasters becomes random. If false, it will be consistent (deterministic)",
 	)
+	disasterProcess = flag.Int(
+		"disasterProcess",
+		0,
+		shared.HelpDisasterProcess(),
+	)
+	disasterMarkovCalmPeriod = flag.Uint(
+		"disasterMarkovCalmPeriod",
+		10,
+		"E[T] between disasters in the calm regime of MarkovModulatedProcess",
+	)
+	disasterMarkovStormyPeriod = flag.Uint(
+		"disasterMarkovStormyPeriod",
+		2,
+		"E[T] between disasters in the stormy regime of MarkovModulatedProcess",
+	)
+	disasterMarkovCalmToStormyProb = flag.Float64(
+		"disasterMarkovCalmToStormyProb",
+		0.1,
+		"Prob. of MarkovModulatedProcess switching from the calm to the stormy regime each turn",
+	)
+	disasterMarkovStormyToCalmProb = flag.Float64(
+		"disasterMarkovStormyToCalmProb",
+		0.3,
+		"Prob. of MarkovModulatedProcess switching from the stormy to the calm regime each turn",
+	)
+	disasterHawkesBaseRate = flag.Float64(
+		"disasterHawkesBaseRate",
+		0.1,
+		"Background disaster intensity of HawkesProcess",
+	)
+	disasterHawkesExcitation = flag.Float64(
+		"disasterHawkesExcitation",
+		0.3,
+		"Disaster intensity added per unit magnitude of a disaster in HawkesProcess",
+	)
+	disasterHawkesDecay = flag.Float64(
+		"disasterHawkesDecay",
+		0.5,
+		"Per-turn exponential decay rate of the intensity added by past disasters in HawkesProcess",
+	)
+	disasterReplayFile = flag.String(
+		"disasterReplayFile",
+		"",
+		"CSV file of disasters replayed by ReplayProcess. Format: one `turn,x,y,magnitude` record per line",
+	)
+	disasterDeerStockSensitivity = flag.Float64(
+		"disasterDeerStockSensitivity",
+		0.1,
+		"Fraction of the deer population killed per unit of disaster magnitude felt by the islands (on average)",
+	)
+	disasterFishStockSensitivity = flag.Float64(
+		"disasterFishStockSensitivity",
+		0.1,
+		"Fraction of the fish population killed per unit of disaster magnitude felt by the islands (on average)",
+	)
+	disasterCatchProbabilityShock = flag.Float64(
+		"disasterCatchProbabilityShock",
+		0.2,
+		"Reduction in the multiplier on deer and fish catch probabilities per unit of disaster magnitude felt by the islands",
+	)
+	disasterCatchShockDuration = flag.Uint(
+		"disasterCatchShockDuration",
+		2,
+		"Number of turns for which catch probabilities are reduced after a disaster",
+	)
 	disasterCommonpoolThresholdVisible = flag.Bool(
 		"disasterCommonpoolThresholdVisible",
 		false,
@@ -209,6 +415,11 @@ var (
 		true,
 		"Whether stochasticPeriod is visible to agents",
 	)
+	disasterMitigationCurveVisible = flag.Bool(
+		"disasterMitigationCurveVisible",
+		false,
+		"Whether the mitigation curve is visible to agents",
+	)
 
 	// config.IIGOConfig - Executive branch
 	iigoGetRuleForSpeakerActionCost = flag.Float64(
@@ -334,6 +545,54 @@ var (
 		"IIGO action cost for appointNextJudge action",
 	)
 
+	iigoRuleVoteQuorum = flag.Float64(
+		"iigoRuleVoteQuorum",
+		0,
+		"Fraction of alive islands that must cast a non-abstaining ballot for a rule vote to be valid. Initial value of rule 'rule_vote_quorum'",
+	)
+
+	iigoRuleVoteSupermajority = flag.Float64(
+		"iigoRuleVoteSupermajority",
+		2.0/3.0,
+		"Fraction of the cast ballot weight needed in favour of a rule under SupermajorityRuleVote. Initial value of rule 'rule_vote_supermajority'",
+	)
+
+	iigoRuleVoteDecisionRule = flag.Int(
+		"iigoRuleVoteDecisionRule",
+		int(shared.MajorityRuleVote),
+		"Decision rule of votes on mutable rules. "+shared.HelpRuleVoteDecisionRule(),
+	)
+
+	iigoRuleVoteWeighting = flag.Int(
+		"iigoRuleVoteWeighting",
+		int(shared.EqualVoteWeighting),
+		"Ballot weighting of votes on mutable rules. "+shared.HelpRuleVoteWeighting(),
+	)
+
+	iigoImmutableRuleVoteDecisionRule = flag.Int(
+		"iigoImmutableRuleVoteDecisionRule",
+		int(shared.SupermajorityRuleVote),
+		"Decision rule of votes on immutable rules. "+shared.HelpRuleVoteDecisionRule(),
+	)
+
+	iigoImmutableRuleVoteWeighting = flag.Int(
+		"iigoImmutableRuleVoteWeighting",
+		int(shared.EqualVoteWeighting),
+		"Ballot weighting of votes on immutable rules. "+shared.HelpRuleVoteWeighting(),
+	)
+
+	iigoRuleVoteProcedureOverrides = flag.String(
+		"iigoRuleVoteProcedureOverrides",
+		"",
+		"Vote procedures of specific rules, taking precedence over the above. Format: `rule1=decisionRule:weighting;rule2=decisionRule:weighting;...`",
+	)
+
+	iigoRejectConflictingRules = flag.Bool(
+		"iigoRejectConflictingRules",
+		false,
+		"If true, a rule voted in that cannot be satisfied together with the rules in play is not pulled into play",
+	)
+
 	iigoTermLengthPresident = flag.Uint(
 		"iigoTermLengthPresident",
 		4,
@@ -352,11 +611,34 @@ var (
 		"Length of the term for the Judge",
 	)
 
+	iigoElectionTieBreakSeed = flag.Int64(
+		"iigoElectionTieBreakSeed",
+		0,
+		"Seed of the tie-breaking ranking of candidates in IIGO elections (Schulze, RankedPairs and Copeland)",
+	)
+
 	startWithRulesInPlay = flag.Bool(
 		"startWithRulesInPlay",
 		true,
 		"Pull all available rules into play at start of run",
 	)
+
+	// config.IIFOConfig
+	iifoPredictionSpatialTolerance = flag.Float64(
+		"iifoPredictionSpatialTolerance",
+		2,
+		"Max distance between predicted and actual disaster epicentre for a disaster prediction to be deemed accurate",
+	)
+	iifoPredictionMagnitudeTolerance = flag.Float64(
+		"iifoPredictionMagnitudeTolerance",
+		0.5,
+		"Max abs. difference between predicted and actual disaster magnitude for a disaster prediction to be deemed accurate",
+	)
+	iifoPredictionTimeTolerance = flag.Uint(
+		"iifoPredictionTimeTolerance",
+		1,
+		"Max number of turns between predicted and actual disaster turn for a disaster prediction to be deemed accurate",
+	)
 )
 
 func parseConfig() (config.Config, error) {
@@ -375,16 +657,126 @@ func parseConfig() (config.Config, error) {
 		return config.Config{}, errors.Errorf("Error parsing foragingDeerMaxPerHunt and/or foragingDeerMaxPopulation: %v", err)
 	}
 
+	parsedForagingDeerPopulationModel, err := shared.ParsePopulationModelType(*foragingDeerPopulationModel)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing foragingDeerPopulationModel: %v", err)
+	}
+
+	parsedForagingDeerStagHuntRule, err := shared.ParseStagHuntRule(*foragingDeerStagHuntRule)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing foragingDeerStagHuntRule: %v", err)
+	}
+
 	parsedForagingFishingDistributionStrategy, err := shared.ParseResourceDistributionStrategy(*foragingFishingDistributionStrategy)
 	if err != nil {
 		return config.Config{}, errors.Errorf("Error parsing foragingFishingDistributionStrategy: %v", err)
 	}
 
+	parsedForagingFishSizeDistribution, err := shared.ParseFishSizeDistribution(*foragingFishSizeDistribution)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing foragingFishSizeDistribution: %v", err)
+	}
+
+	parsedForagingFarmGrowthCurve, err := shared.ParseFarmGrowthCurve(*foragingFarmGrowthCurve)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing foragingFarmGrowthCurve: %v", err)
+	}
+
+	parsedForagingRankingBasis, err := shared.ParseRankingBasis(*foragingRankingBasis)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing foragingRankingBasis: %v", err)
+	}
+
+	if *foragingFishMaxPopulation > 0 && *foragingFishMaxPopulation <= *foragingFishMaxPerHunt {
+		return config.Config{}, errors.Errorf("Error parsing foragingFishMaxPopulation: max fish population should be 0 or strictly greater than max fish per hunt")
+	}
+
 	parsedDisasterSpatialPDFType, err := shared.ParseSpatialPDFType(*disasterSpatialPDFType)
 	if err != nil {
 		return config.Config{}, errors.Errorf("Error parsing disasterSpatialPDFType: %v", err)
 	}
 
+	parsedDisasterAttenuationType, err := shared.ParseAttenuationType(*disasterAttenuationType)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing disasterAttenuationType: %v", err)
+	}
+
+	parsedDisasterIslandLayout, err := shared.ParseIslandLayout(*disasterIslandLayout)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing disasterIslandLayout: %v", err)
+	}
+
+	parsedDisasterIslandPositions, err := shared.ParseIslandPositions(*disasterIslandPositions)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing disasterIslandPositions: %v", err)
+	}
+	if parsedDisasterIslandLayout == shared.ExplicitLayout && len(parsedDisasterIslandPositions) != len(shared.TeamIDs) {
+		return config.Config{}, errors.Errorf("Error parsing disasterIslandPositions: ExplicitLayout requires positions for all %v islands", len(shared.TeamIDs))
+	}
+
+	parsedDisasterMitigationCurveType, err := shared.ParseMitigationCurveType(*disasterMitigationCurveType)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing disasterMitigationCurveType: %v", err)
+	}
+
+	parsedDisasterMitigationCurvePoints, err := shared.ParseMitigationPoints(*disasterMitigationCurvePoints)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing disasterMitigationCurvePoints: %v", err)
+	}
+	if len(parsedDisasterMitigationCurvePoints) == 0 &&
+		(parsedDisasterMitigationCurveType == shared.PiecewiseLinearMitigation || parsedDisasterMitigationCurveType == shared.StepTableMitigation) {
+		return config.Config{}, errors.Errorf("Error parsing disasterMitigationCurvePoints: %v requires at least one point", parsedDisasterMitigationCurveType)
+	}
+
+	parsedDisasterProcess, err := shared.ParseDisasterProcess(*disasterProcess)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing disasterProcess: %v", err)
+	}
+	if parsedDisasterProcess == shared.MarkovModulatedProcess && (*disasterMarkovCalmPeriod == 0 || *disasterMarkovStormyPeriod == 0) {
+		return config.Config{}, errors.Errorf("Error parsing disasterMarkovCalmPeriod and/or disasterMarkovStormyPeriod: periods must be positive")
+	}
+
+	parsedDisasterReplaySchedule := []shared.ScheduledDisaster{}
+	if parsedDisasterProcess == shared.ReplayProcess {
+		parsedDisasterReplaySchedule, err = readDisasterSchedule(*disasterReplayFile)
+		if err != nil {
+			return config.Config{}, errors.Errorf("Error parsing disasterReplayFile: %v", err)
+		}
+	}
+
+	if *iigoRuleVoteQuorum < 0 || *iigoRuleVoteQuorum > 1 {
+		return config.Config{}, errors.Errorf("Error parsing iigoRuleVoteQuorum: must be in [0, 1]")
+	}
+
+	if *iigoRuleVoteSupermajority < 0 || *iigoRuleVoteSupermajority > 1 {
+		return config.Config{}, errors.Errorf("Error parsing iigoRuleVoteSupermajority: must be in [0, 1]")
+	}
+
+	parsedIIGORuleVoteDecisionRule, err := shared.ParseRuleVoteDecisionRule(*iigoRuleVoteDecisionRule)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoRuleVoteDecisionRule: %v", err)
+	}
+
+	parsedIIGORuleVoteWeighting, err := shared.ParseRuleVoteWeighting(*iigoRuleVoteWeighting)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoRuleVoteWeighting: %v", err)
+	}
+
+	parsedIIGOImmutableRuleVoteDecisionRule, err := shared.ParseRuleVoteDecisionRule(*iigoImmutableRuleVoteDecisionRule)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoImmutableRuleVoteDecisionRule: %v", err)
+	}
+
+	parsedIIGOImmutableRuleVoteWeighting, err := shared.ParseRuleVoteWeighting(*iigoImmutableRuleVoteWeighting)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoImmutableRuleVoteWeighting: %v", err)
+	}
+
+	parsedIIGORuleVoteProcedureOverrides, err := shared.ParseRuleVoteProcedureOverrides(*iigoRuleVoteProcedureOverrides)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoRuleVoteProcedureOverrides: %v", err)
+	}
+
 	deerConf := config.DeerHuntConfig{
 		//Deer parameters
 		MaxDeerPerHunt:        parsedDeerMaxPerHunt,
@@ -398,6 +790,10 @@ func parseConfig() (config.Config, error) {
 		ThetaMax:              *foragingDeerThetaMax,
 		MaxDeerPopulation:     parseDeerMaxPopulation,
 		DeerGrowthCoefficient: *foragingDeerGrowthCoefficient,
+		DeerPopulationModel:   parsedForagingDeerPopulationModel,
+		DeerAlleeThreshold:    *foragingDeerAlleeThreshold,
+		StagHuntRule:          parsedForagingDeerStagHuntRule,
+		MinHuntParticipants:   *foragingDeerMinHuntParticipants,
 	}
 	fishingConf := config.FishingConfig{
 		// Fish parameters
@@ -408,10 +804,45 @@ func parseConfig() (config.Config, error) {
 		InputScaler:           *foragingFishingInputScaler,
 		OutputScaler:          *foragingFishingOutputScaler,
 		DistributionStrategy:  parsedForagingFishingDistributionStrategy,
+		FishSizeDistribution:  parsedForagingFishSizeDistribution,
+		MaxFishPopulation:     *foragingFishMaxPopulation,
+		FishGrowthRate:        *foragingFishGrowthRate,
+		ThetaCritical:         *foragingFishThetaCritical,
+		ThetaMax:              *foragingFishThetaMax,
+	}
+	farmingConf := config.FarmingConfig{
+		MaturityTurns:       *foragingFarmMaturityTurns,
+		GrowthCurve:         parsedForagingFarmGrowthCurve,
+		YieldMultiplier:     *foragingFarmYieldMultiplier,
+		DisasterSensitivity: *foragingFarmDisasterSensitivity,
 	}
 	foragingConf := config.ForagingConfig{
 		DeerHuntConfig: deerConf,
 		FishingConfig:  fishingConf,
+		FarmingConfig:  farmingConf,
+		RankingBasis:   parsedForagingRankingBasis,
+		MinimumShare:   *foragingMinimumShare,
+
+		ReturnOracleVisible: *foragingReturnOracleVisible,
+		ReturnOracleSamples: *foragingReturnOracleSamples,
+	}
+	mitigationCurve := config.MitigationCurve{
+		Type:              parsedDisasterMitigationCurveType,
+		Points:            parsedDisasterMitigationCurvePoints,
+		LogisticMidpoint:  shared.Resources(*disasterMitigationLogisticMidpoint),
+		LogisticSteepness: *disasterMitigationLogisticSteepness,
+		MinMultiplier:     *disasterMitigationMinMultiplier,
+	}
+	markovProcess := config.MarkovProcessConfig{
+		CalmPeriod:       *disasterMarkovCalmPeriod,
+		StormyPeriod:     *disasterMarkovStormyPeriod,
+		CalmToStormyProb: *disasterMarkovCalmToStormyProb,
+		StormyToCalmProb: *disasterMarkovStormyToCalmProb,
+	}
+	hawkesProcess := config.HawkesProcessConfig{
+		BaseRate:   *disasterHawkesBaseRate,
+		Excitation: *disasterHawkesExcitation,
+		Decay:      *disasterHawkesDecay,
 	}
 	disasterConf := config.DisasterConfig{
 		XMin:                        *disasterXMin,
@@ -421,18 +852,34 @@ func parseConfig() (config.Config, error) {
 		Period:                      *disasterPeriod,
 		SpatialPDFType:              parsedDisasterSpatialPDFType,
 		MagnitudeLambda:             *disasterMagnitudeLambda,
+		AttenuationType:             parsedDisasterAttenuationType,
+		AttenuationScale:            *disasterAttenuationScale,
 		StochasticPeriod:            *disasterStochasticPeriod,
 		MagnitudeResourceMultiplier: *disasterMagnitudeResourceMultiplier,
 		CommonpoolThreshold:         shared.Resources(*disasterCommonpoolThreshold),
 		CommonpoolThresholdVisible:  *disasterCommonpoolThresholdVisible,
 		PeriodVisible:               *disasterPeriodVisible,
 		StochasticPeriodVisible:     *disasterStochasticPeriodVisible,
+		MitigationCurve:             mitigationCurve,
+		MitigationCurveVisible:      *disasterMitigationCurveVisible,
+		IslandLayout:                parsedDisasterIslandLayout,
+		IslandMinSeparation:         *disasterIslandMinSeparation,
+		IslandPositions:             parsedDisasterIslandPositions,
+		DisasterProcess:             parsedDisasterProcess,
+		MarkovProcess:               markovProcess,
+		HawkesProcess:               hawkesProcess,
+		ReplaySchedule:              parsedDisasterReplaySchedule,
+		DeerStockSensitivity:        *disasterDeerStockSensitivity,
+		FishStockSensitivity:        *disasterFishStockSensitivity,
+		CatchProbabilityShock:       *disasterCatchProbabilityShock,
+		CatchShockDuration:          *disasterCatchShockDuration,
 	}
 
 	iigoConf := config.IIGOConfig{
 		IIGOTermLengths: map[shared.Role]uint{shared.President: *iigoTermLengthPresident,
 			shared.Speaker: *iigoTermLengthSpeaker,
 			shared.Judge:   *iigoTermLengthJudge},
+		ElectionTieBreakSeed: *iigoElectionTieBreakSeed,
 		// Executive branch
 		GetRuleForSpeakerActionCost:        shared.Resources(*iigoGetRuleForSpeakerActionCost),
 		BroadcastTaxationActionCost:        shared.Resources(*iigoBroadcastTaxationActionCost),
@@ -457,7 +904,26 @@ func parseConfig() (config.Config, error) {
 		AnnounceVotingResultActionCost: shared.Resources(*iigoAnnounceVotingResultActionCost),
 		UpdateRulesActionCost:          shared.Resources(*iigoUpdateRulesActionCost),
 		AppointNextJudgeActionCost:     shared.Resources(*iigoAppointNextJudgeActionCost),
-		StartWithRulesInPlay:           *startWithRulesInPlay,
+		// Rule vote decision procedure
+		RuleVoteQuorum:        *iigoRuleVoteQuorum,
+		RuleVoteSupermajority: *iigoRuleVoteSupermajority,
+		RuleVoteProcedure: shared.RuleVoteProcedure{
+			DecisionRule: parsedIIGORuleVoteDecisionRule,
+			Weighting:    parsedIIGORuleVoteWeighting,
+		},
+		ImmutableRuleVoteProcedure: shared.RuleVoteProcedure{
+			DecisionRule: parsedIIGOImmutableRuleVoteDecisionRule,
+			Weighting:    parsedIIGOImmutableRuleVoteWeighting,
+		},
+		RuleVoteProcedureOverrides: parsedIIGORuleVoteProcedureOverrides,
+		RejectConflictingRules:     *iigoRejectConflictingRules,
+		StartWithRulesInPlay:       *startWithRulesInPlay,
+	}
+
+	iifoConf := config.IIFOConfig{
+		PredictionSpatialTolerance:   *iifoPredictionSpatialTolerance,
+		PredictionMagnitudeTolerance: *iifoPredictionMagnitudeTolerance,
+		PredictionTimeTolerance:      *iifoPredictionTimeTolerance,
 	}
 
 	return config.Config{
@@ -471,5 +937,19 @@ func parseConfig() (config.Config, error) {
 		ForagingConfig:              foragingConf,
 		DisasterConfig:              disasterConf,
 		IIGOConfig:                  iigoConf,
+		IIFOConfig:                  iifoConf,
 	}, nil
 }
+
+// readDisasterSchedule reads the disaster schedule replayed by ReplayProcess from a CSV file
+func readDisasterSchedule(path string) ([]shared.ScheduledDisaster, error) {
+	if path == "" {
+		return nil, errors.Errorf("ReplayProcess requires a disaster schedule file")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return disasters.ParseDisasterSchedule(f)
+}
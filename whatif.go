@@ -0,0 +1,118 @@
+// +build !js
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"text/tabwriter"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+)
+
+// whatIfCommand is the subcommand replaying candidate rules against the IIGO history of a finished game
+const whatIfCommand = "whatif"
+
+// whatIfOutput is the part of output.json needed to replay rules
+type whatIfOutput struct {
+	Config struct {
+		IIGOConfig struct {
+			DefaultSanctionScore shared.IIGOSanctionsScore
+		}
+	}
+	GameStates []struct {
+		IIGOHistory map[uint][]shared.Accountability
+		RulesInfo   struct {
+			VariableMap map[rules.VariableFieldName]rules.VariableValuePair
+		}
+	}
+}
+
+// runWhatIf reports which islands would have broken each candidate rule on which turns of a recorded game, and the
+// sanctions that would have resulted. Rule matrices are not recorded in output.json, so links to rules other than the
+// candidates refer to the registered rules.
+func runWhatIf(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet(whatIfCommand, flag.ContinueOnError)
+	flags.SetOutput(w)
+	input := flags.String("input", "output/output.json", "The output.json of the game to replay the rules against.")
+	ruleText := flags.String("rule", "", "The candidate rules, in the rule language (see internal/common/rules/ruledsl.go).")
+	ruleFile := flags.String("ruleFile", "", "A file holding the candidate rules, instead of -rule.")
+	severity := flags.Int("severity", -1, "Sanction score of breaking a candidate rule. Defaults to the game's iigoDefaultSanctionScore.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	text := *ruleText
+	if *ruleFile != "" {
+		buf, err := ioutil.ReadFile(*ruleFile)
+		if err != nil {
+			return errors.Errorf("Failed to read rules: %v", err)
+		}
+		text = string(buf)
+	}
+	candidates, err := rules.ParseRules(text)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return errors.Errorf("No candidate rules given, use -rule or -ruleFile")
+	}
+
+	buf, err := ioutil.ReadFile(*input)
+	if err != nil {
+		return errors.Errorf("Failed to read game output: %v", err)
+	}
+	var o whatIfOutput
+	if err := json.Unmarshal(buf, &o); err != nil {
+		return errors.Errorf("Failed to parse game output: %v", err)
+	}
+	if len(o.GameStates) == 0 {
+		return errors.Errorf("No game states in '%v'", *input)
+	}
+	finalState := o.GameStates[len(o.GameStates)-1]
+	if *severity < 0 {
+		*severity = int(o.Config.IIGOConfig.DefaultSanctionScore)
+	}
+
+	rulesCache, _ := rules.InitialRuleRegistration(false)
+	for _, candidate := range candidates {
+		rulesCache[candidate.RuleName] = candidate
+	}
+	variableCache := rules.InitialVarRegistration()
+	for name, pair := range finalState.RulesInfo.VariableMap {
+		variableCache[name] = pair
+	}
+
+	for _, candidate := range candidates {
+		sanctions, err := shared.ReplayRuleSanctions(candidate, rulesCache, finalState.IIGOHistory, variableCache, shared.IIGOSanctionsScore(*severity), shared.DefaultSanctionThresholds())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Rule '%v': broken %v times\n", candidate.RuleName, countBreaches(sanctions))
+		if len(sanctions) == 0 {
+			continue
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "Turn\tIsland\tBreaches\tScore\tSanction")
+		for _, sanction := range sanctions {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", sanction.Turn, sanction.ClientID, sanction.Breaches, sanction.Score, sanction.SanctionTier)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func countBreaches(sanctions []shared.CounterfactualSanction) int {
+	breaches := 0
+	for _, sanction := range sanctions {
+		breaches += sanction.Breaches
+	}
+	return breaches
+}